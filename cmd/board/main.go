@@ -9,22 +9,35 @@ package main
 // @name Authorization
 // @host localhost:8080
 import (
+	"context"
 	"log/slog"
 	"os"
 
 	_ "github.com/wehw93/kanban-board/docs"
 	"github.com/wehw93/kanban-board/internal/config"
+	"github.com/wehw93/kanban-board/internal/events"
 	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/lib/mailer"
+	"github.com/wehw93/kanban-board/internal/lib/webhook"
+	"github.com/wehw93/kanban-board/internal/service/analytics"
 	"github.com/wehw93/kanban-board/internal/service/auth"
 	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/service/transfer"
 	"github.com/wehw93/kanban-board/internal/storage/postgresql"
 	server "github.com/wehw93/kanban-board/internal/transport/http"
+	"github.com/wehw93/kanban-board/internal/worker"
 )
 
 const (
 	jwtSecret = "secret"
 	env_local = "local"
 	env_prod  = "prod"
+
+	events_local    = "local"
+	events_postgres = "postgres"
+
+	mailer_file = "file"
+	mailer_smtp = "smtp"
 )
 
 func main() {
@@ -37,14 +50,65 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	log.Info("postgres port: ", cfg.DB.Port)
+	log.Info("postgres port", slog.String("port", cfg.DB.Port))
 	defer store.Close()
 
 	svcAuth := auth.NewService(jwtSecret)
 
-	svcBoard := board.NewService(store, jwtSecret)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bus events.Bus
+
+	switch cfg.Events.Bus {
+	case events_local:
+		bus = events.NewHub()
+	case events_postgres:
+		pgBus, err := events.NewPGBus(cfg.DB.GetDSN(), store.Event().GetByID, log)
+		if err != nil {
+			panic(err)
+		}
+		defer pgBus.Close()
+		go pgBus.Listen(ctx)
+		bus = pgBus
+	default:
+		log.Error("unknown events bus", slog.String("bus", cfg.Events.Bus))
+		os.Exit(1)
+	}
+
+	sender := webhook.NewSender(cfg.Webhooks.Timeout, cfg.Webhooks.MaxAttempts, cfg.Webhooks.BackoffBase, cfg.Webhooks.BackoffMax, cfg.Webhooks.AllowPrivate)
+
+	var mail mailer.Mailer
+
+	switch cfg.Mail.Mailer {
+	case mailer_file:
+		mail, err = mailer.NewFileSink(cfg.Mail.Dir, cfg.Mail.From)
+	case mailer_smtp:
+		mail, err = mailer.NewSMTP(cfg.Mail.SMTP.Host, cfg.Mail.SMTP.Port, cfg.Mail.SMTP.Username, cfg.Mail.SMTP.Password, cfg.Mail.From)
+	default:
+		log.Error("unknown mailer", slog.String("mailer", cfg.Mail.Mailer))
+		os.Exit(1)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	svcBoard := board.NewService(store, jwtSecret, cfg.Trash.UndoWindow, bus, sender, mail, cfg.Mail.BaseURL)
+
+	go worker.Run(ctx, log, worker.NewRetention(svcBoard, cfg.Archive.Retention, log), cfg.Archive.PurgeInterval)
+	go worker.Run(ctx, log, worker.NewTrashCleanup(svcBoard, log), cfg.Trash.PurgeInterval)
+	go worker.Run(ctx, log, worker.NewEventCleanup(svcBoard, cfg.Events.Retention, log), cfg.Events.PurgeInterval)
+	go worker.Run(ctx, log, worker.NewWebhookDispatcher(svcBoard, log), cfg.Webhooks.PollInterval)
+	go worker.Run(ctx, log, worker.NewDigestSender(svcBoard, log), cfg.Mail.DigestInterval)
+	go worker.Run(ctx, log, worker.NewRecurringTasks(svcBoard, log), cfg.Recurring.PollInterval)
+
+	svcAnalytics := analytics.NewService(store)
+
+	go worker.Run(ctx, log, worker.NewColumnSnapshots(svcAnalytics, log), cfg.Analytics.SnapshotInterval)
+
+	svcTransfer := transfer.NewService(store)
 
-	srv := server.NewServer(cfg, log, svcBoard, svcAuth)
+	srv := server.NewServer(cfg, log, svcBoard, svcAuth, svcAnalytics, svcTransfer)
 
 	srv.InitRoutes()
 
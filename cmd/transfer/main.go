@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/wehw93/kanban-board/internal/config"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service/transfer"
+	"github.com/wehw93/kanban-board/internal/storage/postgresql"
+)
+
+const usage = `usage:
+  transfer export --project=ID [--format=json|csv] [--out=FILE]
+  transfer import --user=EMAIL --file=FILE [--format=json|trello|jira] [--name=NAME] [--dry-run]`
+
+func main() {
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "export":
+		export(os.Args[2:])
+	case "import":
+		importProject(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func export(args []string) {
+
+	var (
+		projectID int
+		format    string
+		out       string
+	)
+
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.IntVar(&projectID, "project", 0, "id of the project to export")
+	fs.StringVar(&format, "format", transfer.FormatJSON, "json for the whole project, csv for its tasks")
+	fs.StringVar(&out, "out", "", "file to write, standard output when empty")
+	fs.Parse(args)
+
+	if projectID == 0 {
+		log.Fatal("--project is required")
+	}
+
+	cfg := config.MustLoad()
+
+	store, err := postgresql.New(cfg.DB.GetDSN())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer store.Close()
+
+	svc := transfer.NewService(store)
+
+	var f *os.File
+
+	// The file is only created once the project was found.
+	err = svc.ExportProject(projectID, format, func(*model.ProjectExport) (io.Writer, error) {
+		if out == "" {
+			return os.Stdout, nil
+		}
+		var err error
+		f, err = os.Create(out)
+		return f, err
+	})
+	if f != nil {
+		f.Close()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func importProject(args []string) {
+
+	var (
+		email  string
+		file   string
+		format string
+		name   string
+		dryRun bool
+	)
+
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.StringVar(&email, "user", "", "email of the user the project is created for")
+	fs.StringVar(&file, "file", "", "file to import")
+	fs.StringVar(&format, "format", transfer.FormatJSON, "json for our own export, trello or jira")
+	fs.StringVar(&name, "name", "", "project name instead of the one in the file")
+	fs.BoolVar(&dryRun, "dry-run", false, "only report what would be created")
+	fs.Parse(args)
+
+	if email == "" || file == "" {
+		log.Fatal("--user and --file are required")
+	}
+
+	cfg := config.MustLoad()
+
+	store, err := postgresql.New(cfg.DB.GetDSN())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer store.Close()
+
+	user, err := store.User().GetByEmail(email)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	report, err := transfer.NewService(store).Import(user.ID, format, f, name, dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(report)
+}
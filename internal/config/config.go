@@ -14,14 +14,89 @@ type Config struct {
 	Env         string      `yaml:"env" env-default:"prod"`
 	HTTP_Server HTTP_Server `yaml:"http_server"`
 	DB          DB          `yaml:"db"`
+	Archive     Archive     `yaml:"archive"`
+	Trash       Trash       `yaml:"trash"`
+	Events      Events      `yaml:"events"`
+	Webhooks    Webhooks    `yaml:"webhooks"`
+	Mail        Mail        `yaml:"mail"`
+	Analytics   Analytics   `yaml:"analytics"`
+	Recurring   Recurring   `yaml:"recurring"`
 }
 
 type HTTP_Server struct {
-	Address     string        `yaml"address" env-default:"0.0.0.0:8080"`
+	Address     string        `yaml:"address" env-default:"0.0.0.0:8080"`
 	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+type Archive struct {
+	// Retention is how long archived projects, columns and tasks are kept before they are purged.
+	Retention     time.Duration `yaml:"retention" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+type Trash struct {
+	// UndoWindow is how long a permanently deleted column or task can be restored.
+	UndoWindow    time.Duration `yaml:"undo_window" env-default:"24h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+type Events struct {
+	// Bus is "local" for a single instance or "postgres" to share events
+	// between instances through LISTEN/NOTIFY.
+	Bus string `yaml:"bus" env-default:"local"`
+	// Retention is how long events are kept for clients resuming after a reconnect.
+	Retention     time.Duration `yaml:"retention" env-default:"168h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+	PingInterval  time.Duration `yaml:"ping_interval" env-default:"30s"`
+}
+
+type Webhooks struct {
+	// PollInterval is how often the outbox is checked for due deliveries.
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"5s"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+	// MaxAttempts is how many times a delivery is tried before it is marked failed.
+	MaxAttempts int           `yaml:"max_attempts" env-default:"8"`
+	BackoffBase time.Duration `yaml:"backoff_base" env-default:"30s"`
+	BackoffMax  time.Duration `yaml:"backoff_max" env-default:"6h"`
+	// AllowPrivate lets webhooks reach loopback and private addresses, for
+	// receivers running next to a development server only.
+	AllowPrivate bool `yaml:"allow_private" env-default:"false"`
+}
+
+type Mail struct {
+	// Mailer is "file" to write emails to Dir instead of sending them, or
+	// "smtp".
+	Mailer string `yaml:"mailer" env-default:"file"`
+	From   string `yaml:"from" env-default:"Kanban Board <board@localhost>"`
+	Dir    string `yaml:"dir" env-default:"mail"`
+	SMTP   SMTP   `yaml:"smtp"`
+	// BaseURL is the address users reach the server at, links in emails
+	// point there.
+	BaseURL string `yaml:"base_url" env-default:"http://localhost:8080"`
+	// DigestInterval is how often due digests are looked for.
+	DigestInterval time.Duration `yaml:"digest_interval" env-default:"15m"`
+}
+
+type SMTP struct {
+	Host     string `yaml:"host" env-default:"localhost"`
+	Port     string `yaml:"port" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+}
+
+type Analytics struct {
+	// SnapshotInterval is how often the column counts of the current day
+	// are recorded, the last record of a day is the one kept.
+	SnapshotInterval time.Duration `yaml:"snapshot_interval" env-default:"1h"`
+}
+
+type Recurring struct {
+	// PollInterval is how often task templates are checked for due repeats,
+	// a task shows up at most this late.
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1m"`
+}
+
 type DB struct {
 	Host     string `yaml:"host" env-default:"board_db"`
 	Port     string `yaml:"port" env-default:"5432"`
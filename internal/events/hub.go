@@ -0,0 +1,119 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+// subscriberBuffer is how many events a subscriber may lag behind before it
+// is dropped. A dropped client reconnects and resumes from its last event.
+const subscriberBuffer = 64
+
+// Bus delivers stored events to the clients watching their project.
+// Publish doesn't deliver to anyone who subscribes after it returns.
+type Bus interface {
+	Publish(event model.Event) error
+	Subscribe(projectID int64) *Subscription
+}
+
+// Hub is the in-process Bus, it fans project events out to the subscribers
+// of the project within this process. Events are stored before they are
+// published, so the hub keeps nothing itself: resuming clients catch up
+// from the storage.
+type Hub struct {
+	mu       sync.Mutex
+	projects map[int64]map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	hub       *Hub
+	projectID int64
+	ch        chan model.Event
+	once      sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		projects: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+// Publish delivers the event to the subscribers of its project. It never
+// blocks, subscribers that can't keep up are dropped.
+func (h *Hub) Publish(event model.Event) error {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.projects[event.ID_project] {
+		select {
+		case sub.ch <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+
+	return nil
+}
+
+// DropAll drops every subscriber, e.g. when events might have been missed.
+// Clients reconnect and catch up from the storage.
+func (h *Hub) DropAll() {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subs := range h.projects {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe starts delivering the events of the project published from now on.
+func (h *Hub) Subscribe(projectID int64) *Subscription {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.projects[projectID]
+	if !ok {
+		subs = make(map[*Subscription]struct{})
+		h.projects[projectID] = subs
+	}
+
+	sub := &Subscription{
+		hub:       h,
+		projectID: projectID,
+		ch:        make(chan model.Event, subscriberBuffer),
+	}
+	subs[sub] = struct{}{}
+
+	return sub
+}
+
+func (h *Hub) remove(sub *Subscription) {
+
+	if subs, ok := h.projects[sub.projectID]; ok {
+		delete(subs, sub)
+		if len(subs) == 0 {
+			delete(h.projects, sub.projectID)
+		}
+	}
+
+	sub.once.Do(func() { close(sub.ch) })
+}
+
+// Events is closed when the subscription is closed or dropped.
+func (s *Subscription) Events() <-chan model.Event {
+	return s.ch
+}
+
+func (s *Subscription) Close() {
+
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
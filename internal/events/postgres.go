@@ -0,0 +1,147 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+// notifyChannel is the Postgres channel the instances announce events on.
+const notifyChannel = "board_events"
+
+const (
+	minReconnect = 1 * time.Second
+	maxReconnect = 30 * time.Second
+	// listenerPing checks the listening connection when it's been quiet.
+	listenerPing = 90 * time.Second
+)
+
+// EventLoader reads a stored event by its ID.
+type EventLoader func(id int64) (*model.Event, error)
+
+// PGBus is the Bus for several instances sharing one database. Publish
+// announces the ID of a stored event with NOTIFY, every instance, this one
+// included, loads it and fans it out to its own subscribers. IDs are sent
+// instead of events because a NOTIFY payload is limited to 8000 bytes.
+type PGBus struct {
+	db       *sql.DB
+	listener *pq.Listener
+	hub      *Hub
+	load     EventLoader
+	log      *slog.Logger
+}
+
+func NewPGBus(dsn string, load EventLoader, log *slog.Logger) (*PGBus, error) {
+
+	const op = "events.NewPGBus"
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	b := &PGBus{
+		db:   db,
+		hub:  NewHub(),
+		load: load,
+		log:  log.With(slog.String("component", "events.pgbus")),
+	}
+
+	b.listener = pq.NewListener(dsn, minReconnect, maxReconnect, b.reportProblem)
+
+	if err := b.listener.Listen(notifyChannel); err != nil {
+		b.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b, nil
+}
+
+func (b *PGBus) Publish(event model.Event) error {
+
+	const op = "events.PGBus.Publish"
+
+	_, err := b.db.Exec("SELECT pg_notify($1, $2)", notifyChannel, strconv.FormatInt(event.ID, 10))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (b *PGBus) Subscribe(projectID int64) *Subscription {
+	return b.hub.Subscribe(projectID)
+}
+
+// Listen delivers the announced events until ctx is done.
+func (b *PGBus) Listen(ctx context.Context) {
+
+	ticker := time.NewTicker(listenerPing)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case n := <-b.listener.Notify:
+			if n == nil {
+				// The connection was lost and restored, whatever was
+				// announced meanwhile is gone. Dropped clients resume
+				// from the storage.
+				b.log.Warn("listener reconnected, dropping subscribers")
+				b.hub.DropAll()
+				continue
+			}
+			b.deliver(n.Extra)
+		case <-ticker.C:
+			if err := b.listener.Ping(); err != nil {
+				b.log.Warn("listener ping failed", sl.Err(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *PGBus) deliver(payload string) {
+
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		b.log.Warn("bad event notification", slog.String("payload", payload))
+		return
+	}
+
+	event, err := b.load(id)
+	if err != nil {
+		// most likely purged already, nobody could resume from it anyway
+		b.log.Warn("failed to load event", slog.Int64("id", id), sl.Err(err))
+		return
+	}
+
+	b.hub.Publish(*event)
+}
+
+func (b *PGBus) reportProblem(ev pq.ListenerEventType, err error) {
+	if err != nil {
+		b.log.Warn("listener problem", slog.Int("event", int(ev)), sl.Err(err))
+	}
+}
+
+func (b *PGBus) Close() {
+
+	if b.listener != nil {
+		b.listener.Close()
+	}
+
+	b.db.Close()
+}
@@ -0,0 +1,36 @@
+package commitref
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var (
+	// closing matches a closing keyword with the task references after it:
+	// "fixes #12", "Closes: #3, #4 and #5".
+	closing = regexp.MustCompile(`(?i)\b(?:fix(?:e[sd])?|close[sd]?|resolve[sd]?):?\s+(#\d+(?:\s*(?:,|\band\b)?\s*#\d+)*)`)
+	ref     = regexp.MustCompile(`#(\d+)`)
+)
+
+// Closed returns the IDs of the tasks a commit message says it closes, in
+// the order they are mentioned and without repeats.
+func Closed(message string) []int64 {
+
+	var (
+		ids  []int64
+		seen = make(map[int64]bool)
+	)
+
+	for _, m := range closing.FindAllStringSubmatch(message, -1) {
+		for _, r := range ref.FindAllStringSubmatch(m[1], -1) {
+			id, err := strconv.ParseInt(r[1], 10, 64)
+			if err != nil || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
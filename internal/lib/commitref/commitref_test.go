@@ -0,0 +1,49 @@
+package commitref
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClosed(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		message string
+		want    []int64
+	}{
+		{"fix", "fix #1", []int64{1}},
+		{"fixes", "fixes #12", []int64{12}},
+		{"fixed", "fixed #3", []int64{3}},
+		{"close", "close #4", []int64{4}},
+		{"closes", "Closes #5", []int64{5}},
+		{"closed", "closed #6", []int64{6}},
+		{"resolve", "resolve #7", []int64{7}},
+		{"resolves", "RESOLVES #8", []int64{8}},
+		{"resolved", "resolved #9", []int64{9}},
+		{"colon", "Fixes: #10", []int64{10}},
+		{"in a sentence", "Handle empty names, fixes #11 for good", []int64{11}},
+		{"list with commas", "closes #1, #2,#3", []int64{1, 2, 3}},
+		{"list with and", "Closes: #3, #4 and #5", []int64{3, 4, 5}},
+		{"list with spaces", "fixes #1 #2", []int64{1, 2}},
+		{"several keywords", "fixes #1\n\nAlso resolves #2 and closes #3", []int64{1, 2, 3}},
+		{"repeats", "fixes #4, closes #4", []int64{4}},
+		{"order of mention", "closes #9 and #2", []int64{9, 2}},
+		{"body line", "Refactor parser\n\nFixes #42", []int64{42}},
+		{"no keyword", "see #12", nil},
+		{"refs", "refs #12", nil},
+		{"keyword inside a word", "hotfixes #12", nil},
+		{"keyword without reference", "fixes the build", nil},
+		{"number without hash", "fixes 12", nil},
+		{"reference after other text", "fixes bug #12", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Closed(tt.message); !slices.Equal(got, tt.want) {
+				t.Errorf("Closed(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
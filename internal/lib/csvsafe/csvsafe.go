@@ -0,0 +1,54 @@
+// Package csvsafe writes CSV that spreadsheets open as plain text: a cell
+// that starts like a formula is prefixed with a quote, so user input such
+// as a task named "=HYPERLINK(...)" is shown rather than evaluated.
+package csvsafe
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// formulaStart are the characters spreadsheets read a formula from, tab and
+// carriage return included since some of them skip those first.
+const formulaStart = "=+-@\t\r"
+
+// Cell returns s as it is safe to put in a spreadsheet.
+func Cell(s string) string {
+	if s != "" && strings.ContainsRune(formulaStart, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// Writer is a csv.Writer that escapes every cell it writes.
+type Writer struct {
+	*csv.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{Writer: csv.NewWriter(w)}
+}
+
+func (w *Writer) Write(record []string) error {
+
+	safe := make([]string, len(record))
+	for i, s := range record {
+		safe[i] = Cell(s)
+	}
+
+	return w.Writer.Write(safe)
+}
+
+func (w *Writer) WriteAll(records [][]string) error {
+
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
@@ -0,0 +1,50 @@
+package csvsafe
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCell(t *testing.T) {
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"=1+2", "'=1+2"},
+		{"+1", "'+1"},
+		{"-1", "'-1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=b", "a=b"},
+		{" =1", " =1"},
+	}
+
+	for _, tt := range tests {
+		if got := Cell(tt.in); got != tt.want {
+			t.Errorf("Cell(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+
+	if err := w.Write([]string{"name", "note"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteAll([][]string{{`=HYPERLINK("http://x")`, "ok"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "name,note\n\"'=HYPERLINK(\"\"http://x\"\")\",ok\n"
+	if got := buf.String(); got != want {
+		t.Errorf("wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+// Encode turns the position of the last returned row into an opaque token
+// clients send back to get the next page.
+func Encode(position any) (string, error) {
+
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode reads a token made by Encode into position.
+func Decode(token string, position any) error {
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalid
+	}
+
+	if err := json.Unmarshal(data, position); err != nil {
+		return ErrInvalid
+	}
+
+	return nil
+}
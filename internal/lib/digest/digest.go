@@ -0,0 +1,89 @@
+package digest
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/wehw93/kanban-board/internal/lib/mailer"
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var funcs = map[string]any{
+	"date": func(d model.DigestTask) string {
+		if !d.Due_date.Valid {
+			return ""
+		}
+		return d.Due_date.Time.Format("Jan 2")
+	},
+}
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt").Funcs(funcs).ParseFS(templates, "templates/digest.txt"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(funcs).ParseFS(templates, "templates/digest.html"))
+)
+
+type view struct {
+	model.Digest
+	Unsubscribe string
+}
+
+// Render builds the digest email to the given address. unsubscribe is the
+// link that turns digests off for the user.
+func Render(to string, d model.Digest, unsubscribe string) (mailer.Message, error) {
+
+	v := view{
+		Digest:      d,
+		Unsubscribe: unsubscribe,
+	}
+
+	var text, html bytes.Buffer
+
+	if err := textTemplate.Execute(&text, v); err != nil {
+		return mailer.Message{}, err
+	}
+
+	if err := htmlTemplate.Execute(&html, v); err != nil {
+		return mailer.Message{}, err
+	}
+
+	return mailer.Message{
+		To:          to,
+		Subject:     "Your " + d.Frequency + " board digest: " + summary(d),
+		Text:        text.String(),
+		HTML:        html.String(),
+		Unsubscribe: unsubscribe,
+	}, nil
+}
+
+func summary(d model.Digest) string {
+
+	var parts []string
+
+	if n := len(d.Overdue); n > 0 {
+		parts = append(parts, plural(n, "overdue task", "overdue tasks"))
+	}
+	if n := len(d.Assigned); n > 0 {
+		parts = append(parts, plural(n, "open task", "open tasks"))
+	}
+	if n := len(d.Changed); n > 0 {
+		parts = append(parts, plural(n, "change", "changes"))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one string, many string) string {
+
+	if n == 1 {
+		return "1 " + one
+	}
+
+	return strconv.Itoa(n) + " " + many
+}
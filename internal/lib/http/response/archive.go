@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type ArchiveResponse struct {
+	ProjectID uint           `json:"id_project"`
+	Columns   []ArchivedItem `json:"columns"`
+	Tasks     []ArchivedItem `json:"tasks"`
+}
+
+type ArchivedItem struct {
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	ColumnID   uint      `json:"id_column,omitempty"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
@@ -0,0 +1,27 @@
+package response
+
+type BoardResponse struct {
+	ID        uint          `json:"id"`
+	Name      string        `json:"name"`
+	ProjectID uint          `json:"id_project"`
+	GroupBy   string        `json:"group_by"`
+	Columns   []ColumnBrief `json:"columns"`
+	Lanes     []BoardLane   `json:"lanes"`
+}
+
+// BoardLane is a row of the board, Cells go in the same order as Columns.
+type BoardLane struct {
+	Key   string      `json:"key"`
+	Name  string      `json:"name"`
+	Cells []BoardCell `json:"cells"`
+}
+
+type BoardCell struct {
+	ColumnID int         `json:"id_column"`
+	Tasks    []TaskBrief `json:"tasks"`
+}
+
+type BoardBrief struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
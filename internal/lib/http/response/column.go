@@ -1,7 +1,18 @@
 package response
 
 type ReadColumnResponse struct {
-	ID    int         `json:"id"`
-	Name  string      `json:"name"`
-	Tasks []TaskBrief `json:"tasks"`
+	ID        int         `json:"id"`
+	BoardID   int         `json:"id_board"`
+	Name      string      `json:"name"`
+	TaskCount int         `json:"task_count"`
+	WIPLimit  *int        `json:"wip_limit"`
+	Tasks     []TaskBrief `json:"tasks"`
+}
+
+type ColumnBrief struct {
+	ID        int    `json:"id"`
+	BoardID   int    `json:"id_board"`
+	Name      string `json:"name"`
+	TaskCount int    `json:"task_count"`
+	WIPLimit  *int   `json:"wip_limit"`
 }
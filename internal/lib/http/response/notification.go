@@ -0,0 +1,14 @@
+package response
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type Notifications struct {
+	// Unread counts all unread notifications of the user, not only the
+	// ones on this page.
+	Unread int                  `json:"unread"`
+	Items  []model.Notification `json:"items"`
+}
+
+type MarkedRead struct {
+	Marked int64 `json:"marked"`
+}
@@ -1,8 +1,11 @@
 package response
 
 type ReadProjectResponse struct {
-	ID          uint        `json:"id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Tasks       []TaskBrief `json:"tasks"`
+	ID          uint          `json:"id"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description"`
+	Boards      []BoardBrief  `json:"boards"`
+	Columns     []ColumnBrief `json:"columns"`
+	Tasks       []TaskBrief   `json:"tasks"`
 }
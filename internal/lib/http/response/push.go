@@ -0,0 +1,24 @@
+package response
+
+type PushHook struct {
+	ProjectID uint `json:"id_project"`
+	// Path is where on this server the git host sends pushes.
+	Path   string `json:"path"`
+	Secret string `json:"secret"`
+}
+
+type PushResult struct {
+	// Ignored is true for requests that aren't pushes, e.g. pings.
+	Ignored bool      `json:"ignored"`
+	Commits int       `json:"commits"`
+	Refs    []PushRef `json:"refs"`
+}
+
+// PushRef is a task a commit says it closes and what came of it.
+type PushRef struct {
+	Commit string `json:"commit"`
+	TaskID int64  `json:"id_task"`
+	Moved  bool   `json:"moved"`
+	// Reason tells why the task wasn't moved.
+	Reason string `json:"reason,omitempty"`
+}
@@ -4,6 +4,8 @@ type SuccessResponse struct {
 	Status  int         `json:"status"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	// NextCursor is set on paginated lists that have more pages.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,15 @@
+package response
+
+type SearchHit struct {
+	ID         uint    `json:"id"`
+	ProjectID  uint    `json:"id_project"`
+	ColumnID   uint    `json:"id_column"`
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Priority   string  `json:"priority"`
+	ExecutorID *int    `json:"id_executor"`
+	CreatedAt  string  `json:"date_of_create"`
+	Rank       float32 `json:"rank"`
+	// Snippet is the matched text with the found words wrapped in <mark> tags.
+	Snippet string `json:"snippet"`
+}
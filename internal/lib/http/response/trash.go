@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type TrashItem struct {
+	ID        uint   `json:"id"`
+	Kind      string `json:"kind" enums:"column,task"`
+	ProjectID uint   `json:"id_project"`
+	Name      string `json:"name"`
+	DeletedBy *int   `json:"deleted_by"`
+	// TaskCount is the number of tasks deleted along with a column.
+	TaskCount int       `json:"task_count,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// UndoUntil is the moment after which the item can't be restored anymore.
+	UndoUntil time.Time `json:"undo_until"`
+}
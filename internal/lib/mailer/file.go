@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileSink writes every message to its own .eml file instead of sending
+// it, for local runs and tests.
+type FileSink struct {
+	dir  string
+	from string
+	seq  atomic.Int64
+}
+
+func NewFileSink(dir string, from string) (*FileSink, error) {
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileSink{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+func (f *FileSink) Send(ctx context.Context, msg Message) error {
+
+	data, err := compose(f.from, msg)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d-%s.eml",
+		time.Now().UTC().Format("20060102T150405"),
+		f.seq.Add(1),
+		strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To),
+	)
+
+	return os.WriteFile(filepath.Join(f.dir, name), data, 0o644)
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+)
+
+// SMTP sends messages through a mail server, with PLAIN auth when a
+// username is set.
+type SMTP struct {
+	addr string
+	from string
+	// sender is the bare address of from for the SMTP envelope.
+	sender string
+	auth   smtp.Auth
+}
+
+func NewSMTP(host string, port string, username string, password string, from string) (*SMTP, error) {
+
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SMTP{
+		addr:   net.JoinHostPort(host, port),
+		from:   from,
+		sender: sender.Address,
+	}
+
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s, nil
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+
+	data, err := compose(s.from, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(s.addr, s.auth, s.sender, []string{msg.To}, data)
+}
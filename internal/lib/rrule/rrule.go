@@ -0,0 +1,327 @@
+// Package rrule reads the subset of iCalendar recurrence rules (RFC 5545)
+// used by recurring tasks, e.g.
+//
+//	FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20261231
+//
+// FREQ is DAILY, WEEKLY or MONTHLY. INTERVAL is the number of days, weeks
+// or months between repeats, 1 by default. BYDAY lists the weekdays of a
+// daily or weekly rule, weeks start on Monday. UNTIL ends the rule at a
+// date or a UTC time, COUNT after a number of repeats. Rules are evaluated
+// in UTC from the moment the series starts.
+package rrule
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// MaxInterval keeps a typo from pushing the next repeat centuries away.
+const MaxInterval = 1000
+
+const (
+	untilTime = "20060102T150405Z"
+	untilDate = "20060102"
+)
+
+// Weekdays come back every 7 days, and the months with a given day, the
+// 29th of February included, every 400 years. The search for the next
+// repeat stops once its steps have gone through a whole cycle: a rule that
+// has not matched by then never does.
+const (
+	dayCycle   = 7
+	monthCycle = 400 * 12
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type Rule struct {
+	Freq     string
+	Interval int
+	// ByDay is sorted from Monday to Sunday, empty repeats on the weekday
+	// the series starts on.
+	ByDay []time.Weekday
+	// Until is zero for rules without an end date.
+	Until time.Time
+	// Count is 0 for rules without a number of repeats.
+	Count int
+}
+
+type Error struct {
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return "invalid recurrence rule: " + e.Msg
+}
+
+func invalid(format string, args ...any) error {
+	return &Error{Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parse reads a rule with or without the "RRULE:" prefix.
+func Parse(input string) (Rule, error) {
+
+	input = strings.TrimSpace(input)
+	if len(input) >= 6 && strings.EqualFold(input[:6], "RRULE:") {
+		input = input[6:]
+	}
+
+	if input == "" {
+		return Rule{}, invalid("empty rule")
+	}
+
+	r := Rule{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(input, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+
+		if !ok || key == "" || value == "" {
+			return Rule{}, invalid("%q is not a KEY=VALUE pair", part)
+		}
+		if seen[key] {
+			return Rule{}, invalid("%s is given twice", key)
+		}
+		seen[key] = true
+
+		var err error
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case Daily, Weekly, Monthly:
+				r.Freq = value
+			default:
+				err = invalid("FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(key, value, MaxInterval)
+		case "COUNT":
+			r.Count, err = positive(key, value, 0)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseDays(value)
+		default:
+			err = invalid("%s is not supported", key)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if r.Freq == "" {
+		return Rule{}, invalid("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return Rule{}, invalid("UNTIL and COUNT can't be used together")
+	}
+	if len(r.ByDay) > 0 && r.Freq == Monthly {
+		return Rule{}, invalid("BYDAY is only supported with DAILY and WEEKLY")
+	}
+
+	return r, nil
+}
+
+func positive(key string, value string, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, invalid("%s must be a positive number", key)
+	}
+	if max > 0 && n > max {
+		return 0, invalid("%s must be at most %d", key, max)
+	}
+	return n, nil
+}
+
+// parseUntil reads a UTC time or a date, a date includes the whole day.
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilTime, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(untilDate, value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, invalid("UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+func parseDays(value string) ([]time.Weekday, error) {
+
+	var days []time.Weekday
+
+	for _, name := range strings.Split(value, ",") {
+		day, ok := weekdays[strings.TrimSpace(name)]
+		if !ok {
+			return nil, invalid("BYDAY takes MO, TU, WE, TH, FR, SA or SU, not %q", name)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	slices.SortFunc(days, func(a, b time.Weekday) int { return offset(a) - offset(b) })
+
+	return days, nil
+}
+
+// offset is the number of days from Monday.
+func offset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String writes the rule back in its canonical form.
+func (r Rule) String() string {
+
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		names := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			names[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilTime))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first repeat of a series starting at start that comes
+// after the given moment, start itself included when it matches the rule.
+// It reports false once the rule has passed UNTIL. COUNT is left to the
+// caller, who knows how many repeats there were.
+func (r Rule) Next(start time.Time, after time.Time) (time.Time, bool) {
+
+	start = start.UTC()
+	after = after.UTC()
+
+	if after.Before(start) {
+		after = start.Add(-time.Nanosecond)
+	}
+
+	interval := max(r.Interval, 1)
+
+	var next time.Time
+
+	switch r.Freq {
+	case Daily:
+		next = r.nextDaily(start, after, interval)
+	case Weekly:
+		next = r.nextWeekly(start, after, interval)
+	case Monthly:
+		next = r.nextMonthly(start, after, interval)
+	}
+
+	if next.IsZero() || (!r.Until.IsZero() && next.After(r.Until)) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+func (r Rule) nextDaily(start time.Time, after time.Time, interval int) time.Time {
+
+	days := int(after.Sub(start)/(24*time.Hour)) / interval * interval
+
+	// The first step is not after the moment yet, a BYDAY the start never
+	// comes around to leaves the rule without a repeat.
+	for i := 0; i <= cycleSteps(dayCycle, interval); i++ {
+		t := start.AddDate(0, 0, days)
+		if t.After(after) && (len(r.ByDay) == 0 || slices.Contains(r.ByDay, t.Weekday())) {
+			return t
+		}
+		days += interval
+	}
+
+	return time.Time{}
+}
+
+func (r Rule) nextWeekly(start time.Time, after time.Time, interval int) time.Time {
+
+	byDay := r.ByDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{start.Weekday()}
+	}
+
+	monday := start.AddDate(0, 0, -offset(start.Weekday()))
+	weeks := int(after.Sub(monday)/(7*24*time.Hour)) / interval * interval
+
+	// Every weekday is in every week, the week after the first step is
+	// wholly past the moment.
+	for i := 0; i < 2; i++ {
+		for _, day := range byDay {
+			t := monday.AddDate(0, 0, 7*weeks+offset(day))
+			if !t.Before(start) && t.After(after) {
+				return t
+			}
+		}
+		weeks += interval
+	}
+
+	return time.Time{}
+}
+
+// nextMonthly repeats on the day of the month the series starts on and,
+// like RFC 5545, skips months that don't have it.
+func (r Rule) nextMonthly(start time.Time, after time.Time, interval int) time.Time {
+
+	months := ((after.Year()-start.Year())*12 + int(after.Month()) - int(start.Month())) / interval * interval
+
+	// The month of start comes back within a cycle, so there always is a
+	// repeat.
+	for i := 0; i <= cycleSteps(monthCycle, interval); i++ {
+		t := time.Date(
+			start.Year(), start.Month()+time.Month(months), start.Day(),
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(),
+			time.UTC,
+		)
+		if t.Day() == start.Day() && t.After(after) {
+			return t
+		}
+		months += interval
+	}
+
+	return time.Time{}
+}
+
+// cycleSteps is the number of steps of interval that go through the cycle
+// and back to where they started.
+func cycleSteps(cycle int, interval int) int {
+
+	a, b := cycle, interval
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return cycle / a
+}
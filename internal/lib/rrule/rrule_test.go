@@ -0,0 +1,257 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		after string
+		want  string // empty when the rule has no repeat left
+	}{
+		{
+			name:  "start itself",
+			rule:  "FREQ=DAILY",
+			start: "2026-01-07 09:00",
+			after: "2026-01-01 00:00",
+			want:  "2026-01-07 09:00",
+		},
+		{
+			name:  "daily keeps the time of day",
+			rule:  "FREQ=DAILY",
+			start: "2026-01-07 09:00",
+			after: "2026-01-07 09:00",
+			want:  "2026-01-08 09:00",
+		},
+		{
+			name:  "daily interval counts from start",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: "2026-01-07 09:00",
+			after: "2026-01-11 12:00",
+			want:  "2026-01-13 09:00",
+		},
+		{
+			name:  "daily interval on the repeat",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: "2026-01-07 09:00",
+			after: "2026-01-13 08:59",
+			want:  "2026-01-13 09:00",
+		},
+		{
+			name:  "daily by day skips other weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,FR",
+			start: "2026-01-07 09:00",
+			after: "2026-01-07 09:00",
+			want:  "2026-01-09 09:00",
+		},
+		{
+			name:  "daily interval and by day",
+			rule:  "FREQ=DAILY;INTERVAL=2;BYDAY=MO",
+			start: "2026-01-07 09:00",
+			after: "2026-01-07 09:00",
+			want:  "2026-01-19 09:00",
+		},
+		{
+			name:  "daily interval of weeks far from start",
+			rule:  "FREQ=DAILY;INTERVAL=14;BYDAY=WE",
+			start: "2026-01-07 09:00",
+			after: "2027-06-01 00:00",
+			want:  "2027-06-09 09:00",
+		},
+		{
+			// the series only lands on Tuesdays
+			name:  "daily interval of weeks never meets by day",
+			rule:  "FREQ=DAILY;INTERVAL=7;BYDAY=MO",
+			start: "2026-01-06 09:00",
+			after: "2026-01-06 09:00",
+		},
+		{
+			name:  "weekly on the start weekday",
+			rule:  "FREQ=WEEKLY",
+			start: "2026-01-07 09:00",
+			after: "2026-01-07 09:00",
+			want:  "2026-01-14 09:00",
+		},
+		{
+			name:  "weekly by day before start in the first week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start: "2026-01-07 09:00",
+			after: "2026-01-01 00:00",
+			want:  "2026-01-08 09:00",
+		},
+		{
+			name:  "weekly interval skips the odd week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start: "2026-01-07 09:00",
+			after: "2026-01-08 09:00",
+			want:  "2026-01-19 09:00",
+		},
+		{
+			name:  "weekly interval within the week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start: "2026-01-07 09:00",
+			after: "2026-01-19 09:00",
+			want:  "2026-01-22 09:00",
+		},
+		{
+			name:  "weekly interval far from start",
+			rule:  "FREQ=WEEKLY;INTERVAL=3",
+			start: "2026-01-07 09:00",
+			after: "2026-03-01 00:00",
+			want:  "2026-03-11 09:00",
+		},
+		{
+			name:  "monthly",
+			rule:  "FREQ=MONTHLY",
+			start: "2026-01-15 09:00",
+			after: "2026-01-15 09:00",
+			want:  "2026-02-15 09:00",
+		},
+		{
+			name:  "monthly skips months without the 31st",
+			rule:  "FREQ=MONTHLY",
+			start: "2026-01-31 09:00",
+			after: "2026-01-31 09:00",
+			want:  "2026-03-31 09:00",
+		},
+		{
+			name:  "monthly skips april for the 31st",
+			rule:  "FREQ=MONTHLY",
+			start: "2026-01-31 09:00",
+			after: "2026-03-31 09:00",
+			want:  "2026-05-31 09:00",
+		},
+		{
+			name:  "monthly on the 29th of february waits for a leap year",
+			rule:  "FREQ=MONTHLY;INTERVAL=12",
+			start: "2024-02-29 09:00",
+			after: "2024-02-29 09:00",
+			want:  "2028-02-29 09:00",
+		},
+		{
+			name:  "monthly on the 29th of february skips 2100",
+			rule:  "FREQ=MONTHLY;INTERVAL=12",
+			start: "2096-02-29 09:00",
+			after: "2096-02-29 09:00",
+			want:  "2104-02-29 09:00",
+		},
+		{
+			name:  "monthly large interval skips months without the 31st",
+			rule:  "FREQ=MONTHLY;INTERVAL=999",
+			start: "2026-01-31 09:00",
+			after: "2026-01-31 09:00",
+			want:  "2192-07-31 09:00",
+		},
+		{
+			name:  "monthly interval counts from start",
+			rule:  "FREQ=MONTHLY;INTERVAL=2",
+			start: "2026-01-10 09:00",
+			after: "2026-02-20 00:00",
+			want:  "2026-03-10 09:00",
+		},
+		{
+			name:  "until date includes the whole day",
+			rule:  "FREQ=DAILY;UNTIL=20260110",
+			start: "2026-01-07 23:30",
+			after: "2026-01-09 23:30",
+			want:  "2026-01-10 23:30",
+		},
+		{
+			name:  "until date ends after its day",
+			rule:  "FREQ=DAILY;UNTIL=20260110",
+			start: "2026-01-07 23:30",
+			after: "2026-01-10 23:30",
+		},
+		{
+			name:  "until time is exact",
+			rule:  "FREQ=DAILY;UNTIL=20260110T090000Z",
+			start: "2026-01-07 09:30",
+			after: "2026-01-09 09:30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+
+			got, ok := r.Next(date(tt.start), date(tt.after))
+
+			if tt.want == "" {
+				if ok {
+					t.Fatalf("Next() = %v, want no repeat", got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Next() has no repeat, want %s", tt.want)
+			}
+			if want := date(tt.want); !got.Equal(want) {
+				t.Errorf("Next() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		input string
+		want  string // canonical form, empty when the rule is invalid
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;byday=th,mo,th", "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{"FREQ=WEEKLY;INTERVAL=1", "FREQ=WEEKLY"},
+		{"FREQ=MONTHLY;INTERVAL=2;COUNT=5", "FREQ=MONTHLY;INTERVAL=2;COUNT=5"},
+		{"FREQ=DAILY;UNTIL=20261231", "FREQ=DAILY;UNTIL=20261231T235959Z"},
+		{"FREQ=DAILY;UNTIL=20261231T120000Z", "FREQ=DAILY;UNTIL=20261231T120000Z"},
+		{"", ""},
+		{"INTERVAL=2", ""},
+		{"FREQ=YEARLY", ""},
+		{"FREQ=DAILY;INTERVAL=0", ""},
+		{"FREQ=DAILY;INTERVAL=1001", ""},
+		{"FREQ=DAILY;FREQ=WEEKLY", ""},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20261231", ""},
+		{"FREQ=MONTHLY;BYDAY=MO", ""},
+		{"FREQ=WEEKLY;BYDAY=XX", ""},
+		{"FREQ=DAILY;BYHOUR=9", ""},
+		{"FREQ=DAILY;UNTIL=2026-12-31", ""},
+		{"FREQ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := Parse(tt.input)
+
+			if tt.want == "" {
+				var ruleErr *Error
+				if !errors.As(err, &ruleErr) {
+					t.Fatalf("Parse() = %v, %v, want an *Error", r, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(): %v", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
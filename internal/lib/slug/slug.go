@@ -0,0 +1,62 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxLength leaves room for the "-N" suffix that keeps slugs unique.
+const MaxLength = 64
+
+// Fallback is used for names without a single letter or digit to keep.
+const Fallback = "project"
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "h", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// Make turns a name into a lowercase ASCII slug of letters, digits and
+// single dashes. Cyrillic letters are transliterated, anything else becomes
+// a dash.
+func Make(name string) string {
+
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		var part string
+
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			part = string(r)
+		case cyrillic[r] != "":
+			part = cyrillic[r]
+		default:
+			if _, ok := cyrillic[r]; !ok {
+				dash = b.Len() > 0
+			}
+			continue
+		}
+
+		if b.Len()+len(part)+1 > MaxLength {
+			break
+		}
+
+		if dash {
+			b.WriteByte('-')
+			dash = false
+		}
+		b.WriteString(part)
+	}
+
+	if b.Len() == 0 {
+		return Fallback
+	}
+
+	return b.String()
+}
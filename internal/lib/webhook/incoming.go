@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	ProviderGitHub  = "github"
+	ProviderGitLab  = "gitlab"
+	ProviderGeneric = "generic"
+)
+
+// MaxClockSkew is how old a generic push may be, older ones are replays.
+const MaxClockSkew = 5 * time.Minute
+
+// Incoming is a push received from a git host. GitHub signs the body like
+// X-Hub-Signature-256, GitLab sends the secret itself in X-Gitlab-Token,
+// anything else has to be signed the way the board signs its own webhooks.
+type Incoming struct {
+	Provider  string
+	Event     string
+	Signature string
+	Timestamp string
+	Body      []byte
+}
+
+// Push is the part of a push payload the board uses. GitHub, GitLab and
+// generic payloads all share this shape.
+type Push struct {
+	Commits []Commit `json:"commits"`
+}
+
+type Commit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+	Author  struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+func NewIncoming(h http.Header, body []byte) Incoming {
+
+	switch {
+	case h.Get("X-GitHub-Event") != "":
+		return Incoming{
+			Provider:  ProviderGitHub,
+			Event:     h.Get("X-GitHub-Event"),
+			Signature: h.Get("X-Hub-Signature-256"),
+			Body:      body,
+		}
+	case h.Get("X-Gitlab-Event") != "":
+		return Incoming{
+			Provider:  ProviderGitLab,
+			Event:     h.Get("X-Gitlab-Event"),
+			Signature: h.Get("X-Gitlab-Token"),
+			Body:      body,
+		}
+	default:
+		event := h.Get(HeaderEvent)
+		if event == "" {
+			event = "push"
+		}
+		return Incoming{
+			Provider:  ProviderGeneric,
+			Event:     event,
+			Signature: h.Get(HeaderSignature),
+			Timestamp: h.Get(HeaderTimestamp),
+			Body:      body,
+		}
+	}
+}
+
+// Verify reports whether the push was sent by someone knowing the secret.
+func (in Incoming) Verify(secret string, now time.Time) bool {
+
+	switch in.Provider {
+	case ProviderGitHub:
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(in.Body)
+		return hmac.Equal([]byte(in.Signature), []byte("sha256="+hex.EncodeToString(mac.Sum(nil))))
+	case ProviderGitLab:
+		return subtle.ConstantTimeCompare([]byte(in.Signature), []byte(secret)) == 1
+	default:
+		timestamp, err := strconv.ParseInt(in.Timestamp, 10, 64)
+		if err != nil {
+			return false
+		}
+		if d := now.Sub(time.Unix(timestamp, 0)); d > MaxClockSkew || d < -MaxClockSkew {
+			return false
+		}
+		return hmac.Equal([]byte(in.Signature), []byte(Sign(secret, timestamp, in.Body)))
+	}
+}
+
+// IsPush reports whether the request is about pushed commits, hosts also
+// send pings and events of other kinds to the same URL.
+func (in Incoming) IsPush() bool {
+	switch in.Provider {
+	case ProviderGitHub:
+		return in.Event == "push"
+	case ProviderGitLab:
+		return in.Event == "Push Hook"
+	default:
+		return in.Event == "push"
+	}
+}
+
+func (in Incoming) Push() (Push, error) {
+
+	var push Push
+
+	err := json.Unmarshal(in.Body, &push)
+
+	return push, err
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func githubSignature(secret string, body []byte) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestIncomingVerify(t *testing.T) {
+
+	const secret = "s3cret"
+
+	body := []byte(`{"commits":[]}`)
+	now := time.Unix(1_700_000_000, 0)
+	fresh := now.Add(-time.Minute).Unix()
+	stale := now.Add(-MaxClockSkew - time.Second).Unix()
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   bool
+	}{
+		{
+			name: "github good signature",
+			header: http.Header{
+				"X-Github-Event":      {"push"},
+				"X-Hub-Signature-256": {githubSignature(secret, body)},
+			},
+			want: true,
+		},
+		{
+			name: "github bad signature",
+			header: http.Header{
+				"X-Github-Event":      {"push"},
+				"X-Hub-Signature-256": {githubSignature("other", body)},
+			},
+		},
+		{
+			name:   "github missing signature",
+			header: http.Header{"X-Github-Event": {"push"}},
+		},
+		{
+			name: "gitlab good token",
+			header: http.Header{
+				"X-Gitlab-Event": {"Push Hook"},
+				"X-Gitlab-Token": {secret},
+			},
+			want: true,
+		},
+		{
+			name: "gitlab bad token",
+			header: http.Header{
+				"X-Gitlab-Event": {"Push Hook"},
+				"X-Gitlab-Token": {"other"},
+			},
+		},
+		{
+			name: "generic good signature",
+			header: http.Header{
+				HeaderTimestamp: {strconv.FormatInt(fresh, 10)},
+				HeaderSignature: {Sign(secret, fresh, body)},
+			},
+			want: true,
+		},
+		{
+			name: "generic bad signature",
+			header: http.Header{
+				HeaderTimestamp: {strconv.FormatInt(fresh, 10)},
+				HeaderSignature: {Sign("other", fresh, body)},
+			},
+		},
+		{
+			name: "generic signature for another timestamp",
+			header: http.Header{
+				HeaderTimestamp: {strconv.FormatInt(fresh+1, 10)},
+				HeaderSignature: {Sign(secret, fresh, body)},
+			},
+		},
+		{
+			name: "generic stale timestamp",
+			header: http.Header{
+				HeaderTimestamp: {strconv.FormatInt(stale, 10)},
+				HeaderSignature: {Sign(secret, stale, body)},
+			},
+		},
+		{
+			name: "generic timestamp from the future",
+			header: http.Header{
+				HeaderTimestamp: {strconv.FormatInt(now.Add(MaxClockSkew+time.Second).Unix(), 10)},
+				HeaderSignature: {Sign(secret, now.Add(MaxClockSkew+time.Second).Unix(), body)},
+			},
+		},
+		{
+			name:   "generic missing timestamp",
+			header: http.Header{HeaderSignature: {Sign(secret, fresh, body)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewIncoming(tt.header, body)
+			if got := in.Verify(secret, now); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomingIsPush(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   bool
+	}{
+		{"github push", http.Header{"X-Github-Event": {"push"}}, true},
+		{"github ping", http.Header{"X-Github-Event": {"ping"}}, false},
+		{"gitlab push", http.Header{"X-Gitlab-Event": {"Push Hook"}}, true},
+		{"gitlab tag", http.Header{"X-Gitlab-Event": {"Tag Push Hook"}}, false},
+		{"generic default", http.Header{}, true},
+		{"generic ping", http.Header{HeaderEvent: {"ping"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIncoming(tt.header, nil).IsPush(); got != tt.want {
+				t.Errorf("IsPush() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
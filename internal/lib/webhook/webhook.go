@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Board-Event"
+	HeaderDelivery  = "X-Board-Delivery"
+	HeaderTimestamp = "X-Board-Timestamp"
+	// HeaderSignature is "sha256=" and the hex HMAC-SHA256 of the timestamp,
+	// a dot and the body, keyed with the secret of the webhook. Receivers
+	// should reject old timestamps to stop replays.
+	HeaderSignature = "X-Board-Signature"
+)
+
+// Sign returns the HeaderSignature value of a body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ErrForbiddenAddress is returned for deliveries to hosts that resolve to
+// loopback, private, link-local or otherwise internal addresses.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// sharedAddressSpace is the carrier-grade NAT range, private in practice
+// though not in net.IP.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicAddress tells whether ip may be delivered to.
+func PublicAddress(ip netip.Addr) bool {
+
+	ip = ip.Unmap()
+
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// publicOnly is a net.Dialer Control that refuses connections to internal
+// addresses. It runs on the resolved address right before connecting, so a
+// host can't pass a check and then resolve to something else.
+func publicOnly(network string, address string, _ syscall.RawConn) error {
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+
+	if !PublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// Sender posts signed deliveries and decides when failed ones are retried.
+type Sender struct {
+	client *http.Client
+	// MaxAttempts is how many times a delivery is tried before it fails.
+	MaxAttempts int
+	// BackoffBase is the wait after the first failed attempt, it doubles
+	// with every next one up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// NewSender returns a sender that only delivers to public addresses unless
+// allowPrivate is set, which is meant for receivers on a developer machine.
+// Proxies from the environment are not used, they would dial for us.
+func NewSender(timeout time.Duration, maxAttempts int, backoffBase time.Duration, backoffMax time.Duration, allowPrivate bool) *Sender {
+
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = publicOnly
+	}
+
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			// a redirect would send the signed body somewhere not configured
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		MaxAttempts: maxAttempts,
+		BackoffBase: backoffBase,
+		BackoffMax:  backoffMax,
+	}
+}
+
+// Send posts the body to url. Any response but 2xx is an error, the status
+// code is returned whenever there was a response.
+func (s *Sender) Send(ctx context.Context, url string, secret string, deliveryID int64, eventType string, body []byte) (int, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kanban-board-webhook")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(deliveryID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain a little so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Retry returns how long to wait before the next attempt once attempts
+// have failed, ok is false when the delivery should be given up.
+func (s *Sender) Retry(attempts int) (wait time.Duration, ok bool) {
+
+	if attempts >= s.MaxAttempts {
+		return 0, false
+	}
+
+	wait = s.BackoffBase
+	for i := 1; i < attempts && wait < s.BackoffMax; i++ {
+		wait *= 2
+	}
+
+	return min(wait, s.BackoffMax), true
+}
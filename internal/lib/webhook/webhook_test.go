@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSendSignsDelivery(t *testing.T) {
+
+	body := []byte(`{"type":"ping"}`)
+
+	var got *http.Request
+	var gotBody []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := NewSender(time.Second, 3, time.Minute, time.Hour, true)
+
+	status, err := s.Send(context.Background(), srv.URL, "secret", 42, "ping", body)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	if e := got.Header.Get(HeaderEvent); e != "ping" {
+		t.Errorf("%s = %q, want ping", HeaderEvent, e)
+	}
+	if d := got.Header.Get(HeaderDelivery); d != "42" {
+		t.Errorf("%s = %q, want 42", HeaderDelivery, d)
+	}
+
+	ts, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s: %v", HeaderTimestamp, err)
+	}
+	if sig := got.Header.Get(HeaderSignature); sig != Sign("secret", ts, gotBody) {
+		t.Errorf("%s = %q does not sign the body", HeaderSignature, sig)
+	}
+	if string(gotBody) != string(body) {
+		t.Errorf("body = %q, want %q", gotBody, body)
+	}
+}
+
+func TestSendServerError(t *testing.T) {
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	s := NewSender(time.Second, 3, time.Minute, time.Hour, true)
+
+	status, err := s.Send(context.Background(), srv.URL, "secret", 1, "ping", nil)
+	if err == nil {
+		t.Fatal("Send succeeded on 502")
+	}
+	if status != http.StatusBadGateway {
+		t.Errorf("status = %d, want %d", status, http.StatusBadGateway)
+	}
+	// retries are scheduled by the worker, Send itself tries once
+	if calls != 1 {
+		t.Errorf("server called %d times, want 1", calls)
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	s := NewSender(time.Second, 3, time.Minute, time.Hour, true)
+
+	status, err := s.Send(context.Background(), srv.URL, "secret", 1, "ping", []byte("{}"))
+	if err == nil {
+		t.Fatal("Send succeeded on a redirect")
+	}
+	if status != http.StatusTemporaryRedirect {
+		t.Errorf("status = %d, want %d", status, http.StatusTemporaryRedirect)
+	}
+	if followed {
+		t.Error("redirect was followed")
+	}
+}
+
+func TestSendRefusesPrivateAddress(t *testing.T) {
+
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := NewSender(time.Second, 3, time.Minute, time.Hour, false)
+
+	_, err := s.Send(context.Background(), srv.URL, "secret", 1, "ping", nil)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("err = %v, want %v", err, ErrForbiddenAddress)
+	}
+	if called {
+		t.Error("request reached a loopback server")
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := PublicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("PublicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+
+	s := NewSender(time.Second, 5, time.Minute, 5*time.Minute, true)
+
+	tests := []struct {
+		attempts int
+		wait     time.Duration
+		ok       bool
+	}{
+		{1, time.Minute, true},
+		{2, 2 * time.Minute, true},
+		{3, 4 * time.Minute, true},
+		{4, 5 * time.Minute, true},
+		{5, 0, false},
+		{6, 0, false},
+	}
+
+	for _, tt := range tests {
+		wait, ok := s.Retry(tt.attempts)
+		if wait != tt.wait || ok != tt.ok {
+			t.Errorf("Retry(%d) = %v, %v, want %v, %v", tt.attempts, wait, ok, tt.wait, tt.ok)
+		}
+	}
+}
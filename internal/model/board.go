@@ -0,0 +1,7 @@
+package model
+
+type Board struct {
+	ID         int64
+	Name       string
+	ID_project int64
+}
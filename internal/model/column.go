@@ -1,7 +1,12 @@
 package model
 
+import "database/sql"
+
 type Column struct {
-	ID         int64
-	Name       string
-	ID_project int64
+	ID          int64
+	Name        string
+	ID_project  int64
+	ID_board    int64
+	WIP_limit   sql.NullInt64 `json:"wip_limit" swaggertype:"integer"`
+	Archived_at sql.NullTime  `json:"archived_at" swaggertype:"string" format:"date-time"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Comment struct {
+	ID      int64
+	ID_task int64
+	// ID_author is null once the author's account is deleted.
+	ID_author  sql.NullInt64 `json:"id_author" swaggertype:"integer"`
+	Body       string
+	Created_at time.Time `json:"created_at" format:"date-time"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+func ValidDigestFrequency(frequency string) bool {
+	switch frequency {
+	case DigestOff, DigestDaily, DigestWeekly:
+		return true
+	}
+	return false
+}
+
+type DigestSettings struct {
+	Frequency    string       `json:"frequency" enums:"off,daily,weekly"`
+	Last_sent_at sql.NullTime `json:"last_sent_at" swaggertype:"string" format:"date-time"`
+}
+
+// DigestRecipient is a user whose digest is due.
+type DigestRecipient struct {
+	ID_user   int64
+	Name      string
+	Email     string
+	Frequency string
+}
+
+type DigestTask struct {
+	ID       int64
+	Name     string
+	Project  string
+	Status   string
+	Priority string
+	Due_date sql.NullTime
+}
+
+// Digest sums up the tasks of a user since the previous digest.
+type Digest struct {
+	Name      string
+	Frequency string
+	Since     time.Time
+	// Assigned are the open tasks the user executes, the ones past their
+	// due date are in Overdue instead.
+	Assigned []DigestTask
+	Overdue  []DigestTask
+	// Changed are the tasks of the projects the user works in changed
+	// since the previous digest.
+	Changed []DigestTask
+}
+
+func (d Digest) Empty() bool {
+	return len(d.Assigned) == 0 && len(d.Overdue) == 0 && len(d.Changed) == 0
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+const (
+	EventTaskCreated    = "task.created"
+	EventTaskUpdated    = "task.updated"
+	EventTaskMoved      = "task.moved"
+	EventTaskAssigned   = "task.assigned"
+	EventTaskDeleted    = "task.deleted"
+	EventTaskRestored   = "task.restored"
+	EventCommentCreated = "comment.created"
+	EventColumnCreated  = "column.created"
+	EventColumnUpdated  = "column.updated"
+	EventColumnDeleted  = "column.deleted"
+	EventColumnRestored = "column.restored"
+	// EventResync tells a resuming client that some events are lost and the
+	// board has to be loaded again.
+	EventResync = "resync"
+)
+
+// EventTypes are the types of the events made by board changes.
+var EventTypes = []string{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskMoved,
+	EventTaskAssigned,
+	EventTaskDeleted,
+	EventTaskRestored,
+	EventCommentCreated,
+	EventColumnCreated,
+	EventColumnUpdated,
+	EventColumnDeleted,
+	EventColumnRestored,
+}
+
+// Event is a change on a project board pushed to the clients watching the
+// project. Data holds the task or column after the change, or only its ID
+// when it was deleted.
+type Event struct {
+	ID         int64     `json:"id"`
+	ID_project int64     `json:"id_project"`
+	Type       string    `json:"type"`
+	ID_user    int64     `json:"id_user,omitempty"`
+	Data       any       `json:"data"`
+	Created_at time.Time `json:"created_at"`
+}
@@ -0,0 +1,125 @@
+package model
+
+// ExportVersion is the version of the export document, bumped whenever a
+// field changes meaning or goes away.
+const ExportVersion = 1
+
+// ProjectExport is everything of a project but its tasks and their logs and
+// comments, which are streamed after it. IDs are the ones of the exporting server,
+// they only tie the parts of the document together.
+type ProjectExport struct {
+	Version     int                `json:"version"`
+	Exported_at string             `json:"exported_at" format:"date-time"`
+	Project     ExportedProject    `json:"project"`
+	Boards      []ExportedBoard    `json:"boards"`
+	Columns     []ExportedColumn   `json:"columns"`
+	Swimlanes   []ExportedSwimlane `json:"swimlanes"`
+	Labels      []ExportedLabel    `json:"labels"`
+}
+
+type ExportedProject struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug"`
+	Description string  `json:"description"`
+	Creator     string  `json:"creator"`
+	Archived_at *string `json:"archived_at" format:"date-time"`
+}
+
+type ExportedBoard struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type ExportedColumn struct {
+	ID          int64   `json:"id"`
+	ID_board    int64   `json:"id_board"`
+	Name        string  `json:"name"`
+	WIP_limit   *int    `json:"wip_limit"`
+	Archived_at *string `json:"archived_at" format:"date-time"`
+}
+
+type ExportedSwimlane struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type ExportedLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ExportedTask names users by email, ids don't carry over to another
+// server.
+type ExportedTask struct {
+	ID                int64   `json:"id"`
+	ID_column         int64   `json:"id_column"`
+	ID_swimlane       *int64  `json:"id_swimlane"`
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	Status            string  `json:"status"`
+	Priority          string  `json:"priority"`
+	Labels            []int64 `json:"labels"`
+	Creator           string  `json:"creator"`
+	Executor          *string `json:"executor"`
+	Date_of_create    string  `json:"date_of_create" format:"date"`
+	Date_of_execution *string `json:"date_of_execution" format:"date"`
+	Due_date          *string `json:"due_date" format:"date"`
+	Estimate          *int    `json:"estimate"`
+	Archived_at       *string `json:"archived_at" format:"date-time"`
+}
+
+type ExportedLog struct {
+	ID_task           int64  `json:"id_task"`
+	Date_of_operation string `json:"date_of_operation" format:"date"`
+	Info              string `json:"info"`
+	// Status is the status the entry moved the task to, missing in
+	// documents exported before it was recorded.
+	Status *string `json:"status"`
+}
+
+// ExportedComment names its author by email, the author is null once their
+// account is deleted.
+type ExportedComment struct {
+	ID_task    int64   `json:"id_task"`
+	Author     *string `json:"author"`
+	Body       string  `json:"body"`
+	Created_at string  `json:"created_at" format:"date-time"`
+}
+
+// ProjectDocument is the whole export document. Imports of other formats
+// are brought to it before anything is written.
+type ProjectDocument struct {
+	ProjectExport
+	Tasks    []ExportedTask    `json:"tasks"`
+	Logs     []ExportedLog     `json:"logs"`
+	Comments []ExportedComment `json:"comments"`
+}
+
+// ImportReport tells what an import created, or would create on a dry
+// run. Unknown_users have no account here: the tasks they executed are
+// left unassigned, the ones they created are credited to the importer and
+// their comments are kept without an author.
+// Renamed_columns are the lists and statuses of another tracker that were
+// renamed to in_progress or done for the board to set the status by them.
+type ImportReport struct {
+	Dry_run         bool            `json:"dry_run"`
+	ID_project      int64           `json:"id_project"`
+	Name            string          `json:"name"`
+	Slug            string          `json:"slug"`
+	Boards          int             `json:"boards"`
+	Columns         int             `json:"columns"`
+	Swimlanes       int             `json:"swimlanes"`
+	Labels          int             `json:"labels"`
+	Tasks           int             `json:"tasks"`
+	Logs            int             `json:"logs"`
+	Comments        int             `json:"comments"`
+	Unknown_users   []string        `json:"unknown_users"`
+	Renamed_columns []RenamedColumn `json:"renamed_columns"`
+}
+
+type RenamedColumn struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
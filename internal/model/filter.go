@@ -0,0 +1,14 @@
+package model
+
+import "database/sql"
+
+// Filter is a saved task query. A filter with a project only returns tasks
+// of that project and, when shared, is visible to everyone working in it.
+type Filter struct {
+	ID         int64
+	ID_user    int64
+	ID_project sql.NullInt64 `json:"id_project" swaggertype:"integer"`
+	Name       string
+	Query      string
+	Shared     bool
+}
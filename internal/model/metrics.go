@@ -0,0 +1,128 @@
+package model
+
+// TaskFlow is the way of a done task across the board. Dates are days, the
+// task log doesn't keep the time of a change.
+type TaskFlow struct {
+	ID_task    int64   `json:"id_task"`
+	Name       string  `json:"name"`
+	Created_on string  `json:"created_on" format:"date"`
+	Started_on *string `json:"started_on" format:"date"`
+	Done_on    string  `json:"done_on" format:"date"`
+	Lead_days  int     `json:"lead_days"`
+	Cycle_days *int    `json:"cycle_days"`
+}
+
+// FlowStats are in days. Count is how many tasks they are computed from.
+type FlowStats struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	P50     float64 `json:"p50"`
+	P85     float64 `json:"p85"`
+	P95     float64 `json:"p95"`
+}
+
+// FlowMetrics sums up the tasks of a project finished between From and To.
+// Lead time runs from creation to done, cycle time from the first move to
+// in progress to done; tasks that skipped in progress have no cycle time.
+type FlowMetrics struct {
+	From  string     `json:"from" format:"date"`
+	To    string     `json:"to" format:"date"`
+	Lead  FlowStats  `json:"lead_time"`
+	Cycle FlowStats  `json:"cycle_time"`
+	Tasks []TaskFlow `json:"tasks"`
+}
+
+// ColumnCount is a daily snapshot of a column.
+type ColumnCount struct {
+	Day       string
+	ID_column int64
+	ID_board  int64
+	Name      string
+	Tasks     int
+}
+
+type CFDColumn struct {
+	ID_column int64  `json:"id_column"`
+	ID_board  int64  `json:"id_board"`
+	Name      string `json:"name"`
+}
+
+// CFDDay holds the task count of every column of the diagram on the day,
+// in the order of CumulativeFlow.Columns.
+type CFDDay struct {
+	Day    string `json:"day" format:"date"`
+	Counts []int  `json:"counts"`
+}
+
+// CumulativeFlow is the data of a cumulative flow diagram. Days without a
+// snapshot are left out.
+type CumulativeFlow struct {
+	From    string      `json:"from" format:"date"`
+	To      string      `json:"to" format:"date"`
+	Columns []CFDColumn `json:"columns"`
+	Days    []CFDDay    `json:"days"`
+}
+
+// ReportFilter narrows a report to the tasks with a label or an executor,
+// 0 doesn't filter.
+type ReportFilter struct {
+	ID_label    int
+	ID_executor int
+}
+
+// ThroughputWeek starts on Monday. The first and the last week of a report
+// only count the days inside its range.
+type ThroughputWeek struct {
+	Week      string `json:"week" format:"date"`
+	Completed int    `json:"completed"`
+	Estimate  int    `json:"estimate"`
+}
+
+type Throughput struct {
+	From  string           `json:"from" format:"date"`
+	To    string           `json:"to" format:"date"`
+	Weeks []ThroughputWeek `json:"weeks"`
+	// Average is the number of tasks completed per week.
+	Average float64 `json:"average"`
+}
+
+// BurndownDay holds what was left to do at the end of the day, nothing
+// for days still to come. Ideal is the straight line from the start to
+// zero on the target date.
+type BurndownDay struct {
+	Day                string  `json:"day" format:"date"`
+	Remaining          *int    `json:"remaining"`
+	Remaining_estimate *int    `json:"remaining_estimate"`
+	Ideal              float64 `json:"ideal"`
+	Ideal_estimate     float64 `json:"ideal_estimate"`
+}
+
+type Burndown struct {
+	From   string        `json:"from" format:"date"`
+	Target string        `json:"target" format:"date"`
+	Days   []BurndownDay `json:"days"`
+}
+
+// MemberWorkload is what a project member executes. Open tasks are the
+// live ones not done yet, Estimate is their sum; Completed counts the tasks
+// they finished over the last days of the report.
+type MemberWorkload struct {
+	ID_user        int64          `json:"id_user"`
+	Name           string         `json:"name"`
+	Email          string         `json:"email"`
+	Open           int            `json:"open"`
+	Open_by_status map[string]int `json:"open_by_status"`
+	Overdue        int            `json:"overdue"`
+	Estimate       int            `json:"estimate"`
+	Completed      int            `json:"completed"`
+}
+
+// Workload spreads the work of a project across its members, the ones
+// with the most open tasks first. Unassigned holds the open tasks nobody
+// executes yet.
+type Workload struct {
+	Days       int              `json:"days"`
+	Since      string           `json:"since" format:"date"`
+	Members    []MemberWorkload `json:"members"`
+	Unassigned MemberWorkload   `json:"unassigned"`
+}
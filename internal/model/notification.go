@@ -0,0 +1,42 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	NotifyTaskAssigned  = "task.assigned"
+	NotifyTaskMoved     = "task.moved"
+	NotifyTaskUpdated   = "task.updated"
+	NotifyTaskArchived  = "task.archived"
+	NotifyTaskCommented = "task.commented"
+)
+
+// NotificationTypes are the notifications a user can turn off.
+var NotificationTypes = []string{
+	NotifyTaskAssigned,
+	NotifyTaskMoved,
+	NotifyTaskUpdated,
+	NotifyTaskArchived,
+	NotifyTaskCommented,
+}
+
+// Notification tells a user about a change someone else made to a task
+// they created or work on.
+type Notification struct {
+	ID         int64         `json:"id"`
+	ID_user    int64         `json:"id_user"`
+	Type       string        `json:"type" enums:"task.assigned,task.moved,task.updated,task.archived,task.commented"`
+	ID_project int64         `json:"id_project"`
+	ID_task    int64         `json:"id_task"`
+	ID_actor   sql.NullInt64 `json:"id_actor" swaggertype:"integer"`
+	Message    string        `json:"message"`
+	Created_at time.Time     `json:"created_at"`
+	Read_at    sql.NullTime  `json:"read_at" swaggertype:"string" format:"date-time"`
+}
+
+type NotificationPreference struct {
+	Type    string `json:"type" enums:"task.assigned,task.moved,task.updated,task.archived,task.commented"`
+	Enabled bool   `json:"enabled"`
+}
@@ -1,8 +1,12 @@
 package model
 
+import "database/sql"
+
 type Project struct {
-	ID          int64  `json:"id" db:"id"`
-	Name        string `json:"name" db:"name"`
-	IDCreator   int64  `json:"id_creator" db:"id_creator"`
-	Description string `json:"description" db:"description"`
+	ID          int64        `json:"id" db:"id"`
+	Name        string       `json:"name" db:"name"`
+	Slug        string       `json:"slug" db:"slug"`
+	IDCreator   int64        `json:"id_creator" db:"id_creator"`
+	Description string       `json:"description" db:"description"`
+	ArchivedAt  sql.NullTime `json:"archived_at" db:"archived_at" swaggertype:"string" format:"date-time"`
 }
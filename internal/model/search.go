@@ -0,0 +1,20 @@
+package model
+
+import "database/sql"
+
+// SearchFilter narrows a full-text task search, zero values mean no filter.
+type SearchFilter struct {
+	Query        string
+	Status       string
+	ID_executor  int64
+	ID_label     int64
+	Created_from sql.NullTime
+	Created_to   sql.NullTime
+}
+
+type SearchHit struct {
+	Task       Task
+	ID_project int64
+	Rank       float32
+	Snippet    string
+}
@@ -0,0 +1,8 @@
+package model
+
+type Swimlane struct {
+	ID         int64
+	Name       string
+	ID_project int64
+	Position   int
+}
@@ -2,6 +2,19 @@ package model
 
 import "database/sql"
 
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+)
+
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
 type Task struct {
 	ID                int64
 	ID_column         int64
@@ -12,4 +25,40 @@ type Task struct {
 	ID_executor       sql.NullInt64 `json:"id_executor" swaggertype:"integer"`
 	ID_creator        int64
 	Status            string
+	Priority          string
+	ID_swimlane       sql.NullInt64 `json:"id_swimlane" swaggertype:"integer"`
+	Due_date          sql.NullTime  `json:"due_date" swaggertype:"string" format:"date"`
+	Estimate          sql.NullInt64 `json:"estimate" swaggertype:"integer"`
+	Archived_at       sql.NullTime  `json:"archived_at" swaggertype:"string" format:"date-time"`
+	// Time_spent is the tracked time in seconds, running timers count up
+	// to now.
+	Time_spent int64
+}
+
+// TaskUpdate is one edit of a task, the fields left nil stay as they are.
+type TaskUpdate struct {
+	Name        *string
+	Description *string
+	ID_column   *int64
+	Priority    *string
+	ID_swimlane *sql.NullInt64
+	Due_date    *sql.NullTime
+	Estimate    *sql.NullInt64
+	ID_executor *sql.NullInt64
+}
+
+func ValidPriority(priority string) bool {
+	switch priority {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusTodo, StatusInProgress, StatusDone:
+		return true
+	}
+	return false
 }
@@ -1,8 +1,13 @@
 package model
 
+import "database/sql"
+
 type Task_log struct {
 	ID                int64
 	ID_Task           int64
 	Date_of_operation string
 	Info              string
+	// Status is the status the entry moved the task to, null for entries
+	// of other kinds.
+	Status sql.NullString `json:"status" swaggertype:"string"`
 }
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TaskTemplate is a recurring task: every time its rule comes due a task
+// with its fields is created in its column.
+type TaskTemplate struct {
+	ID          int64
+	ID_project  int64
+	ID_column   sql.NullInt64 `json:"id_column" swaggertype:"integer"`
+	ID_creator  int64
+	Name        string
+	Description string
+	Priority    string
+	ID_executor sql.NullInt64 `json:"id_executor" swaggertype:"integer"`
+	ID_swimlane sql.NullInt64 `json:"id_swimlane" swaggertype:"integer"`
+	Estimate    sql.NullInt64 `json:"estimate" swaggertype:"integer"`
+	// Rule is an RRULE, see internal/lib/rrule.
+	Rule      string    `example:"FREQ=WEEKLY;BYDAY=MO"`
+	Starts_at time.Time `json:"starts_at" format:"date-time"`
+	// Next_at is null once the rule has ended.
+	Next_at       sql.NullTime `json:"next_at" swaggertype:"string" format:"date-time"`
+	Created_count int
+	// Failures counts the failed tries of the due repeat, the next one is
+	// made at Retry_at.
+	Failures int
+	Retry_at sql.NullTime `json:"retry_at" swaggertype:"string" format:"date-time"`
+}
+
+// TaskInstance is one task created from a template, the task is null once
+// it was deleted.
+type TaskInstance struct {
+	Occurrence   int
+	Scheduled_at time.Time      `json:"scheduled_at" format:"date-time"`
+	ID_task      sql.NullInt64  `json:"id_task" swaggertype:"integer"`
+	ID_previous  sql.NullInt64  `json:"id_previous" swaggertype:"integer"`
+	Name         sql.NullString `json:"name" swaggertype:"string"`
+	Status       sql.NullString `json:"status" swaggertype:"string"`
+}
@@ -0,0 +1,23 @@
+package model
+
+const (
+	TemplateBasicKanban = "Basic Kanban"
+	TemplateScrum       = "Scrum"
+)
+
+type TemplateColumn struct {
+	Name      string `json:"name"`
+	WIP_limit *int   `json:"wip_limit" minimum:"1"`
+}
+
+// Template is the layout a new project starts with: the columns of its
+// default board, in order, and its labels. Built-in templates have no
+// owner and no id.
+type Template struct {
+	ID      int64            `json:"id"`
+	ID_user int64            `json:"id_user"`
+	Name    string           `json:"name"`
+	Builtin bool             `json:"builtin"`
+	Columns []TemplateColumn `json:"columns"`
+	Labels  []string         `json:"labels"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TimeEntry struct {
+	ID         int64
+	ID_task    int64
+	ID_user    int64
+	Started_at time.Time `json:"started_at" format:"date-time"`
+	// Ended_at is null while the timer runs.
+	Ended_at sql.NullTime `json:"ended_at" swaggertype:"string" format:"date-time"`
+	// Duration is in seconds, up to now while the timer runs.
+	Duration int64
+	Note     string
+}
+
+// TimeRow is the time one user tracked in a project on one day.
+type TimeRow struct {
+	ID_user int64
+	Name    string
+	Email   string
+	Day     string
+	Seconds int64
+}
+
+// TimeReport is the time tracked in a project by user and day. Entries
+// count on the day they started, in UTC.
+type TimeReport struct {
+	From  string     `json:"from" format:"date"`
+	To    string     `json:"to" format:"date"`
+	Users []UserTime `json:"users"`
+	// Total is in seconds, like every duration of the report.
+	Total int64 `json:"total"`
+}
+
+type UserTime struct {
+	ID_user int64     `json:"id_user"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	Total   int64     `json:"total"`
+	Days    []DayTime `json:"days"`
+}
+
+type DayTime struct {
+	Day     string `json:"day" format:"date"`
+	Seconds int64  `json:"seconds"`
+}
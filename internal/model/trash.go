@@ -0,0 +1,37 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const (
+	TrashKindColumn = "column"
+	TrashKindTask   = "task"
+)
+
+// TrashItem is a permanently deleted column or task kept as a snapshot so
+// the deletion can be undone for a while.
+type TrashItem struct {
+	ID         int64
+	ID_project int64
+	Kind       string
+	Name       string
+	ID_user    sql.NullInt64 `json:"id_user" swaggertype:"integer"`
+	Snapshot   json.RawMessage
+	Deleted_at time.Time
+}
+
+type TaskSnapshot struct {
+	Task         Task
+	Logs         []Task_log
+	Labels       []int64
+	Time_entries []TimeEntry
+	Comments     []Comment
+}
+
+type ColumnSnapshot struct {
+	Column Column
+	Tasks  []TaskSnapshot
+}
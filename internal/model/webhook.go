@@ -0,0 +1,48 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// WebhookEventPing is the type of the test event sent on request.
+const WebhookEventPing = "ping"
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook sends the events of a project to an outside URL, signed with its
+// secret. Empty Events subscribes to every event type.
+type Webhook struct {
+	ID         int64     `json:"id"`
+	ID_project int64     `json:"id_project"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	Events     []string  `json:"events"`
+	Active     bool      `json:"active"`
+	Created_at time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is an event queued for a webhook. Finished deliveries
+// stay as the delivery log of the webhook.
+type WebhookDelivery struct {
+	ID              int64           `json:"id"`
+	ID_webhook      int64           `json:"id_webhook"`
+	ID_event        sql.NullInt64   `json:"id_event" swaggertype:"integer"`
+	Event_type      string          `json:"event_type"`
+	Payload         json.RawMessage `json:"payload" swaggertype:"object"`
+	Status          string          `json:"status" enums:"pending,delivered,failed"`
+	Attempts        int             `json:"attempts"`
+	Next_attempt_at time.Time       `json:"next_attempt_at"`
+	Response_code   sql.NullInt64   `json:"response_code" swaggertype:"integer"`
+	Last_error      string          `json:"last_error"`
+	Created_at      time.Time       `json:"created_at"`
+	Delivered_at    sql.NullTime    `json:"delivered_at" swaggertype:"string" format:"date-time"`
+	// URL and Secret are where and how a claimed delivery is sent.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
@@ -0,0 +1,109 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// Throughput counts the tasks of the project completed in every week of
+// the range.
+func (s *Service) Throughput(userID int, projectID int, filter model.ReportFilter, from time.Time, to time.Time) (*model.Throughput, error) {
+
+	const op = "analytics.service.Throughput"
+
+	from, to, err := dateRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	weeks, err := s.store.Metrics().GetThroughput(projectID, filter, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if weeks == nil {
+		weeks = []model.ThroughputWeek{}
+	}
+
+	var completed int
+	for _, w := range weeks {
+		completed += w.Completed
+	}
+
+	var average float64
+	if len(weeks) > 0 {
+		average = round(float64(completed) / float64(len(weeks)))
+	}
+
+	return &model.Throughput{
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		Weeks:   weeks,
+		Average: average,
+	}, nil
+}
+
+// Burndown follows the open tasks of the project from the start of the
+// range to its end, the target date. Days after today are only on the
+// ideal line.
+func (s *Service) Burndown(userID int, projectID int, filter model.ReportFilter, from time.Time, target time.Time) (*model.Burndown, error) {
+
+	const op = "analytics.service.Burndown"
+
+	from, target, err := dateRange(from, target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	var days []model.BurndownDay
+
+	if !from.After(today) {
+		end := target
+		if end.After(today) {
+			end = today
+		}
+
+		days, err = s.store.Metrics().GetBurndown(projectID, filter, from, end)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for day := from.AddDate(0, 0, len(days)); !day.After(target); day = day.AddDate(0, 0, 1) {
+		days = append(days, model.BurndownDay{Day: day.Format(time.DateOnly)})
+	}
+
+	var start, startEstimate float64
+	if len(days) > 0 && days[0].Remaining != nil {
+		start = float64(*days[0].Remaining)
+		startEstimate = float64(*days[0].Remaining_estimate)
+	}
+
+	for i := range days {
+		left := 0.0
+		if len(days) > 1 {
+			left = 1 - float64(i)/float64(len(days)-1)
+		}
+		days[i].Ideal = round(start * left)
+		days[i].Ideal_estimate = round(startEstimate * left)
+	}
+
+	return &model.Burndown{
+		From:   from.Format(time.DateOnly),
+		Target: target.Format(time.DateOnly),
+		Days:   days,
+	}, nil
+}
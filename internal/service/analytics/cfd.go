@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// SnapshotColumns records today's task counts of all columns. It runs
+// several times a day so the last run of a day is close to its end.
+func (s *Service) SnapshotColumns() (int64, error) {
+
+	const op = "analytics.service.SnapshotColumns"
+
+	recorded, err := s.store.Metrics().SnapshotColumns(time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recorded, nil
+}
+
+// CumulativeFlow returns the daily task counts of the columns of the
+// project, or of one of its boards when boardID isn't 0. A column that
+// didn't exist on a day counts 0 there.
+func (s *Service) CumulativeFlow(userID int, projectID int, boardID int, from time.Time, to time.Time) (*model.CumulativeFlow, error) {
+
+	const op = "analytics.service.CumulativeFlow"
+
+	from, to, err := dateRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	snapshots, err := s.store.Metrics().GetSnapshots(projectID, boardID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cfd := &model.CumulativeFlow{
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		Columns: []model.CFDColumn{},
+		Days:    []model.CFDDay{},
+	}
+
+	// columns are indexed in the order they first appear, later snapshots
+	// bring their latest name
+	index := make(map[int64]int)
+
+	for _, snap := range snapshots {
+		i, ok := index[snap.ID_column]
+		if !ok {
+			i = len(cfd.Columns)
+			index[snap.ID_column] = i
+			cfd.Columns = append(cfd.Columns, model.CFDColumn{ID_column: snap.ID_column})
+		}
+		cfd.Columns[i].ID_board = snap.ID_board
+		cfd.Columns[i].Name = snap.Name
+	}
+
+	for _, snap := range snapshots {
+		if n := len(cfd.Days); n == 0 || cfd.Days[n-1].Day != snap.Day {
+			cfd.Days = append(cfd.Days, model.CFDDay{
+				Day:    snap.Day,
+				Counts: make([]int, len(cfd.Columns)),
+			})
+		}
+		cfd.Days[len(cfd.Days)-1].Counts[index[snap.ID_column]] = snap.Tasks
+	}
+
+	return cfd, nil
+}
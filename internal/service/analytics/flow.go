@@ -0,0 +1,90 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// FlowMetrics returns the lead and cycle times of the tasks of the project
+// done between from and to. Zero dates take the defaults of dateRange.
+func (s *Service) FlowMetrics(userID int, projectID int, from time.Time, to time.Time) (*model.FlowMetrics, error) {
+
+	const op = "analytics.service.FlowMetrics"
+
+	from, to, err := dateRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, err := s.store.Metrics().GetFlow(projectID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tasks == nil {
+		tasks = []model.TaskFlow{}
+	}
+
+	var lead, cycle []float64
+
+	for _, t := range tasks {
+		lead = append(lead, float64(t.Lead_days))
+		if t.Cycle_days != nil {
+			cycle = append(cycle, float64(*t.Cycle_days))
+		}
+	}
+
+	return &model.FlowMetrics{
+		From:  from.Format(time.DateOnly),
+		To:    to.Format(time.DateOnly),
+		Lead:  flowStats(lead),
+		Cycle: flowStats(cycle),
+		Tasks: tasks,
+	}, nil
+}
+
+func flowStats(days []float64) model.FlowStats {
+
+	if len(days) == 0 {
+		return model.FlowStats{}
+	}
+
+	slices.Sort(days)
+
+	var sum float64
+	for _, d := range days {
+		sum += d
+	}
+
+	return model.FlowStats{
+		Count:   len(days),
+		Average: round(sum / float64(len(days))),
+		P50:     percentile(days, 0.5),
+		P85:     percentile(days, 0.85),
+		P95:     percentile(days, 0.95),
+	}
+}
+
+// percentile interpolates between the closest ranks of sorted, the same way
+// percentile_cont does in Postgres.
+func percentile(sorted []float64, p float64) float64 {
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return round(sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower)))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var (
+	ErrInvalidRange = errors.New("invalid date range")
+)
+
+const (
+	// defaultRange is the period reported when the client names none.
+	defaultRange = 30 * 24 * time.Hour
+	// maxRange keeps a report from scanning the whole history at once.
+	maxRange = 366 * 24 * time.Hour
+)
+
+// Service computes the reports on how work flows through project boards.
+type Service struct {
+	store storage.Store
+}
+
+func NewService(store storage.Store) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+// dateRange fills in a missing end with today and a missing start with
+// defaultRange before the end.
+func dateRange(from time.Time, to time.Time) (time.Time, time.Time, error) {
+
+	if to.IsZero() {
+		to = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	if from.IsZero() {
+		from = to.Add(-defaultRange)
+	}
+
+	if from.After(to) || to.Sub(from) > maxRange {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+
+	return from, to, nil
+}
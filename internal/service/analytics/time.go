@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// TimeReport sums the time tracked in the project by user and day, days
+// without time left out.
+func (s *Service) TimeReport(userID int, projectID int, from time.Time, to time.Time) (*model.TimeReport, error) {
+
+	const op = "analytics.service.TimeReport"
+
+	from, to, err := dateRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.store.Metrics().GetTimeReport(projectID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report := &model.TimeReport{
+		From:  from.Format(time.DateOnly),
+		To:    to.Format(time.DateOnly),
+		Users: []model.UserTime{},
+	}
+
+	// rows come ordered by user, so each user's days are consecutive
+	for _, row := range rows {
+		n := len(report.Users)
+		if n == 0 || report.Users[n-1].ID_user != row.ID_user {
+			report.Users = append(report.Users, model.UserTime{
+				ID_user: row.ID_user,
+				Name:    row.Name,
+				Email:   row.Email,
+				Days:    []model.DayTime{},
+			})
+			n++
+		}
+
+		user := &report.Users[n-1]
+		user.Days = append(user.Days, model.DayTime{Day: row.Day, Seconds: row.Seconds})
+		user.Total += row.Seconds
+		report.Total += row.Seconds
+	}
+
+	return report, nil
+}
@@ -0,0 +1,56 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// defaultWorkloadDays is how far back completed tasks are counted when the
+// client names no period.
+const defaultWorkloadDays = 14
+
+// Workload sums up the open and recently completed tasks of every member
+// of the project. Completed tasks are the ones done over the last days,
+// today included.
+func (s *Service) Workload(userID int, projectID int, days int) (*model.Workload, error) {
+
+	const op = "analytics.service.Workload"
+
+	if days == 0 {
+		days = defaultWorkloadDays
+	}
+
+	if days < 1 || time.Duration(days)*24*time.Hour > maxRange {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidRange)
+	}
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+
+	members, err := s.store.Metrics().GetWorkload(projectID, since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	workload := &model.Workload{
+		Days:    days,
+		Since:   since.Format(time.DateOnly),
+		Members: make([]model.MemberWorkload, 0, len(members)),
+	}
+
+	for _, m := range members {
+		if m.ID_user == 0 {
+			workload.Unassigned = m
+			continue
+		}
+		workload.Members = append(workload.Members, m)
+	}
+
+	return workload, nil
+}
@@ -0,0 +1,183 @@
+package board
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// Projects, columns and tasks are never deleted right away, they are archived
+// and stay restorable until PurgeArchived removes them after the retention
+// period.
+
+func (s *Service) ArchiveProject(userID int, id int) error {
+
+	const op = "board.service.ArchiveProject"
+
+	err := s.store.Project().Archive(userID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ArchiveColumn(id int) error {
+
+	const op = "board.service.ArchiveColumn"
+
+	err := s.store.Column().ArchiveColumn(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishColumn(int64(id), 0, model.EventColumnDeleted)
+
+	return nil
+}
+
+func (s *Service) ArchiveTask(userID int, id int) error {
+
+	const op = "board.service.ArchiveTask"
+
+	// read before archiving, the executor is told which task is gone
+	task := &model.Task{ID: int64(id)}
+	if err := s.store.Task().ReadTask(task); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.store.Task().ArchiveTask(userID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishTask(int64(id), int64(userID), model.EventTaskDeleted)
+
+	if projectID, err := s.store.Task().GetProjectID(id); err == nil {
+		s.notifyTask(projectID, task, int64(userID), model.EventTaskDeleted)
+	}
+
+	return nil
+}
+
+func (s *Service) RestoreProject(userID int, id int) error {
+
+	const op = "board.service.RestoreProject"
+
+	err := s.store.Project().Unarchive(userID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) RestoreColumn(userID int, id int) error {
+
+	const op = "board.service.RestoreColumn"
+
+	projectID, err := s.store.Column().GetProjectID(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, int(projectID)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.store.Column().UnarchiveColumn(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishColumn(int64(id), 0, model.EventColumnRestored)
+
+	return nil
+}
+
+func (s *Service) RestoreTask(userID int, id int) error {
+
+	const op = "board.service.RestoreTask"
+
+	err := s.store.Task().UnarchiveTask(userID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishTask(int64(id), int64(userID), model.EventTaskRestored)
+
+	return nil
+}
+
+func (s *Service) ListArchivedProjects(userID int, page storage.Page) ([]model.Project, string, error) {
+
+	const op = "board.service.ListArchivedProjects"
+
+	projects, next, err := s.store.Archive().GetProjects(userID, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projects, next, nil
+}
+
+func (s *Service) ReadArchive(userID int, projectID int) (*response.ArchiveResponse, error) {
+
+	const op = "board.service.ReadArchive"
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	columns, err := s.store.Archive().GetColumns(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, err := s.store.Archive().GetTasks(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp := &response.ArchiveResponse{
+		ProjectID: uint(projectID),
+		Columns:   make([]response.ArchivedItem, 0, len(columns)),
+		Tasks:     make([]response.ArchivedItem, 0, len(tasks)),
+	}
+
+	for _, c := range columns {
+		resp.Columns = append(resp.Columns, response.ArchivedItem{
+			ID:         uint(c.ID),
+			Name:       c.Name,
+			ArchivedAt: c.Archived_at.Time,
+		})
+	}
+
+	for _, t := range tasks {
+		resp.Tasks = append(resp.Tasks, response.ArchivedItem{
+			ID:         uint(t.ID),
+			Name:       t.Name,
+			ColumnID:   uint(t.ID_column),
+			ArchivedAt: t.Archived_at.Time,
+		})
+	}
+
+	return resp, nil
+}
+
+// PurgeArchived deletes everything archived before the given moment.
+func (s *Service) PurgeArchived(before time.Time) (int64, error) {
+
+	const op = "board.service.PurgeArchived"
+
+	purged, err := s.store.Archive().Purge(before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,158 @@
+package board
+
+import (
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+func (s *Service) CreateBoard(board *model.Board) error {
+
+	const op = "board.service.CreateBoard"
+
+	err := s.store.Board().CreateBoard(board)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListBoards(projectID int) ([]model.Board, error) {
+
+	const op = "board.service.ListBoards"
+
+	boards, err := s.store.Project().GetBoards(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return boards, nil
+}
+
+func (s *Service) UpdateBoardName(board model.Board) error {
+
+	const op = "board.service.UpdateBoardName"
+
+	err := s.store.Board().UpdateBoardName(board)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteBoard(id int) error {
+
+	const op = "board.service.DeleteBoard"
+
+	err := s.store.Board().DeleteBoard(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadBoard returns the column × lane matrix of the board tasks. Lanes are
+// either the manual swimlanes of the project or built on the fly from the
+// executor, labels or priority of the tasks.
+func (s *Service) ReadBoard(boardID int, groupBy string) (*response.BoardResponse, error) {
+
+	const op = "board.service.ReadBoard"
+
+	if groupBy == "" {
+		groupBy = GroupByManual
+	}
+
+	board := &model.Board{ID: int64(boardID)}
+
+	err := s.store.Board().ReadBoard(board)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	projectID := int(board.ID_project)
+
+	columns, err := s.store.Board().GetColumns(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, err := s.store.Board().GetTasks(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var lanes []lane
+
+	switch groupBy {
+	case GroupByManual:
+		lanes, err = s.manualLanes(projectID, tasks)
+	case GroupByExecutor:
+		lanes, err = s.executorLanes(tasks)
+	case GroupByLabel:
+		lanes, err = s.labelLanes(projectID, tasks)
+	case GroupByPriority:
+		lanes = priorityLanes(tasks)
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrUnknownGrouping)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp := &response.BoardResponse{
+		ID:        uint(board.ID),
+		Name:      board.Name,
+		ProjectID: uint(projectID),
+		GroupBy:   groupBy,
+		Columns:   make([]response.ColumnBrief, 0, len(columns)),
+		Lanes:     make([]response.BoardLane, 0, len(lanes)),
+	}
+
+	counts := make(map[int64]int, len(columns))
+	for _, t := range tasks {
+		counts[t.ID_column]++
+	}
+
+	for _, c := range columns {
+		resp.Columns = append(resp.Columns, response.ColumnBrief{
+			ID:        int(c.ID),
+			BoardID:   int(c.ID_board),
+			Name:      c.Name,
+			TaskCount: counts[c.ID],
+			WIPLimit:  wipLimit(c),
+		})
+	}
+
+	for _, l := range lanes {
+		boardLane := response.BoardLane{
+			Key:   l.key,
+			Name:  l.name,
+			Cells: make([]response.BoardCell, 0, len(columns)),
+		}
+
+		for _, c := range columns {
+			cell := response.BoardCell{
+				ColumnID: int(c.ID),
+				Tasks:    []response.TaskBrief{},
+			}
+			for _, t := range l.tasks {
+				if t.ID_column == c.ID {
+					cell.Tasks = append(cell.Tasks, response.TaskBrief{
+						ID:     uint(t.ID),
+						Name:   t.Name,
+						Status: t.Status,
+					})
+				}
+			}
+			boardLane.Cells = append(boardLane.Cells, cell)
+		}
+
+		resp.Lanes = append(resp.Lanes, boardLane)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var ErrEmptyComment = errors.New("comment can't be empty")
+
+// CreateComment adds a comment of comment.ID_author, who has to work in the
+// project of the task. The creator and the executor of the task are notified.
+func (s *Service) CreateComment(comment *model.Comment) error {
+
+	const op = "board.service.CreateComment"
+
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" {
+		return fmt.Errorf("%s: %w", op, ErrEmptyComment)
+	}
+
+	if err := s.checkTaskMember(int(comment.ID_author.Int64), comment.ID_task); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.store.Comment().CreateComment(comment); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishTask(comment.ID_task, comment.ID_author.Int64, model.EventCommentCreated)
+
+	return nil
+}
+
+// ListComments returns the comments of the task, the oldest first by default.
+func (s *Service) ListComments(userID int, taskID int, page storage.Page) ([]model.Comment, string, error) {
+
+	const op = "board.service.ListComments"
+
+	if err := s.checkTaskMember(userID, int64(taskID)); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	comments, next, err := s.store.Comment().GetComments(taskID, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, next, nil
+}
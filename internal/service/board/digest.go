@@ -0,0 +1,163 @@
+package board
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/digest"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+var (
+	ErrInvalidDigestFrequency = errors.New("digest frequency must be off, daily or weekly")
+	ErrInvalidUnsubscribe     = errors.New("invalid unsubscribe link")
+)
+
+const (
+	// digestBatch is how many users are claimed at once.
+	digestBatch = 50
+	// digestSection caps every list of a digest, the board has the rest.
+	digestSection = 20
+)
+
+func (s *Service) GetDigestSettings(userID int) (*model.DigestSettings, error) {
+
+	const op = "board.service.GetDigestSettings"
+
+	settings, err := s.store.Digest().GetSettings(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return settings, nil
+}
+
+func (s *Service) SetDigestFrequency(userID int, frequency string) error {
+
+	const op = "board.service.SetDigestFrequency"
+
+	if !model.ValidDigestFrequency(frequency) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidDigestFrequency)
+	}
+
+	if err := s.store.Digest().SetFrequency(userID, frequency); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Unsubscribe turns digests off for the user of a link from a digest.
+func (s *Service) Unsubscribe(userID int, token string) error {
+
+	const op = "board.service.Unsubscribe"
+
+	if !hmac.Equal([]byte(token), []byte(s.unsubscribeToken(int64(userID)))) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidUnsubscribe)
+	}
+
+	if err := s.store.Digest().SetFrequency(userID, model.DigestOff); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// unsubscribeToken signs the user ID, links keep working without being
+// stored and can't be made up for other users.
+func (s *Service) unsubscribeToken(userID int64) string {
+
+	mac := hmac.New(sha256.New, []byte(s.jwtSecret))
+	mac.Write([]byte("digest-unsubscribe:" + strconv.FormatInt(userID, 10)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) unsubscribeURL(userID int64) string {
+
+	q := url.Values{}
+	q.Set("user", strconv.FormatInt(userID, 10))
+	q.Set("token", s.unsubscribeToken(userID))
+
+	return strings.TrimRight(s.baseURL, "/") + "/digest/unsubscribe?" + q.Encode()
+}
+
+// SendDigests mails the due digests batch by batch and returns how many
+// were sent. A user is marked as sent before the mail goes out, so a failed
+// one waits for the next period instead of being sent twice.
+func (s *Service) SendDigests(ctx context.Context) (int, error) {
+
+	const op = "board.service.SendDigests"
+
+	var sent int
+
+	for ctx.Err() == nil {
+		recipients, err := s.store.Digest().ClaimDue(digestBatch)
+		if err != nil {
+			return sent, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, recipient := range recipients {
+			ok, err := s.sendDigest(ctx, recipient)
+			if err != nil {
+				slog.Warn("failed to send digest",
+					slog.String("op", op),
+					slog.Int64("user_id", recipient.ID_user),
+					sl.Err(err),
+				)
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(recipients) < digestBatch {
+			break
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDigest reports false without an error when there was nothing to tell.
+func (s *Service) sendDigest(ctx context.Context, recipient model.DigestRecipient) (bool, error) {
+
+	period := 7 * 24 * time.Hour
+	if recipient.Frequency == model.DigestDaily {
+		period = 24 * time.Hour
+	}
+
+	d, err := s.store.Digest().GetDigest(recipient.ID_user, time.Now().Add(-period), digestSection)
+	if err != nil {
+		return false, err
+	}
+
+	if d.Empty() {
+		return false, nil
+	}
+
+	d.Name = recipient.Name
+	d.Frequency = recipient.Frequency
+
+	msg, err := digest.Render(recipient.Email, *d, s.unsubscribeURL(recipient.ID_user))
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,156 @@
+package board
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/events"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// Events are best effort: a change is already committed when its event is
+// built, so a failed lookup is logged and never fails the change itself.
+
+// idOnly is the data of an event about a deleted task or column.
+type idOnly struct {
+	ID int64 `json:"id"`
+}
+
+// replayLimit is how many missed events a resuming client is sent at most,
+// beyond that it is cheaper to reload the board.
+const replayLimit = 500
+
+// publish stores the event, which gives it the ID clients resume from, and
+// delivers it to the clients connected right now.
+func (s *Service) publish(projectID int64, userID int64, eventType string, data any) {
+
+	const op = "board.service.publish"
+
+	event := model.Event{
+		ID_project: projectID,
+		Type:       eventType,
+		ID_user:    userID,
+		Data:       data,
+	}
+
+	if err := s.store.Event().Create(&event); err != nil {
+		slog.Warn("failed to store event", slog.String("op", op), sl.Err(err))
+		return
+	}
+
+	if err := s.bus.Publish(event); err != nil {
+		slog.Warn("failed to publish event", slog.String("op", op), sl.Err(err))
+	}
+}
+
+// publishTask sends the task as it is now, or only its ID once it is gone
+// from the board.
+func (s *Service) publishTask(id int64, userID int64, eventType string) {
+
+	const op = "board.service.publishTask"
+
+	projectID, err := s.store.Task().GetProjectID(int(id))
+	if err != nil {
+		slog.Warn("failed to publish task event", slog.String("op", op), sl.Err(err))
+		return
+	}
+
+	var data any = idOnly{ID: id}
+
+	if eventType != model.EventTaskDeleted {
+		task := &model.Task{ID: id}
+		if err := s.store.Task().ReadTask(task); err != nil {
+			slog.Warn("failed to publish task event", slog.String("op", op), sl.Err(err))
+			return
+		}
+		data = task
+		s.notifyTask(projectID, task, userID, eventType)
+	}
+
+	s.publish(projectID, userID, eventType, data)
+}
+
+// publishColumn is publishTask for columns.
+func (s *Service) publishColumn(id int64, userID int64, eventType string) {
+
+	const op = "board.service.publishColumn"
+
+	projectID, err := s.store.Column().GetProjectID(int(id))
+	if err != nil {
+		slog.Warn("failed to publish column event", slog.String("op", op), sl.Err(err))
+		return
+	}
+
+	var data any = idOnly{ID: id}
+
+	if eventType != model.EventColumnDeleted {
+		column := &model.Column{ID: id}
+		if err := s.store.Column().ReadColumnByID(column); err != nil {
+			slog.Warn("failed to publish column event", slog.String("op", op), sl.Err(err))
+			return
+		}
+		data = column
+	}
+
+	s.publish(projectID, userID, eventType, data)
+}
+
+// SubscribeProject starts watching the project for a user working in it.
+// after is the ID of the last event the client has seen, 0 for a fresh
+// start. The stored events after it are returned as missed, complete is
+// false if some of them are already purged or there are too many of them
+// and the client has to reload the board. Live events may repeat the tail
+// of missed, clients skip IDs they have already seen.
+func (s *Service) SubscribeProject(userID int, projectID int, after int64) (*events.Subscription, []model.Event, bool, error) {
+
+	const op = "board.service.SubscribeProject"
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Subscribe before reading the missed events so nothing published in
+	// between is lost.
+	sub := s.bus.Subscribe(int64(projectID))
+
+	if after == 0 {
+		return sub, nil, true, nil
+	}
+
+	first, last, err := s.store.Event().IDRange()
+	if err != nil {
+		sub.Close()
+		return nil, nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	missed, err := s.store.Event().GetAfter(projectID, after, replayLimit+1)
+	if err != nil {
+		sub.Close()
+		return nil, nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Events older than first are purged, IDs above last are unknown to
+	// this database.
+	complete := after >= first-1 && after <= last && len(missed) <= replayLimit
+	if !complete {
+		missed = nil
+	}
+
+	return sub, missed, complete, nil
+}
+
+// PurgeEvents deletes the events created before the given moment.
+func (s *Service) PurgeEvents(before time.Time) (int64, error) {
+
+	const op = "board.service.PurgeEvents"
+
+	purged, err := s.store.Event().Purge(before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
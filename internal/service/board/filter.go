@@ -0,0 +1,95 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+	"github.com/wehw93/kanban-board/internal/taskql"
+)
+
+var ErrSharedWithoutProject = errors.New("only filters with a project can be shared")
+
+// CreateFilter saves the filter after checking that its query parses, so
+// broken filters never get stored.
+func (s *Service) CreateFilter(filter *model.Filter) error {
+
+	const op = "board.service.CreateFilter"
+
+	if _, err := taskql.Parse(filter.Query); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if filter.Shared && !filter.ID_project.Valid {
+		return fmt.Errorf("%s: %w", op, ErrSharedWithoutProject)
+	}
+
+	err := s.store.Filter().CreateFilter(filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListFilters(userID int, page storage.Page) ([]model.Filter, string, error) {
+
+	const op = "board.service.ListFilters"
+
+	filters, next, err := s.store.Filter().GetFilters(userID, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filters, next, nil
+}
+
+func (s *Service) DeleteFilter(userID int, id int) error {
+
+	const op = "board.service.DeleteFilter"
+
+	err := s.store.Filter().DeleteFilter(userID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// QueryTasks runs a task query for the user, projectID of 0 searches every
+// project they work in.
+func (s *Service) QueryTasks(userID int, query string, projectID int, page storage.Page) ([]model.Task, string, error) {
+
+	const op = "board.service.QueryTasks"
+
+	q, err := taskql.Parse(query)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, next, err := s.store.Search().QueryTasks(userID, q, projectID, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, next, nil
+}
+
+// RunFilter runs a saved filter, "me" in its query is the user running it.
+func (s *Service) RunFilter(userID int, id int, page storage.Page) ([]model.Task, string, error) {
+
+	const op = "board.service.RunFilter"
+
+	filter, err := s.store.Filter().GetFilter(userID, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, next, err := s.QueryTasks(userID, filter.Query, int(filter.ID_project.Int64), page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, next, nil
+}
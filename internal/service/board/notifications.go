@@ -0,0 +1,197 @@
+package board
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var ErrUnknownNotificationType = errors.New("unknown notification type")
+
+// Notifications are best effort like events, a failed one is logged.
+
+// taskNotifications maps the task events worth a notification to its type.
+var taskNotifications = map[string]string{
+	model.EventTaskAssigned:   model.NotifyTaskAssigned,
+	model.EventTaskMoved:      model.NotifyTaskMoved,
+	model.EventTaskUpdated:    model.NotifyTaskUpdated,
+	model.EventTaskDeleted:    model.NotifyTaskArchived,
+	model.EventCommentCreated: model.NotifyTaskCommented,
+}
+
+// notifyTask tells the creator and the executor of the task about a change
+// made by actorID, the actor is never told about their own change. Only the
+// executor hears about an assignment.
+func (s *Service) notifyTask(projectID int64, task *model.Task, actorID int64, eventType string) {
+
+	const op = "board.service.notifyTask"
+
+	kind, ok := taskNotifications[eventType]
+	if !ok {
+		return
+	}
+
+	var recipients []int64
+	if kind != model.NotifyTaskAssigned {
+		recipients = append(recipients, task.ID_creator)
+	}
+	if task.ID_executor.Valid && !slices.Contains(recipients, task.ID_executor.Int64) {
+		recipients = append(recipients, task.ID_executor.Int64)
+	}
+
+	for _, userID := range recipients {
+		if userID == actorID || userID == 0 {
+			continue
+		}
+
+		n := &model.Notification{
+			ID_user:    userID,
+			Type:       kind,
+			ID_project: projectID,
+			ID_task:    task.ID,
+			ID_actor:   sql.NullInt64{Int64: actorID, Valid: actorID != 0},
+			Message:    notificationMessage(kind, task.Name),
+		}
+
+		if err := s.store.Notification().Create(n); err != nil {
+			slog.Warn("failed to create notification",
+				slog.String("op", op),
+				slog.Int64("user_id", userID),
+				sl.Err(err),
+			)
+		}
+	}
+}
+
+func notificationMessage(kind string, taskName string) string {
+
+	switch kind {
+	case model.NotifyTaskAssigned:
+		return fmt.Sprintf("You were assigned to %q", taskName)
+	case model.NotifyTaskMoved:
+		return fmt.Sprintf("%q was moved", taskName)
+	case model.NotifyTaskArchived:
+		return fmt.Sprintf("%q was archived", taskName)
+	case model.NotifyTaskCommented:
+		return fmt.Sprintf("%q was commented on", taskName)
+	}
+
+	return fmt.Sprintf("%q was updated", taskName)
+}
+
+// AssignTask makes executorID the executor of the task, 0 leaves it without
+// one.
+func (s *Service) AssignTask(userID int, taskID int, executorID int) error {
+
+	const op = "board.service.AssignTask"
+
+	task := &model.Task{
+		ID:          int64(taskID),
+		ID_executor: sql.NullInt64{Int64: int64(executorID), Valid: executorID != 0},
+	}
+
+	if err := s.store.Task().UpdateTaskExecutor(task); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	eventType := model.EventTaskAssigned
+	if executorID == 0 {
+		eventType = model.EventTaskUpdated
+	}
+
+	s.publishTask(task.ID, int64(userID), eventType)
+
+	return nil
+}
+
+// ListNotifications returns a page of the notifications of the user, newest
+// first, and how many of all of them are unread.
+func (s *Service) ListNotifications(userID int, unreadOnly bool, page storage.Page) ([]model.Notification, int, string, error) {
+
+	const op = "board.service.ListNotifications"
+
+	notifications, next, err := s.store.Notification().GetNotifications(userID, unreadOnly, page)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	unread, err := s.store.Notification().CountUnread(userID)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, unread, next, nil
+}
+
+func (s *Service) MarkNotificationsRead(userID int, ids []int64) (int64, error) {
+
+	const op = "board.service.MarkNotificationsRead"
+
+	marked, err := s.store.Notification().MarkRead(userID, ids)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return marked, nil
+}
+
+func (s *Service) MarkAllNotificationsRead(userID int) (int64, error) {
+
+	const op = "board.service.MarkAllNotificationsRead"
+
+	marked, err := s.store.Notification().MarkAllRead(userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return marked, nil
+}
+
+// GetNotificationPreferences returns every notification type with whether
+// the user gets it.
+func (s *Service) GetNotificationPreferences(userID int) ([]model.NotificationPreference, error) {
+
+	const op = "board.service.GetNotificationPreferences"
+
+	saved, err := s.store.Notification().GetPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	prefs := make([]model.NotificationPreference, 0, len(model.NotificationTypes))
+
+	for _, t := range model.NotificationTypes {
+		enabled, ok := saved[t]
+		prefs = append(prefs, model.NotificationPreference{
+			Type:    t,
+			Enabled: enabled || !ok,
+		})
+	}
+
+	return prefs, nil
+}
+
+// SetNotificationPreferences changes the listed types, the rest stay as
+// they are.
+func (s *Service) SetNotificationPreferences(userID int, prefs []model.NotificationPreference) error {
+
+	const op = "board.service.SetNotificationPreferences"
+
+	for _, pref := range prefs {
+		if !slices.Contains(model.NotificationTypes, pref.Type) {
+			return fmt.Errorf("%s: %w: %q", op, ErrUnknownNotificationType, pref.Type)
+		}
+	}
+
+	if err := s.store.Notification().SetPreferences(userID, prefs); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
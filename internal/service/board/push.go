@@ -0,0 +1,199 @@
+package board
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/commitref"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/lib/webhook"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid push signature")
+	ErrInvalidPush      = errors.New("invalid push payload")
+)
+
+const (
+	reasonUnknownAuthor = "commit author is not a board user"
+	reasonNotMember     = "commit author doesn't work in the project"
+	reasonNoTask        = "task not found in the project"
+	reasonNoDoneColumn  = "task's board has no done column"
+	reasonAlreadyDone   = "task is already done"
+	reasonWIPLimit      = "WIP limit of the done column is reached"
+	reasonFailed        = "failed to move the task"
+)
+
+// SetPushHook creates the push hook of the project or rotates its secret,
+// the new secret is returned.
+func (s *Service) SetPushHook(userID int, projectID int) (string, error) {
+
+	const op = "board.service.SetPushHook"
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.store.PushHook().SetSecret(userID, projectID, secret); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, nil
+}
+
+func (s *Service) DeletePushHook(userID int, projectID int) error {
+
+	const op = "board.service.DeletePushHook"
+
+	if err := s.store.PushHook().DeletePushHook(userID, projectID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReceivePush moves the tasks the pushed commits close, "fixes #123", to
+// the done column of their board. A task is moved on behalf of the commit
+// author, matched to a user working in the project by email, who also
+// comments on it with the commit.
+func (s *Service) ReceivePush(projectID int, in webhook.Incoming) (*response.PushResult, error) {
+
+	const op = "board.service.ReceivePush"
+
+	secret, err := s.store.PushHook().GetSecret(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !in.Verify(secret, time.Now()) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSignature)
+	}
+
+	if !in.IsPush() {
+		return &response.PushResult{Ignored: true}, nil
+	}
+
+	push, err := in.Push()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidPush, err)
+	}
+
+	result := &response.PushResult{
+		Commits: len(push.Commits),
+		Refs:    []response.PushRef{},
+	}
+
+	for _, commit := range push.Commits {
+		ids := commitref.Closed(commit.Message)
+		if len(ids) == 0 {
+			continue
+		}
+
+		var (
+			userID int
+			reason string
+		)
+
+		user, err := s.store.User().GetByEmail(commit.Author.Email)
+		if err != nil {
+			reason = reasonUnknownAuthor
+		} else if member, err := s.store.Project().IsMember(user.ID, projectID); err != nil || !member {
+			reason = reasonNotMember
+		} else {
+			userID = user.ID
+		}
+
+		for _, id := range ids {
+			ref := response.PushRef{Commit: commit.ID, TaskID: id, Reason: reason}
+			if reason == "" {
+				ref.Reason = s.closeTask(projectID, id, userID, commit)
+				ref.Moved = ref.Reason == ""
+			}
+			result.Refs = append(result.Refs, ref)
+		}
+	}
+
+	return result, nil
+}
+
+// closeTask moves the task to done and returns why it didn't, empty when it did.
+func (s *Service) closeTask(projectID int, taskID int64, userID int, commit webhook.Commit) string {
+
+	const op = "board.service.closeTask"
+
+	taskProject, err := s.store.Task().GetProjectID(int(taskID))
+	if err != nil || taskProject != int64(projectID) {
+		return reasonNoTask
+	}
+
+	doneColumn, current, err := s.store.Task().GetDoneColumn(int(taskID))
+	if errors.Is(err, storage.ErrColumnNotFound) {
+		return reasonNoDoneColumn
+	}
+	if err != nil {
+		slog.Error("failed to find done column", slog.String("op", op), sl.Err(err))
+		return reasonFailed
+	}
+
+	if doneColumn == current {
+		return reasonAlreadyDone
+	}
+
+	task := &model.Task{ID: taskID, ID_column: doneColumn}
+
+	err = s.UpdateTaskColumn(userID, task)
+	if errors.Is(err, storage.ErrWIPLimitExceeded) {
+		return reasonWIPLimit
+	}
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		return reasonNoTask
+	}
+	if err != nil {
+		slog.Error("failed to move task", slog.String("op", op), sl.Err(err))
+		return reasonFailed
+	}
+
+	comment := &model.Comment{
+		ID_task:   taskID,
+		ID_author: sql.NullInt64{Int64: int64(userID), Valid: true},
+		Body:      commitComment(commit),
+	}
+	if err := s.store.Comment().CreateComment(comment); err != nil {
+		slog.Warn("failed to comment on task", slog.String("op", op), sl.Err(err))
+	} else {
+		s.publishTask(taskID, int64(userID), model.EventCommentCreated)
+	}
+
+	return ""
+}
+
+// commitComment is what the commit author says on the task it closes.
+func commitComment(commit webhook.Commit) string {
+
+	body := "Closed by commit " + shortCommit(commit.ID) + ": " + firstLine(commit.Message)
+	if commit.URL != "" {
+		body += "\n" + commit.URL
+	}
+
+	return body
+}
+
+func shortCommit(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,44 @@
+package board
+
+import (
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// SearchTasks runs a full-text search over the tasks of the projects the
+// user works in.
+func (s *Service) SearchTasks(userID int, filter model.SearchFilter, page storage.Page) ([]response.SearchHit, string, error) {
+
+	const op = "board.service.SearchTasks"
+
+	hits, next, err := s.store.Search().SearchTasks(userID, filter, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp := make([]response.SearchHit, 0, len(hits))
+
+	for _, h := range hits {
+		hit := response.SearchHit{
+			ID:        uint(h.Task.ID),
+			ProjectID: uint(h.ID_project),
+			ColumnID:  uint(h.Task.ID_column),
+			Name:      h.Task.Name,
+			Status:    h.Task.Status,
+			Priority:  h.Task.Priority,
+			CreatedAt: h.Task.Date_of_create,
+			Rank:      h.Rank,
+			Snippet:   h.Snippet,
+		}
+		if h.Task.ID_executor.Valid {
+			executorID := int(h.Task.ID_executor.Int64)
+			hit.ExecutorID = &executorID
+		}
+		resp = append(resp, hit)
+	}
+
+	return resp, next, nil
+}
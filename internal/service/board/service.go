@@ -6,8 +6,12 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/wehw93/kanban-board/internal/events"
 	"github.com/wehw93/kanban-board/internal/lib/http/response"
 	"github.com/wehw93/kanban-board/internal/lib/jwt"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/lib/mailer"
+	"github.com/wehw93/kanban-board/internal/lib/webhook"
 	"github.com/wehw93/kanban-board/internal/model"
 	"github.com/wehw93/kanban-board/internal/storage"
 	"golang.org/x/crypto/bcrypt"
@@ -16,12 +20,26 @@ import (
 type Service struct {
 	store     storage.Store
 	jwtSecret string
+	// undoWindow is how long a deleted column or task stays in the trash.
+	undoWindow time.Duration
+	// bus delivers the changes made through the service to board clients.
+	bus events.Bus
+	// webhooks sends the outbox of project webhooks.
+	webhooks *webhook.Sender
+	// mailer sends the email digests, baseURL is where links in them point.
+	mailer  mailer.Mailer
+	baseURL string
 }
 
-func NewService(store storage.Store, jwtSceret string) *Service {
+func NewService(store storage.Store, jwtSceret string, undoWindow time.Duration, bus events.Bus, webhooks *webhook.Sender, mail mailer.Mailer, baseURL string) *Service {
 	return &Service{
-		store:     store,
-		jwtSecret: jwtSceret,
+		store:      store,
+		jwtSecret:  jwtSceret,
+		undoWindow: undoWindow,
+		bus:        bus,
+		webhooks:   webhooks,
+		mailer:     mail,
+		baseURL:    baseURL,
 	}
 }
 
@@ -32,11 +50,11 @@ func (s *Service) LoginUser(email string, password string) (string, error) {
 	user, err := s.store.User().Login(email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
-			slog.Warn("user not found", err)
+			slog.Warn("user not found", sl.Err(err))
 			return "", fmt.Errorf("%s: %w", op, errors.New("Invalid credentials"))
 		}
 
-		slog.Warn("failed to get user", err)
+		slog.Warn("failed to get user", sl.Err(err))
 
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -149,11 +167,25 @@ func (s *Service) UpdatePassword(user model.User) error {
 	return nil
 }
 
-func (s *Service) CreateProject(project *model.Project) error {
+// CreateProject creates the project, laid out after the built-in or saved
+// template of the name unless it is empty.
+func (s *Service) CreateProject(project *model.Project, template string) error {
 
 	const op = "service.CreateProject"
 
-	err := s.store.Project().Create(project)
+	var (
+		tmpl *model.Template
+		err  error
+	)
+
+	if template != "" {
+		tmpl, err = s.findTemplate(int(project.IDCreator), template)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+	}
+
+	err = s.store.Project().Create(project, tmpl)
 	if err != nil {
 		return fmt.Errorf("%s:%w", op, err)
 	}
@@ -161,24 +193,107 @@ func (s *Service) CreateProject(project *model.Project) error {
 	return nil
 }
 
-func (s *Service) ReadProject(name string) (*response.ReadProjectResponse, error) {
+// ReadProject returns the project with its boards and columns and one page
+// of its tasks.
+func (s *Service) ReadProject(id int, page storage.Page) (*response.ReadProjectResponse, string, error) {
 
 	const op = "board.service.ReadProject"
 
+	project, err := s.store.Project().GetByID(id)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	return s.readProject(project, page)
+}
+
+func (s *Service) ReadProjectBySlug(slug string, page storage.Page) (*response.ReadProjectResponse, string, error) {
+
+	const op = "board.service.ReadProjectBySlug"
+
+	project, err := s.store.Project().GetBySlug(slug)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	return s.readProject(project, page)
+}
+
+// ReadProjectByName is kept for clients of the name based API, names are
+// only unique per creator.
+func (s *Service) ReadProjectByName(name string, page storage.Page) (*response.ReadProjectResponse, string, error) {
+
+	const op = "board.service.ReadProjectByName"
+
 	project, err := s.store.Project().GetByName(name)
 	if err != nil {
-		return nil, fmt.Errorf("%s:%w", op, err)
+		return nil, "", fmt.Errorf("%s:%w", op, err)
 	}
 
-	tasks, err := s.store.Project().GetTasks(int(project.ID))
+	return s.readProject(project, page)
+}
+
+// FindOwnProject returns the active project the user created with the name.
+func (s *Service) FindOwnProject(userID int, name string) (*model.Project, error) {
+
+	const op = "board.service.FindOwnProject"
+
+	project, err := s.store.Project().GetOwnByName(userID, name)
 	if err != nil {
-		return nil, fmt.Errorf("%s:%w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return project, nil
+}
+
+func (s *Service) readProject(project *model.Project, page storage.Page) (*response.ReadProjectResponse, string, error) {
+
+	const op = "board.service.readProject"
+
+	tasks, next, err := s.store.Project().GetTasks(int(project.ID), page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	boards, err := s.store.Project().GetBoards(int(project.ID))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	columns, err := s.store.Project().GetColumns(int(project.ID))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s:%w", op, err)
 	}
 
 	resp := &response.ReadProjectResponse{
 		ID:          uint(project.ID),
 		Name:        project.Name,
+		Slug:        project.Slug,
 		Description: project.Description,
+		Boards:      make([]response.BoardBrief, 0, len(boards)),
+		Columns:     make([]response.ColumnBrief, 0, len(columns)),
+	}
+
+	for _, b := range boards {
+		resp.Boards = append(resp.Boards, response.BoardBrief{
+			ID:   uint(b.ID),
+			Name: b.Name,
+		})
+	}
+
+	for _, c := range columns {
+		count, err := s.store.Column().CountTasks(int(c.ID))
+		if err != nil {
+			return nil, "", fmt.Errorf("%s:%w", op, err)
+		}
+
+		resp.Columns = append(resp.Columns, response.ColumnBrief{
+			ID:        int(c.ID),
+			BoardID:   int(c.ID_board),
+			Name:      c.Name,
+			TaskCount: count,
+			WIPLimit:  wipLimit(c),
+		})
 	}
 
 	for _, t := range tasks {
@@ -189,26 +304,15 @@ func (s *Service) ReadProject(name string) (*response.ReadProjectResponse, error
 		})
 	}
 
-	return resp, nil
-}
-
-func (s *Service) DeleteProject(userID int, name string) error {
-
-	const op = "board.service.DeleteProject"
-
-	err := s.store.Project().Delete(userID, name)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	return nil
+	return resp, next, nil
 }
 
-func (s *Service) UpdateProjectName(name string, project model.Project) error {
+// UpdateProjectName renames the project project.ID to project.Name.
+func (s *Service) UpdateProjectName(project model.Project) error {
 
 	const op = "board.service.UpdateProjectName"
 
-	err := s.store.Project().UpdateName(name, project)
+	err := s.store.Project().UpdateName(project)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -228,16 +332,16 @@ func (s *Service) UpdateProjectDescription(project model.Project) error {
 	return nil
 }
 
-func (s *Service) ListProjects() ([]model.Project, error) {
+func (s *Service) ListProjects(page storage.Page) ([]model.Project, string, error) {
 
 	const op = "board.service.ListProjects"
 
-	listProjects, err := s.store.Project().ListProjects()
+	listProjects, next, err := s.store.Project().ListProjects(page)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return listProjects, nil
+	return listProjects, next, nil
 }
 func (s *Service) CreateColumn(column *model.Column) error {
 
@@ -248,28 +352,44 @@ func (s *Service) CreateColumn(column *model.Column) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.publish(column.ID_project, 0, model.EventColumnCreated, column)
+
 	return nil
 }
 
-func (s *Service) ReadColumn(column model.Column) (*response.ReadColumnResponse, error) {
+// ReadColumn returns the column with one page of its tasks.
+func (s *Service) ReadColumn(column model.Column, page storage.Page) (*response.ReadColumnResponse, string, error) {
 
 	const op = "board.service.ReadColumn"
 
-	id, err := s.store.Column().GetID(column)
+	var err error
+
+	// Columns are found by ID, name and board are the legacy way.
+	if column.ID != 0 {
+		err = s.store.Column().ReadColumnByID(&column)
+	} else {
+		err = s.store.Column().ReadColumn(&column)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	column.ID = int64(id)
-
-	resp := &response.ReadColumnResponse{
-		ID:   id,
-		Name: column.Name,
+	tasks, next, err := s.store.Column().GetTasks(column, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	tasks, err := s.store.Column().GetTasks(column)
+	count, err := s.store.Column().CountTasks(int(column.ID))
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp := &response.ReadColumnResponse{
+		ID:        int(column.ID),
+		BoardID:   int(column.ID_board),
+		Name:      column.Name,
+		TaskCount: count,
+		WIPLimit:  wipLimit(column),
 	}
 
 	for _, t := range tasks {
@@ -280,33 +400,49 @@ func (s *Service) ReadColumn(column model.Column) (*response.ReadColumnResponse,
 		})
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
-func (s *Service) DeleteColumn(id int) error {
+func (s *Service) UpdateColumnName(column model.Column, name string) error {
 
-	const op = "board.service.DeleteColumn"
+	const op = "board.service.UpdateColumnName"
 
-	err := s.store.Column().DeleteColumn(id)
+	err := s.store.Column().UpdateColumnName(column, name)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.publishColumn(column.ID, 0, model.EventColumnUpdated)
+
 	return nil
 }
 
-func (s *Service) UpdateColumnName(column model.Column, name string) error {
+func (s *Service) UpdateColumnWIPLimit(column model.Column) error {
 
-	const op = "board.service.UpdateColumnName"
+	const op = "board.service.UpdateColumnWIPLimit"
 
-	err := s.store.Column().UpdateColumnName(column, name)
+	err := s.store.Column().UpdateColumnWIPLimit(column)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.publishColumn(column.ID, 0, model.EventColumnUpdated)
+
 	return nil
 }
 
+// wipLimit returns the column limit as it is shown in responses, nil means
+// the column is unlimited.
+func wipLimit(column model.Column) *int {
+	if !column.WIP_limit.Valid {
+		return nil
+	}
+
+	limit := int(column.WIP_limit.Int64)
+
+	return &limit
+}
+
 func (s *Service) CreateTask(task *model.Task) error {
 
 	const op = "board.service.CreateTask"
@@ -316,6 +452,8 @@ func (s *Service) CreateTask(task *model.Task) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.publishTask(task.ID, task.ID_creator, model.EventTaskCreated)
+
 	return nil
 }
 
@@ -331,62 +469,117 @@ func (s *Service) ReadTask(task *model.Task) error {
 	return nil
 }
 
-func (s *Service) DeleteTask(IDuser int, id int) error {
+// UpdateTask saves one edit of the task. A move to a column at its WIP limit
+// rejects the edit as a whole, otherwise the fields are saved one by one and
+// the ones that fail are reported together. The edit is published, and its
+// people notified, once: as an assignment when it gives the task an
+// executor, as a move when it changes the column, as an update otherwise.
+func (s *Service) UpdateTask(userID int, id int, update model.TaskUpdate) error {
 
-	const op = "board.service.DeleteTask"
+	const op = "board.service.UpdateTask"
 
-	err := s.store.Task().DeleteTask(IDuser, id)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	var (
+		errs      []error
+		eventType string
+	)
+
+	task := &model.Task{ID: int64(id)}
+
+	saved := func(field string, err error) bool {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: update %s: %w", op, field, err))
+			return false
+		}
+		if eventType == "" {
+			eventType = model.EventTaskUpdated
+		}
+		return true
 	}
 
-	return nil
-}
+	// The move goes first: a full column turns the whole edit down before
+	// anything else is saved.
+	if update.ID_column != nil {
+		task.ID_column = *update.ID_column
+		err := s.store.Task().UpdateTaskColumn(task)
+		if errors.Is(err, storage.ErrWIPLimitExceeded) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if saved("column", err) {
+			eventType = model.EventTaskMoved
+		}
+	}
 
-func (s *Service) UpdateTaskName(task *model.Task) error {
+	if update.Name != nil {
+		task.Name = *update.Name
+		saved("name", s.store.Task().UpdateTaskName(task))
+	}
 
-	const op = "board.service.UpdateTaskName"
+	if update.Description != nil {
+		task.Description = *update.Description
+		saved("description", s.store.Task().UpdateTaskDescription(task))
+	}
 
-	err := s.store.Task().UpdateTaskName(task)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if update.Priority != nil {
+		task.Priority = *update.Priority
+		saved("priority", s.store.Task().UpdateTaskPriority(task))
 	}
 
-	return nil
-}
+	if update.ID_swimlane != nil {
+		task.ID_swimlane = *update.ID_swimlane
+		saved("swimlane", s.store.Task().UpdateTaskSwimlane(task))
+	}
 
-func (s *Service) UpdateTaskColumn(task *model.Task) error {
+	if update.Due_date != nil {
+		task.Due_date = *update.Due_date
+		saved("due date", s.store.Task().UpdateTaskDueDate(task))
+	}
 
-	const op = "board.service.UpdateTaskColumn"
+	if update.Estimate != nil {
+		task.Estimate = *update.Estimate
+		saved("estimate", s.store.Task().UpdateTaskEstimate(task))
+	}
 
-	err := s.store.Task().UpdateTaskColumn(task)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if update.ID_executor != nil {
+		task.ID_executor = *update.ID_executor
+		if saved("executor", s.store.Task().UpdateTaskExecutor(task)) && task.ID_executor.Valid {
+			eventType = model.EventTaskAssigned
+		}
 	}
 
-	return nil
+	s.publishEdit(task.ID, int64(userID), eventType)
+
+	return errors.Join(errs...)
 }
 
-func (s *Service) UpdateTaskDescription(task *model.Task) error {
+// publishEdit publishes an edit that saved anything at all.
+func (s *Service) publishEdit(id int64, userID int64, eventType string) {
+	if eventType != "" {
+		s.publishTask(id, userID, eventType)
+	}
+}
 
-	const op = "board.service.UpdateTaskDescription"
+func (s *Service) UpdateTaskColumn(userID int, task *model.Task) error {
+
+	const op = "board.service.UpdateTaskColumn"
 
-	err := s.store.Task().UpdateTaskDescription(task)
+	err := s.store.Task().UpdateTaskColumn(task)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.publishTask(task.ID, int64(userID), model.EventTaskMoved)
+
 	return nil
 }
 
-func (s *Service) GetLogsTask(id_task int) ([]model.Task_log, error) {
+func (s *Service) GetLogsTask(id_task int, page storage.Page) ([]model.Task_log, string, error) {
 
 	const op = "service.board.GetLogsTask"
 
-	logs, err := s.store.Task().GetLogsTask(id_task)
+	logs, next, err := s.store.Task().GetLogsTask(id_task, page)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return logs, nil
+	return logs, next, nil
 }
@@ -0,0 +1,279 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+const (
+	GroupByManual   = "manual"
+	GroupByExecutor = "executor"
+	GroupByLabel    = "label"
+	GroupByPriority = "priority"
+)
+
+// noLaneKey is the key of the lane collecting tasks that don't fall into any other lane.
+const noLaneKey = "none"
+
+var ErrUnknownGrouping = errors.New("unknown swimlane grouping")
+
+func (s *Service) CreateSwimlane(swimlane *model.Swimlane) error {
+
+	const op = "board.service.CreateSwimlane"
+
+	err := s.store.Swimlane().CreateSwimlane(swimlane)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListSwimlanes(projectID int) ([]model.Swimlane, error) {
+
+	const op = "board.service.ListSwimlanes"
+
+	swimlanes, err := s.store.Swimlane().GetSwimlanes(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return swimlanes, nil
+}
+
+func (s *Service) UpdateSwimlane(swimlane model.Swimlane) error {
+
+	const op = "board.service.UpdateSwimlane"
+
+	err := s.store.Swimlane().UpdateSwimlane(swimlane)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteSwimlane(id int) error {
+
+	const op = "board.service.DeleteSwimlane"
+
+	err := s.store.Swimlane().DeleteSwimlane(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) CreateLabel(label *model.Label) error {
+
+	const op = "board.service.CreateLabel"
+
+	err := s.store.Label().CreateLabel(label)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListLabels(projectID int) ([]model.Label, error) {
+
+	const op = "board.service.ListLabels"
+
+	labels, err := s.store.Label().GetLabels(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return labels, nil
+}
+
+func (s *Service) DeleteLabel(id int) error {
+
+	const op = "board.service.DeleteLabel"
+
+	err := s.store.Label().DeleteLabel(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddTaskLabel(taskID int, labelID int) error {
+
+	const op = "board.service.AddTaskLabel"
+
+	err := s.store.Label().AddToTask(taskID, labelID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishTask(int64(taskID), 0, model.EventTaskUpdated)
+
+	return nil
+}
+
+func (s *Service) RemoveTaskLabel(taskID int, labelID int) error {
+
+	const op = "board.service.RemoveTaskLabel"
+
+	err := s.store.Label().RemoveFromTask(taskID, labelID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publishTask(int64(taskID), 0, model.EventTaskUpdated)
+
+	return nil
+}
+
+type lane struct {
+	key   string
+	name  string
+	tasks []model.Task
+}
+
+// laneSet keeps lanes in the order they were first seen.
+type laneSet struct {
+	lanes []*lane
+	index map[string]*lane
+}
+
+func newLaneSet() *laneSet {
+	return &laneSet{index: make(map[string]*lane)}
+}
+
+func (ls *laneSet) add(key string, name string) *lane {
+	if l, ok := ls.index[key]; ok {
+		return l
+	}
+
+	l := &lane{key: key, name: name}
+	ls.lanes = append(ls.lanes, l)
+	ls.index[key] = l
+
+	return l
+}
+
+func (ls *laneSet) list() []lane {
+	lanes := make([]lane, 0, len(ls.lanes))
+	for _, l := range ls.lanes {
+		lanes = append(lanes, *l)
+	}
+	return lanes
+}
+
+func (s *Service) manualLanes(projectID int, tasks []model.Task) ([]lane, error) {
+
+	swimlanes, err := s.store.Swimlane().GetSwimlanes(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := newLaneSet()
+	for _, sw := range swimlanes {
+		set.add(strconv.FormatInt(sw.ID, 10), sw.Name)
+	}
+	none := set.add(noLaneKey, "No lane")
+
+	for _, t := range tasks {
+		// a lane deleted between the two reads leaves its tasks without one
+		l, ok := set.index[strconv.FormatInt(t.ID_swimlane.Int64, 10)]
+		if !t.ID_swimlane.Valid || !ok {
+			l = none
+		}
+		l.tasks = append(l.tasks, t)
+	}
+
+	return set.list(), nil
+}
+
+func (s *Service) executorLanes(tasks []model.Task) ([]lane, error) {
+
+	set := newLaneSet()
+
+	for _, t := range tasks {
+		if !t.ID_executor.Valid {
+			continue
+		}
+
+		key := strconv.FormatInt(t.ID_executor.Int64, 10)
+		if _, ok := set.index[key]; !ok {
+			user, err := s.store.User().GetByID(int(t.ID_executor.Int64))
+			if err != nil {
+				return nil, err
+			}
+			set.add(key, user.Name)
+		}
+
+		set.index[key].tasks = append(set.index[key].tasks, t)
+	}
+
+	none := set.add(noLaneKey, "Unassigned")
+	for _, t := range tasks {
+		if !t.ID_executor.Valid {
+			none.tasks = append(none.tasks, t)
+		}
+	}
+
+	return set.list(), nil
+}
+
+// labelLanes puts a task into the lane of every label it has, so a task with
+// several labels shows up in several lanes.
+func (s *Service) labelLanes(projectID int, tasks []model.Task) ([]lane, error) {
+
+	labels, err := s.store.Label().GetLabels(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskLabels, err := s.store.Label().GetProjectTaskLabels(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := newLaneSet()
+	for _, l := range labels {
+		set.add(strconv.FormatInt(l.ID, 10), l.Name)
+	}
+	none := set.add(noLaneKey, "No label")
+
+	for _, t := range tasks {
+		if len(taskLabels[t.ID]) == 0 {
+			none.tasks = append(none.tasks, t)
+			continue
+		}
+		for _, l := range taskLabels[t.ID] {
+			ln := set.index[strconv.FormatInt(l.ID, 10)]
+			ln.tasks = append(ln.tasks, t)
+		}
+	}
+
+	return set.list(), nil
+}
+
+func priorityLanes(tasks []model.Task) []lane {
+
+	set := newLaneSet()
+	for _, p := range []string{
+		model.PriorityUrgent,
+		model.PriorityHigh,
+		model.PriorityMedium,
+		model.PriorityLow,
+	} {
+		set.add(p, p)
+	}
+
+	for _, t := range tasks {
+		l := set.add(t.Priority, t.Priority)
+		l.tasks = append(l.tasks, t)
+	}
+
+	return set.list()
+}
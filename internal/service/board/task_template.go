@@ -0,0 +1,249 @@
+package board
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/lib/rrule"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var ErrTaskTemplateEnded = errors.New("the recurrence rule has no repeats left")
+
+// taskTemplateBatch is how many due templates are handled in one run, the
+// rest wait for the next one.
+const taskTemplateBatch = 100
+
+// A repeat that failed to spawn is tried again after taskTemplateRetryBase,
+// the wait doubles with every failure up to taskTemplateRetryMax.
+const (
+	taskTemplateRetryBase = time.Minute
+	taskTemplateRetryMax  = 6 * time.Hour
+)
+
+// CreateTaskTemplate checks the rule and schedules its first repeat, the
+// earliest one after now. The rule is stored in its canonical form.
+func (s *Service) CreateTaskTemplate(userID int, template *model.TaskTemplate) error {
+
+	const op = "board.service.CreateTaskTemplate"
+
+	projectID, err := s.store.Column().GetProjectID(int(template.ID_column.Int64))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, int(projectID)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rule, err := rrule.Parse(template.Rule)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	if template.Starts_at.IsZero() {
+		template.Starts_at = now
+	}
+
+	next, ok := rule.Next(template.Starts_at, now)
+	if !ok {
+		return fmt.Errorf("%s: %w", op, ErrTaskTemplateEnded)
+	}
+
+	if template.Priority == "" {
+		template.Priority = model.PriorityMedium
+	}
+
+	template.ID_creator = int64(userID)
+	template.Rule = rule.String()
+	template.Next_at = sql.NullTime{Time: next, Valid: true}
+
+	if err := s.store.TaskTemplate().CreateTaskTemplate(template); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListTaskTemplates(userID int, projectID int) ([]model.TaskTemplate, error) {
+
+	const op = "board.service.ListTaskTemplates"
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	templates, err := s.store.TaskTemplate().GetTaskTemplates(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+// getTaskTemplate returns the template if the user works in its project.
+func (s *Service) getTaskTemplate(userID int, id int) (*model.TaskTemplate, error) {
+
+	template, err := s.store.TaskTemplate().GetTaskTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckMember(s.store, userID, int(template.ID_project)); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// SetTaskTemplateColumn moves the template to another column of its
+// project, templates whose column was deleted wait for one.
+func (s *Service) SetTaskTemplateColumn(userID int, id int, columnID int) error {
+
+	const op = "board.service.SetTaskTemplateColumn"
+
+	if _, err := s.getTaskTemplate(userID, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.store.TaskTemplate().SetColumn(id, int64(columnID)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteTaskTemplate stops the repeats, the tasks already created stay.
+func (s *Service) DeleteTaskTemplate(userID int, id int) error {
+
+	const op = "board.service.DeleteTaskTemplate"
+
+	if _, err := s.getTaskTemplate(userID, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.store.TaskTemplate().DeleteTaskTemplate(id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListTaskInstances(userID int, id int, page storage.Page) ([]model.TaskInstance, string, error) {
+
+	const op = "board.service.ListTaskInstances"
+
+	if _, err := s.getTaskTemplate(userID, id); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	instances, next, err := s.store.TaskTemplate().GetInstances(id, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return instances, next, nil
+}
+
+// SpawnRecurringTasks creates the tasks of the due templates and returns
+// how many were created. A template that missed several repeats, e.g.
+// while its project was archived, gets one task and moves on to its first
+// repeat after now. One whose column is full stays due and is tried again
+// on the next run.
+func (s *Service) SpawnRecurringTasks(ctx context.Context) (int, error) {
+
+	const op = "board.service.SpawnRecurringTasks"
+
+	now := time.Now()
+
+	templates, err := s.store.TaskTemplate().GetDue(now, taskTemplateBatch)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var created int
+
+	for _, template := range templates {
+		if ctx.Err() != nil {
+			break
+		}
+
+		task, err := s.spawn(template, now)
+		if errors.Is(err, storage.ErrTaskTemplateNotFound) {
+			continue
+		}
+		if err != nil {
+			slog.Warn("failed to create recurring task",
+				slog.String("op", op),
+				slog.Int64("template_id", template.ID),
+				slog.Int("failures", template.Failures+1),
+				sl.Err(err),
+			)
+			// Left as it is the template would come first in every batch.
+			retryAt := now.Add(taskTemplateRetry(template.Failures + 1))
+			if err := s.store.TaskTemplate().Postpone(&template, retryAt); err != nil && !errors.Is(err, storage.ErrTaskTemplateNotFound) {
+				return created, fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+
+		created++
+
+		s.publishTask(task.ID, task.ID_creator, model.EventTaskCreated)
+	}
+
+	return created, nil
+}
+
+// taskTemplateRetry returns the wait before the next try of a repeat that
+// failed the given number of times.
+func taskTemplateRetry(failures int) time.Duration {
+
+	wait := taskTemplateRetryBase
+	for i := 1; i < failures && wait < taskTemplateRetryMax; i++ {
+		wait *= 2
+	}
+
+	return min(wait, taskTemplateRetryMax)
+}
+
+func (s *Service) spawn(template model.TaskTemplate, now time.Time) (*model.Task, error) {
+
+	rule, err := rrule.Parse(template.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var next sql.NullTime
+
+	if rule.Count == 0 || template.Created_count+1 < rule.Count {
+		next.Time, next.Valid = rule.Next(template.Starts_at, now)
+	}
+
+	task := &model.Task{
+		ID_column:      template.ID_column.Int64,
+		Name:           template.Name,
+		Description:    template.Description,
+		Date_of_create: now.Format("2006-01-02"),
+		ID_executor:    template.ID_executor,
+		ID_creator:     template.ID_creator,
+		Priority:       template.Priority,
+		ID_swimlane:    template.ID_swimlane,
+		Estimate:       template.Estimate,
+	}
+
+	if err := s.store.TaskTemplate().Spawn(&template, task, next); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
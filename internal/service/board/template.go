@@ -0,0 +1,270 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var ErrInvalidTemplate = errors.New("a template needs uniquely named columns with in_progress and done among them, positive WIP limits and uniquely named labels")
+
+// maxTemplateName is the length of the name columns of the schema.
+const maxTemplateName = 255
+
+func wip(n int) *int {
+	return &n
+}
+
+// builtinTemplates are offered to everyone. The in_progress and done
+// columns are the ones moving a task changes its status by.
+var builtinTemplates = []model.Template{
+	{
+		Name:    model.TemplateBasicKanban,
+		Builtin: true,
+		Columns: []model.TemplateColumn{
+			{Name: model.StatusTodo},
+			{Name: model.StatusInProgress, WIP_limit: wip(3)},
+			{Name: model.StatusDone},
+		},
+		Labels: []string{"bug", "feature", "improvement"},
+	},
+	{
+		Name:    model.TemplateScrum,
+		Builtin: true,
+		Columns: []model.TemplateColumn{
+			{Name: "backlog"},
+			{Name: model.StatusTodo},
+			{Name: model.StatusInProgress, WIP_limit: wip(5)},
+			{Name: model.StatusDone},
+		},
+		Labels: []string{"story", "bug", "task", "spike"},
+	},
+}
+
+// builtinTemplate returns the built-in template of the name, nil when
+// there is none.
+func builtinTemplate(name string) *model.Template {
+	for i := range builtinTemplates {
+		if builtinTemplates[i].Name == name {
+			t := builtinTemplates[i]
+			return &t
+		}
+	}
+	return nil
+}
+
+// checkTemplate makes sure a project made from the template has a working
+// board.
+func checkTemplate(t *model.Template) error {
+
+	if t.Name == "" || len(t.Name) > maxTemplateName || len(t.Columns) == 0 {
+		return ErrInvalidTemplate
+	}
+
+	columns := make(map[string]bool, len(t.Columns))
+	for _, c := range t.Columns {
+		if strings.TrimSpace(c.Name) == "" || len(c.Name) > maxTemplateName || columns[c.Name] {
+			return ErrInvalidTemplate
+		}
+		if c.WIP_limit != nil && *c.WIP_limit <= 0 {
+			return ErrInvalidTemplate
+		}
+		columns[c.Name] = true
+	}
+
+	if !columns[model.StatusInProgress] || !columns[model.StatusDone] {
+		return ErrInvalidTemplate
+	}
+
+	labels := make(map[string]bool, len(t.Labels))
+	for _, l := range t.Labels {
+		if strings.TrimSpace(l) == "" || len(l) > maxTemplateName || labels[l] {
+			return ErrInvalidTemplate
+		}
+		labels[l] = true
+	}
+
+	return nil
+}
+
+// ListTemplates returns the built-in templates followed by the ones the
+// user saved.
+func (s *Service) ListTemplates(userID int) ([]model.Template, error) {
+
+	const op = "board.service.ListTemplates"
+
+	saved, err := s.store.Template().GetTemplates(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return append(slices.Clone(builtinTemplates), saved...), nil
+}
+
+// SaveTemplate stores a template of the user. With a project, the columns
+// of its first board and its labels make the template, whatever it held.
+func (s *Service) SaveTemplate(template *model.Template, projectID int) error {
+
+	const op = "board.service.SaveTemplate"
+
+	if builtinTemplate(template.Name) != nil {
+		return fmt.Errorf("%s: %w", op, storage.ErrTemplateExists)
+	}
+
+	if projectID != 0 {
+		member, err := s.store.Project().IsMember(int(template.ID_user), projectID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if !member {
+			return fmt.Errorf("%s: %w", op, storage.ErrProjectNotFound)
+		}
+
+		if err := s.layoutOf(template, projectID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if template.Labels == nil {
+		template.Labels = []string{}
+	}
+
+	if err := checkTemplate(template); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.store.Template().CreateTemplate(template); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// layoutOf fills the template with the layout of the project.
+func (s *Service) layoutOf(template *model.Template, projectID int) error {
+
+	boards, err := s.store.Project().GetBoards(projectID)
+	if err != nil {
+		return err
+	}
+
+	columns, err := s.store.Project().GetColumns(projectID)
+	if err != nil {
+		return err
+	}
+
+	labels, err := s.store.Label().GetLabels(projectID)
+	if err != nil {
+		return err
+	}
+
+	template.Columns = []model.TemplateColumn{}
+	for _, c := range columns {
+		if len(boards) == 0 || c.ID_board != boards[0].ID {
+			continue
+		}
+		tc := model.TemplateColumn{Name: c.Name}
+		if c.WIP_limit.Valid {
+			tc.WIP_limit = wip(int(c.WIP_limit.Int64))
+		}
+		template.Columns = append(template.Columns, tc)
+	}
+
+	template.Labels = make([]string, 0, len(labels))
+	for _, l := range labels {
+		template.Labels = append(template.Labels, l.Name)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteTemplate(userID int, id int) error {
+
+	const op = "board.service.DeleteTemplate"
+
+	if err := s.store.Template().DeleteTemplate(userID, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// findTemplate looks the name up among the built-in templates, then among
+// the ones the user saved.
+func (s *Service) findTemplate(userID int, name string) (*model.Template, error) {
+
+	if t := builtinTemplate(name); t != nil {
+		return t, nil
+	}
+
+	return s.store.Template().GetTemplate(userID, name)
+}
+
+// CloneProject copies the layout of a project the user works in, its
+// live boards, columns, swimlanes and labels, into a new project of theirs.
+// With tasks, its live tasks come along too; their history stays behind.
+func (s *Service) CloneProject(userID int, projectID int, name string, withTasks bool) (*model.Project, error) {
+
+	const op = "board.service.CloneProject"
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	exp, err := s.store.Export().GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc := &model.ProjectDocument{ProjectExport: *exp}
+
+	if name == "" {
+		name = exp.Project.Name + " (copy)"
+	}
+	doc.Project.Name = name
+
+	doc.Columns = slices.DeleteFunc(doc.Columns, func(c model.ExportedColumn) bool { return c.Archived_at != nil })
+
+	live := make(map[int64]bool, len(doc.Columns))
+	for _, c := range doc.Columns {
+		live[c.ID] = true
+	}
+
+	if withTasks {
+		today := time.Now().UTC().Format(time.DateOnly)
+
+		err := s.store.Export().EachTask(projectID, func(t model.ExportedTask) error {
+			if t.Archived_at != nil || !live[t.ID_column] {
+				return nil
+			}
+			doc.Tasks = append(doc.Tasks, t)
+			doc.Logs = append(doc.Logs, model.ExportedLog{
+				ID_task:           t.ID,
+				Date_of_operation: today,
+				Info:              fmt.Sprintf("clone of task %d", t.ID),
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	report, err := s.store.Import().Import(doc, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	project, err := s.store.Project().GetByID(int(report.ID_project))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return project, nil
+}
@@ -0,0 +1,133 @@
+package board
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var ErrInvalidTimeEntry = errors.New("a time entry needs an end after its start or a positive duration")
+
+// checkTaskMember makes sure the user works in the project of the task.
+func (s *Service) checkTaskMember(userID int, taskID int64) error {
+
+	projectID, err := s.store.Task().GetProjectID(int(taskID))
+	if err != nil {
+		return err
+	}
+
+	return service.CheckMember(s.store, userID, int(projectID))
+}
+
+// CreateTimeEntry records time spent without a timer. Of start, end and
+// duration two are enough: a duration alone ends now, one with a start
+// ends that long after it.
+func (s *Service) CreateTimeEntry(entry *model.TimeEntry, duration time.Duration) error {
+
+	const op = "board.service.CreateTimeEntry"
+
+	if duration < 0 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidTimeEntry)
+	}
+
+	switch {
+	case !entry.Ended_at.Valid && duration > 0:
+		if entry.Started_at.IsZero() {
+			entry.Started_at = time.Now().Add(-duration)
+		}
+		entry.Ended_at = sql.NullTime{Time: entry.Started_at.Add(duration), Valid: true}
+	case entry.Ended_at.Valid && duration > 0 && entry.Started_at.IsZero():
+		entry.Started_at = entry.Ended_at.Time.Add(-duration)
+	}
+
+	if entry.Started_at.IsZero() || !entry.Ended_at.Valid || !entry.Ended_at.Time.After(entry.Started_at) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidTimeEntry)
+	}
+
+	if err := s.checkTaskMember(int(entry.ID_user), entry.ID_task); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.store.TimeEntry().CreateEntry(entry); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) StartTimer(userID int, taskID int, note string) (*model.TimeEntry, error) {
+
+	const op = "board.service.StartTimer"
+
+	if err := s.checkTaskMember(userID, int64(taskID)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry := &model.TimeEntry{
+		ID_task: int64(taskID),
+		ID_user: int64(userID),
+		Note:    note,
+	}
+
+	if err := s.store.TimeEntry().StartTimer(entry); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (s *Service) StopTimer(userID int, note string) (*model.TimeEntry, error) {
+
+	const op = "board.service.StopTimer"
+
+	entry, err := s.store.TimeEntry().StopTimer(userID, note)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (s *Service) GetTimer(userID int) (*model.TimeEntry, error) {
+
+	const op = "board.service.GetTimer"
+
+	entry, err := s.store.TimeEntry().GetTimer(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (s *Service) ListTimeEntries(userID int, taskID int, page storage.Page) ([]model.TimeEntry, string, error) {
+
+	const op = "board.service.ListTimeEntries"
+
+	if err := s.checkTaskMember(userID, int64(taskID)); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, next, err := s.store.TimeEntry().GetEntries(taskID, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, next, nil
+}
+
+func (s *Service) DeleteTimeEntry(userID int, id int) error {
+
+	const op = "board.service.DeleteTimeEntry"
+
+	if err := s.store.TimeEntry().DeleteEntry(userID, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
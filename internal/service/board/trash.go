@@ -0,0 +1,163 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// Unlike archiving, moving to the trash deletes the rows right away. The
+// trash keeps a snapshot that can be restored within the undo window.
+
+func (s *Service) TrashColumn(userID int, id int) (*response.TrashItem, error) {
+
+	const op = "board.service.TrashColumn"
+
+	projectID, err := s.store.Column().GetProjectID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, int(projectID)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	item, err := s.store.Trash().TrashColumn(userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publish(item.ID_project, int64(userID), model.EventColumnDeleted, idOnly{ID: int64(id)})
+
+	resp, err := s.trashItem(*item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) TrashTask(userID int, id int) (*response.TrashItem, error) {
+
+	const op = "board.service.TrashTask"
+
+	item, err := s.store.Trash().TrashTask(userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.publish(item.ID_project, int64(userID), model.EventTaskDeleted, idOnly{ID: int64(id)})
+
+	resp, err := s.trashItem(*item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// ListTrash returns the items of the project that can still be restored.
+func (s *Service) ListTrash(userID int, projectID int, page storage.Page) ([]response.TrashItem, string, error) {
+
+	const op = "board.service.ListTrash"
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	items, next, err := s.store.Trash().GetItems(projectID, time.Now().Add(-s.undoWindow), page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp := make([]response.TrashItem, 0, len(items))
+
+	for _, item := range items {
+		ti, err := s.trashItem(item)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		resp = append(resp, *ti)
+	}
+
+	return resp, next, nil
+}
+
+func (s *Service) RestoreFromTrash(userID int, id int, fallbackColumnID int) error {
+
+	const op = "board.service.RestoreFromTrash"
+
+	projectID, err := s.store.Trash().GetProjectID(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := service.CheckMember(s.store, userID, int(projectID)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	item, err := s.store.Trash().Restore(userID, id, int64(fallbackColumnID), time.Now().Add(-s.undoWindow))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch item.Kind {
+	case model.TrashKindColumn:
+		var snapshot model.ColumnSnapshot
+		if err := json.Unmarshal(item.Snapshot, &snapshot); err == nil {
+			s.publishColumn(snapshot.Column.ID, int64(userID), model.EventColumnRestored)
+		}
+	case model.TrashKindTask:
+		var snapshot model.TaskSnapshot
+		if err := json.Unmarshal(item.Snapshot, &snapshot); err == nil {
+			s.publishTask(snapshot.Task.ID, int64(userID), model.EventTaskRestored)
+		}
+	}
+
+	return nil
+}
+
+// PurgeExpiredTrash drops the items whose undo window is over.
+func (s *Service) PurgeExpiredTrash() (int64, error) {
+
+	const op = "board.service.PurgeExpiredTrash"
+
+	purged, err := s.store.Trash().Purge(time.Now().Add(-s.undoWindow))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
+
+func (s *Service) trashItem(item model.TrashItem) (*response.TrashItem, error) {
+
+	resp := &response.TrashItem{
+		ID:        uint(item.ID),
+		Kind:      item.Kind,
+		ProjectID: uint(item.ID_project),
+		Name:      item.Name,
+		DeletedAt: item.Deleted_at,
+		UndoUntil: item.Deleted_at.Add(s.undoWindow),
+	}
+
+	if item.ID_user.Valid {
+		deletedBy := int(item.ID_user.Int64)
+		resp.DeletedBy = &deletedBy
+	}
+
+	if item.Kind == model.TrashKindColumn {
+		var snapshot model.ColumnSnapshot
+		if err := json.Unmarshal(item.Snapshot, &snapshot); err != nil {
+			return nil, err
+		}
+		resp.TaskCount = len(snapshot.Tasks)
+	}
+
+	return resp, nil
+}
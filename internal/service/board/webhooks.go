@@ -0,0 +1,281 @@
+package board
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var (
+	ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+	ErrUnknownEventType  = errors.New("unknown event type")
+)
+
+const (
+	// webhookBatch is how many deliveries are claimed at once.
+	webhookBatch = 20
+	// webhookLease keeps a claimed delivery from being claimed again while
+	// it is sent. It has to outlast a whole batch of timed out requests.
+	webhookLease = 10 * time.Minute
+	// maxErrorLength keeps the delivery log readable.
+	maxErrorLength = 500
+)
+
+// CreateWebhook adds a webhook to a project of the user. A secret is
+// generated when none is given, the webhook is returned with it once.
+func (s *Service) CreateWebhook(userID int, webhook *model.Webhook) error {
+
+	const op = "board.service.CreateWebhook"
+
+	if err := validateWebhook(*webhook); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		webhook.Secret = secret
+	}
+
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+
+	if err := s.store.Webhook().CreateWebhook(userID, webhook); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListWebhooks returns the webhooks of the project without their secrets.
+func (s *Service) ListWebhooks(userID int, projectID int) ([]model.Webhook, error) {
+
+	const op = "board.service.ListWebhooks"
+
+	webhooks, err := s.store.Webhook().GetWebhooks(userID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhook returns the webhook with its secret.
+func (s *Service) GetWebhook(userID int, id int) (*model.Webhook, error) {
+
+	const op = "board.service.GetWebhook"
+
+	webhook, err := s.store.Webhook().GetWebhook(userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) UpdateWebhook(userID int, webhook model.Webhook) error {
+
+	const op = "board.service.UpdateWebhook"
+
+	if err := validateWebhook(webhook); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+
+	if err := s.store.Webhook().UpdateWebhook(userID, webhook); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteWebhook(userID int, id int) error {
+
+	const op = "board.service.DeleteWebhook"
+
+	if err := s.store.Webhook().DeleteWebhook(userID, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListWebhookDeliveries(userID int, webhookID int, page storage.Page) ([]model.WebhookDelivery, string, error) {
+
+	const op = "board.service.ListWebhookDeliveries"
+
+	deliveries, next, err := s.store.Webhook().GetDeliveries(userID, webhookID, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, next, nil
+}
+
+// TestWebhook sends a ping event to the webhook right away and returns the
+// delivery. A failed ping is retried like any other delivery.
+func (s *Service) TestWebhook(ctx context.Context, userID int, id int) (*model.WebhookDelivery, error) {
+
+	const op = "board.service.TestWebhook"
+
+	webhook, err := s.store.Webhook().GetWebhook(userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload, err := json.Marshal(model.Event{
+		ID_project: webhook.ID_project,
+		Type:       model.WebhookEventPing,
+		ID_user:    int64(userID),
+		Data:       idOnly{ID: webhook.ID},
+		Created_at: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	delivery := &model.WebhookDelivery{
+		ID_webhook: webhook.ID,
+		Event_type: model.WebhookEventPing,
+		Payload:    payload,
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+	}
+
+	if err := s.store.Webhook().Enqueue(delivery, webhookLease); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.attemptDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return delivery, nil
+}
+
+// DeliverWebhooks sends the due deliveries batch by batch until none are
+// left and returns how many of them got through.
+func (s *Service) DeliverWebhooks(ctx context.Context) (int, error) {
+
+	const op = "board.service.DeliverWebhooks"
+
+	var delivered int
+
+	for ctx.Err() == nil {
+		deliveries, err := s.store.Webhook().ClaimDue(webhookBatch, webhookLease)
+		if err != nil {
+			return delivered, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for i := range deliveries {
+			if ctx.Err() != nil {
+				// what's left is claimed and waits for the lease to run out
+				break
+			}
+
+			if err := s.attemptDelivery(ctx, &deliveries[i]); err != nil {
+				return delivered, fmt.Errorf("%s: %w", op, err)
+			}
+
+			if deliveries[i].Status == model.DeliveryDelivered {
+				delivered++
+			}
+		}
+
+		if len(deliveries) < webhookBatch {
+			break
+		}
+	}
+
+	return delivered, nil
+}
+
+// attemptDelivery sends the delivery once and records the outcome. Only a
+// failure to record is returned, the failed attempt itself is in delivery.
+func (s *Service) attemptDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+
+	const op = "board.service.attemptDelivery"
+
+	code, err := s.webhooks.Send(ctx, delivery.URL, delivery.Secret, delivery.ID, delivery.Event_type, delivery.Payload)
+
+	delivery.Attempts++
+	delivery.Response_code = sql.NullInt64{Int64: int64(code), Valid: code != 0}
+
+	if err == nil {
+		delivery.Status = model.DeliveryDelivered
+		delivery.Last_error = ""
+		delivery.Delivered_at = sql.NullTime{Time: time.Now(), Valid: true}
+	} else {
+		delivery.Last_error = err.Error()
+		if len(delivery.Last_error) > maxErrorLength {
+			delivery.Last_error = delivery.Last_error[:maxErrorLength]
+		}
+
+		if wait, ok := s.webhooks.Retry(delivery.Attempts); ok {
+			delivery.Next_attempt_at = time.Now().Add(wait)
+		} else {
+			delivery.Status = model.DeliveryFailed
+		}
+
+		slog.Warn("webhook delivery failed",
+			slog.String("op", op),
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Int("attempts", delivery.Attempts),
+			sl.Err(err),
+		)
+	}
+
+	if err := s.store.Webhook().RecordAttempt(*delivery); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func validateWebhook(webhook model.Webhook) error {
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	for _, t := range webhook.Events {
+		if !slices.Contains(model.EventTypes, t) {
+			return fmt.Errorf("%w: %q", ErrUnknownEventType, t)
+		}
+	}
+
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var ErrNotProjectMember = errors.New("user does not work in the project")
+
+// CheckMember fails with ErrNotProjectMember unless the user works in the
+// project.
+func CheckMember(store storage.Store, userID int, projectID int) error {
+
+	member, err := store.Project().IsMember(userID, projectID)
+	if err != nil {
+		return err
+	}
+
+	if !member {
+		return ErrNotProjectMember
+	}
+
+	return nil
+}
@@ -1,8 +1,15 @@
 package service
 
 import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/events"
 	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/webhook"
 	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
 )
 
 type AuthService interface {
@@ -16,21 +23,104 @@ type BoardService interface {
 	DeleteUser(user_id int) error
 	UpdateEmail(user model.User) error
 	UpdatePassword(user model.User) error
-	CreateProject(project *model.Project) error
-	ReadProject(name string) (*response.ReadProjectResponse, error)
-	DeleteProject(userID int, name string) error
+	CreateProject(project *model.Project, template string) error
+	ReadProject(id int, page storage.Page) (*response.ReadProjectResponse, string, error)
+	ReadProjectBySlug(slug string, page storage.Page) (*response.ReadProjectResponse, string, error)
+	ReadProjectByName(name string, page storage.Page) (*response.ReadProjectResponse, string, error)
+	FindOwnProject(userID int, name string) (*model.Project, error)
+	ArchiveProject(userID int, id int) error
 	UpdateProjectDescription(project model.Project) error
-	UpdateProjectName(name string, project model.Project) error
-	ListProjects() ([]model.Project, error)
+	UpdateProjectName(project model.Project) error
+	ListProjects(page storage.Page) ([]model.Project, string, error)
 	CreateColumn(column *model.Column) error
-	ReadColumn(column model.Column) (*response.ReadColumnResponse, error)
-	DeleteColumn(id int) error
+	ReadColumn(column model.Column, page storage.Page) (*response.ReadColumnResponse, string, error)
+	ArchiveColumn(id int) error
 	UpdateColumnName(column model.Column, name string) error
+	UpdateColumnWIPLimit(column model.Column) error
 	CreateTask(task *model.Task) error
 	ReadTask(task *model.Task) error
-	DeleteTask(userID int, id int) error
-	UpdateTaskName(task *model.Task) error
-	UpdateTaskDescription(task *model.Task) error
-	UpdateTaskColumn(task *model.Task) error
-	GetLogsTask(id_task int) ([]model.Task_log, error)
+	ArchiveTask(userID int, id int) error
+	UpdateTask(userID int, id int, update model.TaskUpdate) error
+	UpdateTaskColumn(userID int, task *model.Task) error
+	AssignTask(userID int, taskID int, executorID int) error
+	GetLogsTask(id_task int, page storage.Page) ([]model.Task_log, string, error)
+	CreateSwimlane(swimlane *model.Swimlane) error
+	ListSwimlanes(projectID int) ([]model.Swimlane, error)
+	UpdateSwimlane(swimlane model.Swimlane) error
+	DeleteSwimlane(id int) error
+	CreateLabel(label *model.Label) error
+	ListLabels(projectID int) ([]model.Label, error)
+	DeleteLabel(id int) error
+	AddTaskLabel(taskID int, labelID int) error
+	RemoveTaskLabel(taskID int, labelID int) error
+	CreateBoard(board *model.Board) error
+	ListBoards(projectID int) ([]model.Board, error)
+	UpdateBoardName(board model.Board) error
+	DeleteBoard(id int) error
+	ReadBoard(boardID int, groupBy string) (*response.BoardResponse, error)
+	ListArchivedProjects(userID int, page storage.Page) ([]model.Project, string, error)
+	ReadArchive(userID int, projectID int) (*response.ArchiveResponse, error)
+	RestoreProject(userID int, id int) error
+	RestoreColumn(userID int, id int) error
+	RestoreTask(userID int, id int) error
+	TrashColumn(userID int, id int) (*response.TrashItem, error)
+	TrashTask(userID int, id int) (*response.TrashItem, error)
+	ListTrash(userID int, projectID int, page storage.Page) ([]response.TrashItem, string, error)
+	RestoreFromTrash(userID int, id int, fallbackColumnID int) error
+	SearchTasks(userID int, filter model.SearchFilter, page storage.Page) ([]response.SearchHit, string, error)
+	CreateFilter(filter *model.Filter) error
+	ListFilters(userID int, page storage.Page) ([]model.Filter, string, error)
+	DeleteFilter(userID int, id int) error
+	QueryTasks(userID int, query string, projectID int, page storage.Page) ([]model.Task, string, error)
+	RunFilter(userID int, id int, page storage.Page) ([]model.Task, string, error)
+	SubscribeProject(userID int, projectID int, after int64) (*events.Subscription, []model.Event, bool, error)
+	CreateWebhook(userID int, webhook *model.Webhook) error
+	ListWebhooks(userID int, projectID int) ([]model.Webhook, error)
+	GetWebhook(userID int, id int) (*model.Webhook, error)
+	UpdateWebhook(userID int, webhook model.Webhook) error
+	DeleteWebhook(userID int, id int) error
+	ListWebhookDeliveries(userID int, webhookID int, page storage.Page) ([]model.WebhookDelivery, string, error)
+	TestWebhook(ctx context.Context, userID int, id int) (*model.WebhookDelivery, error)
+	SetPushHook(userID int, projectID int) (string, error)
+	DeletePushHook(userID int, projectID int) error
+	ReceivePush(projectID int, in webhook.Incoming) (*response.PushResult, error)
+	ListNotifications(userID int, unreadOnly bool, page storage.Page) ([]model.Notification, int, string, error)
+	MarkNotificationsRead(userID int, ids []int64) (int64, error)
+	MarkAllNotificationsRead(userID int) (int64, error)
+	GetNotificationPreferences(userID int) ([]model.NotificationPreference, error)
+	SetNotificationPreferences(userID int, prefs []model.NotificationPreference) error
+	GetDigestSettings(userID int) (*model.DigestSettings, error)
+	SetDigestFrequency(userID int, frequency string) error
+	Unsubscribe(userID int, token string) error
+	ListTemplates(userID int) ([]model.Template, error)
+	SaveTemplate(template *model.Template, projectID int) error
+	DeleteTemplate(userID int, id int) error
+	CloneProject(userID int, projectID int, name string, withTasks bool) (*model.Project, error)
+	CreateTaskTemplate(userID int, template *model.TaskTemplate) error
+	ListTaskTemplates(userID int, projectID int) ([]model.TaskTemplate, error)
+	SetTaskTemplateColumn(userID int, id int, columnID int) error
+	DeleteTaskTemplate(userID int, id int) error
+	ListTaskInstances(userID int, id int, page storage.Page) ([]model.TaskInstance, string, error)
+	CreateTimeEntry(entry *model.TimeEntry, duration time.Duration) error
+	StartTimer(userID int, taskID int, note string) (*model.TimeEntry, error)
+	StopTimer(userID int, note string) (*model.TimeEntry, error)
+	GetTimer(userID int) (*model.TimeEntry, error)
+	ListTimeEntries(userID int, taskID int, page storage.Page) ([]model.TimeEntry, string, error)
+	DeleteTimeEntry(userID int, id int) error
+	CreateComment(comment *model.Comment) error
+	ListComments(userID int, taskID int, page storage.Page) ([]model.Comment, string, error)
+}
+
+type AnalyticsService interface {
+	FlowMetrics(userID int, projectID int, from time.Time, to time.Time) (*model.FlowMetrics, error)
+	CumulativeFlow(userID int, projectID int, boardID int, from time.Time, to time.Time) (*model.CumulativeFlow, error)
+	Throughput(userID int, projectID int, filter model.ReportFilter, from time.Time, to time.Time) (*model.Throughput, error)
+	Burndown(userID int, projectID int, filter model.ReportFilter, from time.Time, target time.Time) (*model.Burndown, error)
+	Workload(userID int, projectID int, days int) (*model.Workload, error)
+	TimeReport(userID int, projectID int, from time.Time, to time.Time) (*model.TimeReport, error)
+}
+
+type TransferService interface {
+	Export(userID int, projectID int, format string, open func(*model.ProjectExport) (io.Writer, error)) error
+	Import(userID int, format string, r io.Reader, name string, dryRun bool) (*model.ImportReport, error)
 }
@@ -0,0 +1,221 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wehw93/kanban-board/internal/lib/csvsafe"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvHeader names the columns of the flat task list.
+var csvHeader = []string{
+	"id", "board", "column", "swimlane", "name", "description", "status", "priority", "labels",
+	"creator", "executor", "date_of_create", "date_of_execution", "due_date", "estimate", "archived_at",
+}
+
+// Export streams the project to a member of it, see ExportProject.
+func (s *Service) Export(userID int, projectID int, format string, open func(*model.ProjectExport) (io.Writer, error)) error {
+
+	const op = "transfer.service.Export"
+
+	if err := service.CheckMember(s.store, userID, projectID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.ExportProject(projectID, format, open); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExportProject writes the project as a JSON document or its tasks as CSV,
+// one task at a time, all read from one snapshot of the database. open gets
+// the head of the document once it is read and returns where to write it;
+// until open is called nothing has been written. It doesn't check who asks,
+// tools run by the operator call it directly.
+func (s *Service) ExportProject(projectID int, format string, open func(*model.ProjectExport) (io.Writer, error)) error {
+
+	const op = "transfer.service.ExportProject"
+
+	if format != FormatJSON && format != FormatCSV {
+		return fmt.Errorf("%s: %w", op, ErrUnknownFormat)
+	}
+
+	err := s.store.Export().Snapshot(func(r storage.ExportReader) error {
+
+		exp, err := r.GetProject(projectID)
+		if err != nil {
+			return err
+		}
+
+		w, err := open(exp)
+		if err != nil {
+			return err
+		}
+
+		if format == FormatCSV {
+			return writeCSV(w, r, exp)
+		}
+
+		return writeJSON(w, r, exp)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, r storage.ExportReader, exp *model.ProjectExport) error {
+
+	bw := bufio.NewWriter(w)
+
+	head, err := json.Marshal(exp)
+	if err != nil {
+		return err
+	}
+
+	// The head is an object of its own, tasks, logs and comments go in
+	// before its closing brace.
+	bw.Write(bytes.TrimSuffix(head, []byte("}")))
+
+	bw.WriteString(`,"tasks":[`)
+	err = r.EachTask(int(exp.Project.ID), jsonItems[model.ExportedTask](bw))
+	if err != nil {
+		return err
+	}
+
+	bw.WriteString(`],"logs":[`)
+	err = r.EachLog(int(exp.Project.ID), jsonItems[model.ExportedLog](bw))
+	if err != nil {
+		return err
+	}
+
+	bw.WriteString(`],"comments":[`)
+	err = r.EachComment(int(exp.Project.ID), jsonItems[model.ExportedComment](bw))
+	if err != nil {
+		return err
+	}
+
+	bw.WriteString("]}\n")
+
+	return bw.Flush()
+}
+
+// jsonItems returns a callback writing every item it gets as an element of
+// a JSON array.
+func jsonItems[T any](bw *bufio.Writer) func(T) error {
+
+	first := true
+
+	return func(v T) error {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		if !first {
+			bw.WriteByte(',')
+		}
+		first = false
+
+		_, err = bw.Write(b)
+		return err
+	}
+}
+
+func writeCSV(w io.Writer, r storage.ExportReader, exp *model.ProjectExport) error {
+
+	boards := make(map[int64]string, len(exp.Boards))
+	for _, b := range exp.Boards {
+		boards[b.ID] = b.Name
+	}
+
+	columns := make(map[int64]model.ExportedColumn, len(exp.Columns))
+	for _, c := range exp.Columns {
+		columns[c.ID] = c
+	}
+
+	swimlanes := make(map[int64]string, len(exp.Swimlanes))
+	for _, sl := range exp.Swimlanes {
+		swimlanes[sl.ID] = sl.Name
+	}
+
+	labels := make(map[int64]string, len(exp.Labels))
+	for _, l := range exp.Labels {
+		labels[l.ID] = l.Name
+	}
+
+	cw := csvsafe.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	err := r.EachTask(int(exp.Project.ID), func(t model.ExportedTask) error {
+
+		column := columns[t.ID_column]
+
+		var swimlane string
+		if t.ID_swimlane != nil {
+			swimlane = swimlanes[*t.ID_swimlane]
+		}
+
+		names := make([]string, 0, len(t.Labels))
+		for _, id := range t.Labels {
+			names = append(names, labels[id])
+		}
+
+		var estimate string
+		if t.Estimate != nil {
+			estimate = strconv.Itoa(*t.Estimate)
+		}
+
+		return cw.Write([]string{
+			strconv.FormatInt(t.ID, 10),
+			boards[column.ID_board],
+			column.Name,
+			swimlane,
+			t.Name,
+			t.Description,
+			t.Status,
+			t.Priority,
+			strings.Join(names, ";"),
+			t.Creator,
+			deref(t.Executor),
+			t.Date_of_create,
+			deref(t.Date_of_execution),
+			deref(t.Due_date),
+			estimate,
+			deref(t.Archived_at),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
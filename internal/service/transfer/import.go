@@ -0,0 +1,334 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+const (
+	FormatTrello = "trello"
+	FormatJira   = "jira"
+)
+
+// DocumentError tells what is wrong with an import document.
+type DocumentError struct {
+	Msg string
+}
+
+func (e *DocumentError) Error() string {
+	return "invalid import document: " + e.Msg
+}
+
+func documentError(format string, args ...any) error {
+	return &DocumentError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// maxNameLength is the length of the name columns of the schema.
+const maxNameLength = 255
+
+// Import creates a project for the user from a document in one of the
+// formats. A name replaces the one of the document, to import the same
+// project twice. On a dry run nothing is kept, the report tells what
+// would have been created.
+func (s *Service) Import(userID int, format string, r io.Reader, name string, dryRun bool) (*model.ImportReport, error) {
+
+	const op = "transfer.service.Import"
+
+	var (
+		doc     *model.ProjectDocument
+		renamed = []model.RenamedColumn{}
+		err     error
+	)
+
+	switch format {
+	case FormatJSON:
+		doc, err = readDocument(r)
+	case FormatTrello:
+		doc, err = readTrello(r)
+	case FormatJira:
+		doc, err = readJira(r)
+	default:
+		err = ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if format != FormatJSON {
+		renamed = statusColumns(doc)
+	}
+
+	if name != "" {
+		doc.Project.Name = name
+	}
+
+	if err := checkDocument(doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report, err := s.store.Import().Import(doc, userID, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report.Renamed_columns = renamed
+
+	return report, nil
+}
+
+// readDocument reads a document of our own export.
+func readDocument(r io.Reader) (*model.ProjectDocument, error) {
+
+	var doc model.ProjectDocument
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, &DocumentError{Msg: err.Error()}
+	}
+
+	if doc.Version != model.ExportVersion {
+		return nil, documentError("unsupported version %d", doc.Version)
+	}
+
+	return &doc, nil
+}
+
+// checkDocument makes sure every reference of the document leads somewhere
+// and every value fits the schema, filling in the defaults of the board.
+func checkDocument(doc *model.ProjectDocument) error {
+
+	doc.Project.Name = strings.TrimSpace(doc.Project.Name)
+	if doc.Project.Name == "" || len(doc.Project.Name) > maxNameLength {
+		return documentError("project name must be 1 to %d characters", maxNameLength)
+	}
+
+	if len(doc.Boards) == 0 {
+		return documentError("no boards")
+	}
+
+	boards := make(map[int64]bool, len(doc.Boards))
+	for _, b := range doc.Boards {
+		if boards[b.ID] {
+			return documentError("board %d is repeated", b.ID)
+		}
+		if !validName(b.Name) {
+			return documentError("board %d: name must be 1 to %d characters", b.ID, maxNameLength)
+		}
+		boards[b.ID] = true
+	}
+
+	columns := make(map[int64]bool, len(doc.Columns))
+	for _, c := range doc.Columns {
+		if columns[c.ID] {
+			return documentError("column %d is repeated", c.ID)
+		}
+		if !boards[c.ID_board] {
+			return documentError("column %d: unknown board %d", c.ID, c.ID_board)
+		}
+		if !validName(c.Name) {
+			return documentError("column %d: name must be 1 to %d characters", c.ID, maxNameLength)
+		}
+		if c.WIP_limit != nil && *c.WIP_limit <= 0 {
+			return documentError("column %d: wip_limit must be positive", c.ID)
+		}
+		if !validTime(c.Archived_at) {
+			return documentError("column %d: archived_at must be an RFC 3339 time", c.ID)
+		}
+		columns[c.ID] = true
+	}
+
+	swimlanes := make(map[int64]bool, len(doc.Swimlanes))
+	for _, sl := range doc.Swimlanes {
+		if swimlanes[sl.ID] {
+			return documentError("swimlane %d is repeated", sl.ID)
+		}
+		if !validName(sl.Name) {
+			return documentError("swimlane %d: name must be 1 to %d characters", sl.ID, maxNameLength)
+		}
+		swimlanes[sl.ID] = true
+	}
+
+	labels := make(map[int64]bool, len(doc.Labels))
+	labelNames := make(map[string]bool, len(doc.Labels))
+	for _, l := range doc.Labels {
+		if labels[l.ID] {
+			return documentError("label %d is repeated", l.ID)
+		}
+		if !validName(l.Name) {
+			return documentError("label %d: name must be 1 to %d characters", l.ID, maxNameLength)
+		}
+		if labelNames[l.Name] {
+			return documentError("label %q is repeated", l.Name)
+		}
+		labels[l.ID] = true
+		labelNames[l.Name] = true
+	}
+
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	tasks := make(map[int64]bool, len(doc.Tasks))
+	for i := range doc.Tasks {
+		t := &doc.Tasks[i]
+
+		if tasks[t.ID] {
+			return documentError("task %d is repeated", t.ID)
+		}
+		if !columns[t.ID_column] {
+			return documentError("task %d: unknown column %d", t.ID, t.ID_column)
+		}
+		if t.ID_swimlane != nil && !swimlanes[*t.ID_swimlane] {
+			return documentError("task %d: unknown swimlane %d", t.ID, *t.ID_swimlane)
+		}
+		for _, l := range t.Labels {
+			if !labels[l] {
+				return documentError("task %d: unknown label %d", t.ID, l)
+			}
+		}
+		if t.Name == "" {
+			return documentError("task %d: name is empty", t.ID)
+		}
+		if !model.ValidStatus(t.Status) {
+			return documentError("task %d: unknown status %q", t.ID, t.Status)
+		}
+		if t.Priority == "" {
+			t.Priority = model.PriorityMedium
+		}
+		if !model.ValidPriority(t.Priority) {
+			return documentError("task %d: unknown priority %q", t.ID, t.Priority)
+		}
+		if t.Date_of_create == "" {
+			t.Date_of_create = today
+		}
+		if !validDate(&t.Date_of_create) || !validDate(t.Date_of_execution) || !validDate(t.Due_date) {
+			return documentError("task %d: dates must be YYYY-MM-DD", t.ID)
+		}
+		if t.Estimate != nil && *t.Estimate < 0 {
+			return documentError("task %d: estimate must not be negative", t.ID)
+		}
+		if !validTime(t.Archived_at) {
+			return documentError("task %d: archived_at must be an RFC 3339 time", t.ID)
+		}
+		tasks[t.ID] = true
+	}
+
+	for _, l := range doc.Logs {
+		if !tasks[l.ID_task] {
+			return documentError("log of unknown task %d", l.ID_task)
+		}
+		if !validDate(&l.Date_of_operation) {
+			return documentError("log of task %d: date must be YYYY-MM-DD", l.ID_task)
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	for i := range doc.Comments {
+		c := &doc.Comments[i]
+
+		if !tasks[c.ID_task] {
+			return documentError("comment on unknown task %d", c.ID_task)
+		}
+		if strings.TrimSpace(c.Body) == "" {
+			return documentError("comment on task %d: body is empty", c.ID_task)
+		}
+		if c.Created_at == "" {
+			c.Created_at = now
+		}
+		if !validTime(&c.Created_at) {
+			return documentError("comment on task %d: created_at must be an RFC 3339 time", c.ID_task)
+		}
+	}
+
+	return nil
+}
+
+func validName(name string) bool {
+	return strings.TrimSpace(name) != "" && len(name) <= maxNameLength
+}
+
+// validDate accepts a missing date.
+func validDate(d *string) bool {
+	if d == nil {
+		return true
+	}
+	_, err := time.Parse(time.DateOnly, *d)
+	return err == nil
+}
+
+// validTime accepts a missing time.
+func validTime(t *string) bool {
+	if t == nil {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, *t)
+	return err == nil
+}
+
+// statusColumns gives the board of a converted document the in_progress
+// and done columns moving tasks depends on: the first open column guessed
+// to hold the status takes its name, or an empty one is put in its place.
+// The columns renamed are returned with the names they had.
+func statusColumns(doc *model.ProjectDocument) []model.RenamedColumn {
+
+	renamed := []model.RenamedColumn{}
+
+	for _, status := range []string{model.StatusInProgress, model.StatusDone} {
+
+		var (
+			found bool
+			maxID int64
+			at    = len(doc.Columns)
+		)
+
+		for i := range doc.Columns {
+			c := &doc.Columns[i]
+			maxID = max(maxID, c.ID)
+
+			if c.Archived_at != nil {
+				continue
+			}
+			if !found && statusOf(c.Name) == status {
+				if c.Name != status {
+					renamed = append(renamed, model.RenamedColumn{From: c.Name, To: status})
+				}
+				c.Name = status
+				found = true
+			}
+			if at == len(doc.Columns) && statusOrder[statusOf(c.Name)] > statusOrder[status] {
+				at = i
+			}
+		}
+
+		if !found {
+			doc.Columns = slices.Insert(doc.Columns, at, model.ExportedColumn{ID: maxID + 1, ID_board: doc.Boards[0].ID, Name: status})
+		}
+	}
+
+	return renamed
+}
+
+// statusOf guesses the status of the tasks in a list or column of another
+// tracker from its name.
+func statusOf(name string) string {
+
+	name = strings.ToLower(name)
+
+	for _, w := range []string{"done", "complete", "closed", "resolved", "finished", "готово", "сделано"} {
+		if strings.Contains(name, w) {
+			return model.StatusDone
+		}
+	}
+
+	for _, w := range []string{"progress", "doing", "review", "testing", "wip", "в работе"} {
+		if strings.Contains(name, w) {
+			return model.StatusInProgress
+		}
+	}
+
+	return model.StatusTodo
+}
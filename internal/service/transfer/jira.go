@@ -0,0 +1,215 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+// jiraTimeLayouts are the date formats Jira writes depending on the
+// language and the version of the site, the first one is the default.
+var jiraTimeLayouts = []string{
+	"02/Jan/06 3:04 PM",
+	"2/Jan/06 3:04 PM",
+	"02/Jan/06",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// jiraPriorities maps the priority schemes of Jira onto the board's.
+var jiraPriorities = map[string]string{
+	"highest":  model.PriorityUrgent,
+	"blocker":  model.PriorityUrgent,
+	"critical": model.PriorityUrgent,
+	"high":     model.PriorityHigh,
+	"major":    model.PriorityHigh,
+	"medium":   model.PriorityMedium,
+	"low":      model.PriorityLow,
+	"minor":    model.PriorityLow,
+	"lowest":   model.PriorityLow,
+	"trivial":  model.PriorityLow,
+}
+
+// statusOrder puts the columns made of Jira statuses in the order of the
+// board.
+var statusOrder = map[string]int{
+	model.StatusTodo:       0,
+	model.StatusInProgress: 1,
+	model.StatusDone:       2,
+}
+
+// readJira turns a Jira issue export into a project with a single board,
+// a column for every status. Users are matched by what the Assignee and
+// Reporter columns hold, an email on sites configured to export them.
+func readJira(r io.Reader) (*model.ProjectDocument, error) {
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, &DocumentError{Msg: err.Error()}
+	}
+
+	// Columns repeat for every value of multi-valued fields like labels.
+	fields := make(map[string][]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		fields[h] = append(fields[h], i)
+	}
+
+	if len(fields["summary"]) == 0 || len(fields["status"]) == 0 {
+		return nil, &DocumentError{Msg: "summary and status columns are required"}
+	}
+
+	get := func(record []string, names ...string) string {
+		for _, name := range names {
+			for _, i := range fields[name] {
+				if i < len(record) && strings.TrimSpace(record[i]) != "" {
+					return strings.TrimSpace(record[i])
+				}
+			}
+		}
+		return ""
+	}
+
+	now := time.Now().UTC()
+	today := now.Format(time.DateOnly)
+
+	doc := &model.ProjectDocument{
+		ProjectExport: model.ProjectExport{
+			Version: model.ExportVersion,
+			Boards:  []model.ExportedBoard{{ID: 1}},
+		},
+	}
+
+	columns := make(map[string]int64)
+	labels := make(map[string]int64)
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &DocumentError{Msg: err.Error()}
+		}
+
+		if doc.Project.Name == "" {
+			doc.Project.Name = get(record, "project name")
+		}
+
+		status := get(record, "status")
+		column, ok := columns[status]
+		if !ok {
+			column = int64(len(columns) + 1)
+			columns[status] = column
+			doc.Columns = append(doc.Columns, model.ExportedColumn{ID: column, ID_board: 1, Name: status})
+		}
+
+		key := get(record, "issue key")
+
+		t := model.ExportedTask{
+			ID:             int64(len(doc.Tasks) + 1),
+			ID_column:      column,
+			Name:           get(record, "summary"),
+			Description:    get(record, "description"),
+			Status:         statusOf(status),
+			Priority:       model.PriorityMedium,
+			Labels:         []int64{},
+			Creator:        get(record, "reporter", "creator"),
+			Date_of_create: today,
+		}
+
+		if p, ok := jiraPriorities[strings.ToLower(get(record, "priority"))]; ok {
+			t.Priority = p
+		}
+
+		if assignee := get(record, "assignee"); assignee != "" {
+			t.Executor = &assignee
+		}
+
+		if created, ok := jiraTime(get(record, "created")); ok {
+			t.Date_of_create = created.Format(time.DateOnly)
+		}
+
+		if due, ok := jiraTime(get(record, "due date")); ok {
+			d := due.Format(time.DateOnly)
+			t.Due_date = &d
+		}
+
+		if t.Status == model.StatusDone {
+			doneOn := today
+			if resolved, ok := jiraTime(get(record, "resolved")); ok {
+				doneOn = resolved.Format(time.DateOnly)
+			}
+			t.Date_of_execution = &doneOn
+		}
+
+		if points := get(record, "custom field (story points)", "custom field (story point estimate)"); points != "" {
+			if f, err := strconv.ParseFloat(points, 64); err == nil && f >= 0 {
+				estimate := int(f + 0.5)
+				t.Estimate = &estimate
+			}
+		}
+
+		for _, i := range fields["labels"] {
+			if i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			name := strings.TrimSpace(record[i])
+			id, ok := labels[name]
+			if !ok {
+				id = int64(len(labels) + 1)
+				labels[name] = id
+				doc.Labels = append(doc.Labels, model.ExportedLabel{ID: id, Name: name})
+			}
+			if !slices.Contains(t.Labels, id) {
+				t.Labels = append(t.Labels, id)
+			}
+		}
+
+		doc.Tasks = append(doc.Tasks, t)
+		doc.Logs = append(doc.Logs, model.ExportedLog{
+			ID_task:           t.ID,
+			Date_of_operation: today,
+			Info:              strings.TrimSpace("import from jira issue " + key),
+		})
+	}
+
+	if doc.Project.Name == "" {
+		doc.Project.Name = "Jira import"
+	}
+	doc.Boards[0].Name = doc.Project.Name
+
+	sort.SliceStable(doc.Columns, func(i, j int) bool {
+		return statusOrder[statusOf(doc.Columns[i].Name)] < statusOrder[statusOf(doc.Columns[j].Name)]
+	})
+
+	return doc, nil
+}
+
+// jiraTime parses a Jira date in any of the layouts it comes in.
+func jiraTime(s string) (time.Time, bool) {
+
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range jiraTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,22 @@
+package transfer
+
+import (
+	"errors"
+
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format")
+)
+
+// Service moves projects in and out of the board as documents.
+type Service struct {
+	store storage.Store
+}
+
+func NewService(store storage.Store) *Service {
+	return &Service{
+		store: store,
+	}
+}
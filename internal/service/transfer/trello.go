@@ -0,0 +1,212 @@
+package transfer
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+// trelloBoard is the part of a Trello board export the import reads.
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Desc  string `json:"desc"`
+	Lists []struct {
+		ID     string  `json:"id"`
+		Name   string  `json:"name"`
+		Closed bool    `json:"closed"`
+		Pos    float64 `json:"pos"`
+	} `json:"lists"`
+	Cards []struct {
+		ID               string   `json:"id"`
+		Name             string   `json:"name"`
+		Desc             string   `json:"desc"`
+		IDList           string   `json:"idList"`
+		Closed           bool     `json:"closed"`
+		Due              *string  `json:"due"`
+		IDLabels         []string `json:"idLabels"`
+		IDMembers        []string `json:"idMembers"`
+		DateLastActivity string   `json:"dateLastActivity"`
+		Pos              float64  `json:"pos"`
+	} `json:"cards"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"members"`
+}
+
+// readTrello turns a Trello board into a project with a single board,
+// lists becoming columns. Trello exports carry no emails, members are
+// named by their username and show up among the unknown users unless an
+// account has it for an email.
+func readTrello(r io.Reader) (*model.ProjectDocument, error) {
+
+	var tb trelloBoard
+
+	if err := json.NewDecoder(r).Decode(&tb); err != nil {
+		return nil, &DocumentError{Msg: err.Error()}
+	}
+
+	now := time.Now().UTC()
+
+	doc := &model.ProjectDocument{
+		ProjectExport: model.ProjectExport{
+			Version: model.ExportVersion,
+			Project: model.ExportedProject{
+				Name:        tb.Name,
+				Description: tb.Desc,
+			},
+			Boards: []model.ExportedBoard{{ID: 1, Name: tb.Name}},
+		},
+	}
+
+	sort.SliceStable(tb.Lists, func(i, j int) bool { return tb.Lists[i].Pos < tb.Lists[j].Pos })
+
+	type list struct {
+		column int64
+		order  int
+		status string
+	}
+
+	lists := make(map[string]list, len(tb.Lists))
+	for i, l := range tb.Lists {
+		c := model.ExportedColumn{
+			ID:       int64(i + 1),
+			ID_board: 1,
+			Name:     l.Name,
+		}
+		if l.Closed {
+			archived := now.Format(time.RFC3339)
+			c.Archived_at = &archived
+		}
+		doc.Columns = append(doc.Columns, c)
+		lists[l.ID] = list{column: c.ID, order: i, status: statusOf(l.Name)}
+	}
+
+	// Trello lets labels share a name or go without one, the board wants
+	// them named and apart.
+	labels := make(map[string]int64, len(tb.Labels))
+	byName := make(map[string]int64, len(tb.Labels))
+	for _, l := range tb.Labels {
+		name := l.Name
+		if name == "" {
+			name = l.Color
+		}
+		if name == "" {
+			continue
+		}
+		id, ok := byName[name]
+		if !ok {
+			id = int64(len(byName) + 1)
+			byName[name] = id
+			doc.Labels = append(doc.Labels, model.ExportedLabel{ID: id, Name: name})
+		}
+		labels[l.ID] = id
+	}
+
+	members := make(map[string]string, len(tb.Members))
+	for _, m := range tb.Members {
+		members[m.ID] = m.Username
+	}
+
+	cards := tb.Cards
+	sort.SliceStable(cards, func(i, j int) bool {
+		li, lj := lists[cards[i].IDList], lists[cards[j].IDList]
+		if li.order != lj.order {
+			return li.order < lj.order
+		}
+		return cards[i].Pos < cards[j].Pos
+	})
+
+	today := now.Format(time.DateOnly)
+
+	for i, c := range cards {
+		l, ok := lists[c.IDList]
+		if !ok {
+			return nil, documentError("card %s: unknown list %s", c.ID, c.IDList)
+		}
+
+		active := trelloTime(c.DateLastActivity, now)
+
+		t := model.ExportedTask{
+			ID:             int64(i + 1),
+			ID_column:      l.column,
+			Name:           c.Name,
+			Description:    c.Desc,
+			Status:         l.status,
+			Priority:       model.PriorityMedium,
+			Labels:         []int64{},
+			Date_of_create: trelloCreated(c.ID, active).Format(time.DateOnly),
+		}
+
+		for _, id := range c.IDLabels {
+			if label, ok := labels[id]; ok && !slices.Contains(t.Labels, label) {
+				t.Labels = append(t.Labels, label)
+			}
+		}
+
+		if len(c.IDMembers) > 0 {
+			if username, ok := members[c.IDMembers[0]]; ok {
+				t.Executor = &username
+			}
+		}
+
+		if c.Due != nil {
+			due := trelloTime(*c.Due, now).Format(time.DateOnly)
+			t.Due_date = &due
+		}
+
+		if t.Status == model.StatusDone {
+			doneOn := active.Format(time.DateOnly)
+			t.Date_of_execution = &doneOn
+		}
+
+		if c.Closed {
+			archived := active.Format(time.RFC3339)
+			t.Archived_at = &archived
+		}
+
+		doc.Tasks = append(doc.Tasks, t)
+		doc.Logs = append(doc.Logs, model.ExportedLog{
+			ID_task:           t.ID,
+			Date_of_operation: today,
+			Info:              "import from trello card " + c.ID,
+		})
+	}
+
+	return doc, nil
+}
+
+// trelloTime parses a Trello timestamp, def when there is none.
+func trelloTime(s string, def time.Time) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return def
+	}
+	return t.UTC()
+}
+
+// trelloCreated reads the creation time of a card from its id, the first
+// four bytes of a Trello id are a Unix time.
+func trelloCreated(id string, def time.Time) time.Time {
+
+	if len(id) < 8 {
+		return def
+	}
+
+	sec, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return def
+	}
+
+	return time.Unix(sec, 0).UTC()
+}
@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type ArchiveRepository interface {
+	GetProjects(userID int, page Page) ([]model.Project, string, error)
+	GetColumns(projectID int) ([]model.Column, error)
+	GetTasks(projectID int) ([]model.Task, error)
+	Purge(before time.Time) (int64, error)
+}
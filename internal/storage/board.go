@@ -3,11 +3,29 @@ package storage
 import "github.com/wehw93/kanban-board/internal/model"
 
 type ProjectRepository interface {
-	Create(project *model.Project) error
+	// Create makes the project with its default board, laid out after the
+	// template unless it is nil.
+	Create(project *model.Project, template *model.Template) error
+	GetByID(id int) (*model.Project, error)
+	GetBySlug(slug string) (*model.Project, error)
 	GetByName(name string) (*model.Project, error)
-	GetTasks(projectID int) ([]model.Task, error)
-	Delete(userID int, name string) error
-	UpdateName(name string, project model.Project) error
+	GetOwnByName(userID int, name string) (*model.Project, error)
+	IsMember(userID int, projectID int) (bool, error)
+	GetTasks(projectID int, page Page) ([]model.Task, string, error)
+	GetColumns(projectID int) ([]model.Column, error)
+	GetBoards(projectID int) ([]model.Board, error)
+	Archive(userID int, id int) error
+	Unarchive(userID int, id int) error
+	UpdateName(project model.Project) error
 	UpdateDescription(project model.Project) error
-	ListProjects() ([]model.Project, error)
+	ListProjects(page Page) ([]model.Project, string, error)
+}
+
+type BoardRepository interface {
+	CreateBoard(board *model.Board) error
+	ReadBoard(board *model.Board) error
+	GetColumns(boardID int) ([]model.Column, error)
+	GetTasks(boardID int) ([]model.Task, error)
+	DeleteBoard(id int) error
+	UpdateBoardName(board model.Board) error
 }
@@ -5,7 +5,13 @@ import "github.com/wehw93/kanban-board/internal/model"
 type ColumnRepository interface {
 	CreateColumn(column *model.Column) error
 	GetID(column model.Column) (int, error)
-	GetTasks(column model.Column) ([]model.Task, error)
-	DeleteColumn(id int) error
+	ReadColumn(column *model.Column) error
+	ReadColumnByID(column *model.Column) error
+	GetProjectID(id int) (int64, error)
+	GetTasks(column model.Column, page Page) ([]model.Task, string, error)
+	CountTasks(id int) (int, error)
+	ArchiveColumn(id int) error
+	UnarchiveColumn(id int) error
 	UpdateColumnName(column model.Column, name string) error
+	UpdateColumnWIPLimit(column model.Column) error
 }
@@ -0,0 +1,9 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type CommentRepository interface {
+	// CreateComment adds a comment to a live task.
+	CreateComment(comment *model.Comment) error
+	GetComments(taskID int, page Page) ([]model.Comment, string, error)
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type DigestRepository interface {
+	GetSettings(userID int) (*model.DigestSettings, error)
+	SetFrequency(userID int, frequency string) error
+	// ClaimDue marks up to limit users whose digest is due as sent and
+	// returns them, so no other instance sends them the same digest.
+	ClaimDue(limit int) ([]model.DigestRecipient, error)
+	// GetDigest collects the tasks of the user, at most limit in every
+	// section, with changes counted since the given moment.
+	GetDigest(userID int64, since time.Time, limit int) (*model.Digest, error)
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type EventRepository interface {
+	Create(event *model.Event) error
+	GetByID(id int64) (*model.Event, error)
+	GetAfter(projectID int, after int64, limit int) ([]model.Event, error)
+	// IDRange returns the IDs of the oldest and the newest stored event of
+	// all projects, zeros when there are none.
+	IDRange() (first int64, last int64, err error)
+	Purge(before time.Time) (int64, error)
+}
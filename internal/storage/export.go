@@ -0,0 +1,27 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+// ExportReader reads a project the way the export document lays it out.
+type ExportReader interface {
+	// GetProject returns the project with its boards, columns, swimlanes
+	// and labels, archived ones included.
+	GetProject(projectID int) (*model.ProjectExport, error)
+	// EachTask calls fn with every task of the project in id order, without
+	// holding them all in memory; an error from fn stops the walk.
+	EachTask(projectID int, fn func(model.ExportedTask) error) error
+	// EachLog calls fn with every log entry of the tasks of the project,
+	// ordered by task and entry.
+	EachLog(projectID int, fn func(model.ExportedLog) error) error
+	// EachComment calls fn with every comment on the tasks of the project,
+	// ordered by task and comment.
+	EachComment(projectID int, fn func(model.ExportedComment) error) error
+}
+
+type ExportRepository interface {
+	ExportReader
+	// Snapshot calls fn with a reader that sees the database as it was when
+	// the snapshot was taken, so a project read in several steps stays
+	// consistent. The reader is only valid until fn returns.
+	Snapshot(fn func(ExportReader) error) error
+}
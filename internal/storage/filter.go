@@ -0,0 +1,10 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type FilterRepository interface {
+	CreateFilter(filter *model.Filter) error
+	GetFilters(userID int, page Page) ([]model.Filter, string, error)
+	GetFilter(userID int, id int) (*model.Filter, error)
+	DeleteFilter(userID int, id int) error
+}
@@ -0,0 +1,10 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type ImportRepository interface {
+	// Import creates the project of the document for the user in a single
+	// transaction, rolled back when dryRun is set. References inside the
+	// document are expected to be checked already.
+	Import(doc *model.ProjectDocument, creatorID int, dryRun bool) (*model.ImportReport, error)
+}
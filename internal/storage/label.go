@@ -0,0 +1,12 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type LabelRepository interface {
+	CreateLabel(label *model.Label) error
+	GetLabels(projectID int) ([]model.Label, error)
+	DeleteLabel(id int) error
+	AddToTask(taskID int, labelID int) error
+	RemoveFromTask(taskID int, labelID int) error
+	GetProjectTaskLabels(projectID int) (map[int64][]model.Label, error)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type MetricsRepository interface {
+	// GetFlow returns the tasks of the project that are done and were last
+	// moved to done between from and to, both days included.
+	GetFlow(projectID int, from time.Time, to time.Time) ([]model.TaskFlow, error)
+	// SnapshotColumns records how many tasks every live column holds on
+	// the day, a later snapshot of the same day replaces the earlier one.
+	SnapshotColumns(day time.Time) (int64, error)
+	// GetSnapshots returns the snapshots of the project, or of one of its
+	// boards when boardID isn't 0, ordered by day and column.
+	GetSnapshots(projectID int, boardID int, from time.Time, to time.Time) ([]model.ColumnCount, error)
+	// GetThroughput counts the tasks completed in every week between from
+	// and to, weeks without any included.
+	GetThroughput(projectID int, filter model.ReportFilter, from time.Time, to time.Time) ([]model.ThroughputWeek, error)
+	// GetBurndown returns the number and the estimate of the tasks left
+	// open at the end of every day between from and to.
+	GetBurndown(projectID int, filter model.ReportFilter, from time.Time, to time.Time) ([]model.BurndownDay, error)
+	// GetWorkload returns the workload of every member of the project, and
+	// of nobody for the tasks without an executor, with ID_user 0. Tasks
+	// done on since or later count as completed.
+	GetWorkload(projectID int, since time.Time) ([]model.MemberWorkload, error)
+	// GetTimeReport sums the time tracked on the tasks of the project by
+	// user and by the day entries started on, from and to included,
+	// ordered by user name and day.
+	GetTimeReport(projectID int, from time.Time, to time.Time) ([]model.TimeRow, error)
+}
@@ -0,0 +1,16 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type NotificationRepository interface {
+	// Create stores the notification unless the user turned its type off.
+	Create(n *model.Notification) error
+	GetNotifications(userID int, unreadOnly bool, page Page) ([]model.Notification, string, error)
+	CountUnread(userID int) (int, error)
+	MarkRead(userID int, ids []int64) (int64, error)
+	MarkAllRead(userID int) (int64, error)
+	// GetPreferences returns the choices the user made, types missing from
+	// it notify.
+	GetPreferences(userID int) (map[string]bool, error)
+	SetPreferences(userID int, prefs []model.NotificationPreference) error
+}
@@ -0,0 +1,20 @@
+package storage
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Page selects one page of a list. Rows are ordered by Sort and then by ID,
+// so rows with equal sort values keep a stable order between pages.
+type Page struct {
+	// Cursor is the next_cursor returned with the previous page, empty for
+	// the first one. It is only valid with the same Sort and Desc.
+	Cursor string
+	// Limit is capped at MaxPageLimit, 0 means DefaultPageLimit.
+	Limit int
+	// Sort is one of the sort fields of the list, empty means its default
+	// order and ignores Desc.
+	Sort string
+	Desc bool
+}
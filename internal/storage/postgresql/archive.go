@@ -0,0 +1,193 @@
+package postgresql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type ArchiveRepository struct {
+	store *Storage
+}
+
+var archivedProjectSorts = map[string]sortField{
+	"archived": {expr: "archived_at", sqlType: "timestamptz"},
+	"id":       {expr: "id", sqlType: "bigint"},
+	"name":     {expr: "name", sqlType: "text"},
+}
+
+func (r *ArchiveRepository) GetProjects(userID int, page storage.Page) ([]model.Project, string, error) {
+
+	const op = "storage.postgresql.archive.GetProjects"
+
+	p, err := newPager(page, archivedProjectSorts, "-archived")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID}
+
+	rows, err := r.store.db.Query(`
+		SELECT id, name, slug, id_creator, description, archived_at, `+p.sortKey()+`
+		FROM projects
+		WHERE id_creator = $1 and archived_at IS NOT NULL and `+p.keyset("id", &args)+`
+		`+p.orderLimit("id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		projects []model.Project
+		keys     []string
+	)
+
+	for rows.Next() {
+		var (
+			pr  model.Project
+			key string
+		)
+		if err := rows.Scan(
+			&pr.ID,
+			&pr.Name,
+			&pr.Slug,
+			&pr.IDCreator,
+			&pr.Description,
+			&pr.ArchivedAt,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		projects = append(projects, pr)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	projects, next, err := pageOf(p, projects, keys, func(pr model.Project) int64 { return pr.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projects, next, nil
+}
+
+func (r *ArchiveRepository) GetColumns(projectID int) ([]model.Column, error) {
+
+	const op = "storage.postgresql.archive.GetColumns"
+
+	rows, err := r.store.db.Query(`
+		SELECT id, name, id_project, id_board, wip_limit, archived_at
+		FROM columns
+		WHERE id_project = $1 and archived_at IS NOT NULL
+		ORDER BY archived_at DESC`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var columns []model.Column
+
+	for rows.Next() {
+		var c model.Column
+		if err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.ID_project,
+			&c.ID_board,
+			&c.WIP_limit,
+			&c.Archived_at,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return columns, nil
+}
+
+func (r *ArchiveRepository) GetTasks(projectID int) ([]model.Task, error) {
+
+	const op = "storage.postgresql.archive.GetTasks"
+
+	rows, err := r.store.db.Query(`
+		SELECT t.id, t.id_column, t.name, t.description, t.status, t.archived_at
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		WHERE c.id_project = $1 and t.archived_at IS NOT NULL
+		ORDER BY t.archived_at DESC`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []model.Task
+
+	for rows.Next() {
+		var t model.Task
+		if err := rows.Scan(
+			&t.ID,
+			&t.ID_column,
+			&t.Name,
+			&t.Description,
+			&t.Status,
+			&t.Archived_at,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// Purge removes rows archived before the given moment for good, dependent
+// rows go away through ON DELETE CASCADE. It returns the number of removed
+// projects, columns and tasks together.
+func (r *ArchiveRepository) Purge(before time.Time) (int64, error) {
+
+	const op = "storage.postgresql.archive.Purge"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var total int64
+
+	for _, table := range []string{"tasks", "columns", "projects"} {
+		res, err := tx.Exec("DELETE FROM "+table+" WHERE archived_at < $1", before)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		total += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return total, nil
+}
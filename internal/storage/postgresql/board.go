@@ -0,0 +1,178 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// defaultBoardName is the name of the board every new project starts with.
+const defaultBoardName = "Default"
+
+type BoardRepository struct {
+	store *Storage
+}
+
+func (r *BoardRepository) CreateBoard(board *model.Board) error {
+
+	const op = "storage.postgresql.board.CreateBoard"
+
+	err := r.store.db.QueryRow(
+		"INSERT INTO boards (id_project, name) VALUES ($1, $2) RETURNING id",
+		board.ID_project,
+		board.Name,
+	).Scan(&board.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *BoardRepository) ReadBoard(board *model.Board) error {
+
+	const op = "storage.postgresql.board.ReadBoard"
+
+	err := r.store.db.QueryRow(
+		"SELECT name, id_project FROM boards WHERE id = $1",
+		board.ID,
+	).Scan(
+		&board.Name,
+		&board.ID_project,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrBoardNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *BoardRepository) GetColumns(boardID int) ([]model.Column, error) {
+
+	const op = "storage.postgresql.board.GetColumns"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, name, id_project, id_board, wip_limit FROM columns WHERE id_board = $1 and archived_at IS NULL ORDER BY id",
+		boardID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var columns []model.Column
+
+	for rows.Next() {
+		var c model.Column
+		if err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.ID_project,
+			&c.ID_board,
+			&c.WIP_limit,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return columns, nil
+}
+
+func (r *BoardRepository) GetTasks(boardID int) ([]model.Task, error) {
+
+	const op = "storage.postgresql.board.GetTasks"
+
+	rows, err := r.store.db.Query(
+		`SELECT t.id, t.id_column, t.name, t.description, t.status, t.priority, t.id_executor, t.id_swimlane, t.due_date, t.estimate,
+		`+timeSpent("t.id")+`
+		FROM tasks t
+		JOIN columns c ON t.id_column = c.id
+		WHERE c.id_board = $1
+		and t.archived_at IS NULL and c.archived_at IS NULL
+		ORDER BY t.id`,
+		boardID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []model.Task
+
+	for rows.Next() {
+		var t model.Task
+		if err := rows.Scan(
+			&t.ID,
+			&t.ID_column,
+			&t.Name,
+			&t.Description,
+			&t.Status,
+			&t.Priority,
+			&t.ID_executor,
+			&t.ID_swimlane,
+			&t.Due_date,
+			&t.Estimate,
+			&t.Time_spent,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+func (r *BoardRepository) DeleteBoard(id int) error {
+
+	const op = "storage.postgresql.board.DeleteBoard"
+
+	res, err := r.store.db.Exec("DELETE FROM boards WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrBoardNotFound)
+	}
+
+	return nil
+}
+
+func (r *BoardRepository) UpdateBoardName(board model.Board) error {
+
+	const op = "storage.postgresql.board.UpdateBoardName"
+
+	res, err := r.store.db.Exec("UPDATE boards SET name = $1 WHERE id = $2", board.Name, board.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrBoardNotFound)
+	}
+
+	return nil
+}
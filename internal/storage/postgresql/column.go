@@ -1,6 +1,8 @@
 package postgresql
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/wehw93/kanban-board/internal/model"
@@ -15,8 +17,18 @@ func (r *ColumnRepository) CreateColumn(column *model.Column) error {
 
 	const op = "storage.postgresql.column.CreateColumn"
 
-	err := r.store.db.QueryRow("INSERT INTO columns (name,id_project) VALUES($1,$2) RETURNING id", column.Name, column.ID_project).Scan(&column.ID)
+	err := r.store.db.QueryRow(`
+		INSERT INTO columns (name,id_board,id_project,wip_limit)
+		SELECT $1, id, id_project, $3 FROM boards WHERE id = $2
+		RETURNING id, id_project`,
+		column.Name,
+		column.ID_board,
+		column.WIP_limit,
+	).Scan(&column.ID, &column.ID_project)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrBoardNotFound)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -29,7 +41,7 @@ func (r *ColumnRepository) GetID(column model.Column) (int, error) {
 
 	var id int
 
-	err := r.store.db.QueryRow("SELECT id FROM columns WHERE name = $1 and id_project = $2", column.Name, column.ID_project).Scan(&id)
+	err := r.store.db.QueryRow("SELECT id FROM columns WHERE name = $1 and id_board = $2 and archived_at IS NULL", column.Name, column.ID_board).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -37,42 +49,163 @@ func (r *ColumnRepository) GetID(column model.Column) (int, error) {
 	return id, nil
 }
 
-func (r *ColumnRepository) GetTasks(column model.Column) ([]model.Task, error) {
+func (r *ColumnRepository) ReadColumn(column *model.Column) error {
+
+	const op = "storage.postgresql.column.ReadColumn"
+
+	err := r.store.db.QueryRow("SELECT id, id_project, wip_limit FROM columns WHERE name = $1 and id_board = $2 and archived_at IS NULL",
+		column.Name,
+		column.ID_board,
+	).Scan(
+		&column.ID,
+		&column.ID_project,
+		&column.WIP_limit,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *ColumnRepository) ReadColumnByID(column *model.Column) error {
+
+	const op = "storage.postgresql.column.ReadColumnByID"
+
+	err := r.store.db.QueryRow("SELECT name, id_project, id_board, wip_limit FROM columns WHERE id = $1 and archived_at IS NULL",
+		column.ID,
+	).Scan(
+		&column.Name,
+		&column.ID_project,
+		&column.ID_board,
+		&column.WIP_limit,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetProjectID returns the project of the column, archived columns included.
+func (r *ColumnRepository) GetProjectID(id int) (int64, error) {
+
+	const op = "storage.postgresql.column.GetProjectID"
+
+	var projectID int64
+
+	err := r.store.db.QueryRow("SELECT id_project FROM columns WHERE id = $1", id).Scan(&projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projectID, nil
+}
+
+func (r *ColumnRepository) GetTasks(column model.Column, page storage.Page) ([]model.Task, string, error) {
 
 	const op = "storage.postgresql.column.GetTasks"
 
-	rows, err := r.store.db.Query("SELECT id,name,description,status FROM columns WHERE id_column = $1", column.ID)
+	p, err := newPager(page, projectTaskSorts, "id")
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{column.ID}
+
+	rows, err := r.store.db.Query(
+		"SELECT t.id, t.name, t.description, t.status, "+p.sortKey()+" FROM tasks t WHERE t.id_column = $1 and t.archived_at IS NULL and "+
+			p.keyset("t.id", &args)+" "+p.orderLimit("t.id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var tasks []model.Task
+	var (
+		tasks []model.Task
+		keys  []string
+	)
 
 	for rows.Next() {
-		var t model.Task
+		var (
+			t   model.Task
+			key string
+		)
 		if err := rows.Scan(
 			&t.ID,
 			&t.Name,
 			&t.Description,
 			&t.Status,
+			&key,
 		); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, "", fmt.Errorf("%s: %w", op, err)
 		}
 		tasks = append(tasks, t)
+		keys = append(keys, key)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, next, err := pageOf(p, tasks, keys, taskID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, next, nil
+}
+
+func (r *ColumnRepository) CountTasks(id int) (int, error) {
+
+	const op = "storage.postgresql.column.CountTasks"
+
+	var count int
+
+	err := r.store.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE id_column = $1 and archived_at IS NULL", id).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return tasks, nil
+	return count, nil
 }
 
-func (r *ColumnRepository) DeleteColumn(id int) error {
+func (r *ColumnRepository) ArchiveColumn(id int) error {
 
-	const op = "storage.postgesql.column.DeleteColumn"
+	const op = "storage.postgesql.column.ArchiveColumn"
 
-	res, err := r.store.db.Exec("DELETE FROM column WHERE id = $1", id)
+	res, err := r.store.db.Exec("UPDATE columns SET archived_at = now() WHERE id = $1 and archived_at IS NULL", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+	}
+
+	return nil
+}
+
+func (r *ColumnRepository) UnarchiveColumn(id int) error {
+
+	const op = "storage.postgesql.column.UnarchiveColumn"
+
+	res, err := r.store.db.Exec("UPDATE columns SET archived_at = NULL WHERE id = $1 and archived_at IS NOT NULL", id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -109,3 +242,59 @@ func (r *ColumnRepository) UpdateColumnName(column model.Column, name string) er
 
 	return nil
 }
+
+func (r *ColumnRepository) UpdateColumnWIPLimit(column model.Column) error {
+
+	const op = "storage.postgresql.column.UpdateColumnWIPLimit"
+
+	res, err := r.store.db.Exec("UPDATE columns SET wip_limit = $1 WHERE id = $2", column.WIP_limit, column.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+	}
+
+	return nil
+}
+
+// reserveColumnSlot locks the column row until tx ends, so concurrent
+// creates and moves into the same column are serialized, and fails with
+// storage.ErrWIPLimitExceeded when the column can't take one more task.
+func reserveColumnSlot(tx *sql.Tx, columnID int64) error {
+
+	const op = "storage.postgresql.column.reserveColumnSlot"
+
+	var limit sql.NullInt64
+
+	err := tx.QueryRow("SELECT wip_limit FROM columns WHERE id = $1 and archived_at IS NULL FOR UPDATE", columnID).Scan(&limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !limit.Valid {
+		return nil
+	}
+
+	var count int64
+
+	err = tx.QueryRow("SELECT COUNT(*) FROM tasks WHERE id_column = $1 and archived_at IS NULL", columnID).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if count >= limit.Int64 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWIPLimitExceeded)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type CommentRepository struct {
+	store *Storage
+}
+
+const commentColumns = "id, id_task, id_author, body, created_at"
+
+func (r *CommentRepository) CreateComment(comment *model.Comment) error {
+
+	const op = "storage.postgresql.comment.CreateComment"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO comments (id_task, id_author, body)
+		SELECT $1, $2, $3
+		WHERE EXISTS (SELECT 1 FROM tasks WHERE id = $1 and archived_at IS NULL)
+		RETURNING id, created_at`,
+		comment.ID_task,
+		comment.ID_author,
+		comment.Body,
+	).Scan(&comment.ID, &comment.Created_at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var commentSorts = map[string]sortField{
+	"id": {expr: "id", sqlType: "bigint"},
+}
+
+func (r *CommentRepository) GetComments(taskID int, page storage.Page) ([]model.Comment, string, error) {
+
+	const op = "storage.postgresql.comment.GetComments"
+
+	p, err := newPager(page, commentSorts, "id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{taskID}
+
+	rows, err := r.store.db.Query(
+		"SELECT "+commentColumns+", "+p.sortKey()+" FROM comments WHERE id_task = $1 and "+
+			p.keyset("id", &args)+" "+p.orderLimit("id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		comments []model.Comment
+		keys     []string
+	)
+
+	for rows.Next() {
+		var (
+			c   model.Comment
+			key string
+		)
+		if err := rows.Scan(&c.ID, &c.ID_task, &c.ID_author, &c.Body, &c.Created_at, &key); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		comments = append(comments, c)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	comments, next, err := pageOf(p, comments, keys, func(c model.Comment) int64 { return c.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, next, nil
+}
+
+func selectComments(q querier, taskID int64) ([]model.Comment, error) {
+
+	const op = "storage.postgresql.comment.selectComments"
+
+	rows, err := q.Query("SELECT "+commentColumns+" FROM comments WHERE id_task = $1 ORDER BY id", taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var comments []model.Comment
+
+	for rows.Next() {
+		var c model.Comment
+		if err := rows.Scan(&c.ID, &c.ID_task, &c.ID_author, &c.Body, &c.Created_at); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, nil
+}
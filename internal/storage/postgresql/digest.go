@@ -0,0 +1,177 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type DigestRepository struct {
+	store *Storage
+}
+
+// GetSettings returns the default weekly digest for users who never
+// changed it.
+func (r *DigestRepository) GetSettings(userID int) (*model.DigestSettings, error) {
+
+	const op = "storage.postgresql.digest.GetSettings"
+
+	settings := model.DigestSettings{Frequency: model.DigestWeekly}
+
+	err := r.store.db.QueryRow(
+		"SELECT frequency, last_sent_at FROM digest_settings WHERE id_user = $1",
+		userID,
+	).Scan(&settings.Frequency, &settings.Last_sent_at)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &settings, nil
+}
+
+func (r *DigestRepository) SetFrequency(userID int, frequency string) error {
+
+	const op = "storage.postgresql.digest.SetFrequency"
+
+	_, err := r.store.db.Exec(`
+		INSERT INTO digest_settings (id_user, frequency) VALUES ($1, $2)
+		ON CONFLICT (id_user) DO UPDATE SET frequency = EXCLUDED.frequency`,
+		userID,
+		frequency,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// digestDue tells whether the digest of the settings row is due. Periods
+// are an hour short so the send time doesn't creep by the poll interval
+// every day.
+func digestDue(alias string) string {
+	return alias + `.frequency <> 'off' and (` + alias + `.last_sent_at IS NULL or ` + alias + `.last_sent_at <=
+		now() - CASE ` + alias + `.frequency WHEN 'daily' THEN interval '23 hours' ELSE interval '167 hours' END)`
+}
+
+func (r *DigestRepository) ClaimDue(limit int) ([]model.DigestRecipient, error) {
+
+	const op = "storage.postgresql.digest.ClaimDue"
+
+	// Users without settings get the default weekly digest. The conflict
+	// check runs again on the locked row, so an instance racing for the
+	// same user gets nothing back.
+	rows, err := r.store.db.Query(`
+		WITH claimed AS (
+			INSERT INTO digest_settings AS ds (id_user, last_sent_at)
+			SELECT u.id, now() FROM users u
+			LEFT JOIN digest_settings d ON d.id_user = u.id
+			WHERE d.id_user IS NULL or (`+digestDue("d")+`)
+			ORDER BY u.id
+			LIMIT $1
+			ON CONFLICT (id_user) DO UPDATE SET last_sent_at = now()
+			WHERE `+digestDue("ds")+`
+			RETURNING id_user, frequency
+		)
+		SELECT c.id_user, u.name, u.email, c.frequency
+		FROM claimed c JOIN users u ON u.id = c.id_user`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var recipients []model.DigestRecipient
+
+	for rows.Next() {
+		var d model.DigestRecipient
+		if err := rows.Scan(&d.ID_user, &d.Name, &d.Email, &d.Frequency); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		recipients = append(recipients, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recipients, nil
+}
+
+// digestTasks selects the tasks on live boards with the name of their
+// project, the conditions are appended by the caller.
+const digestTasks = `
+	SELECT t.id, t.name, p.name, t.status, t.priority, t.due_date
+	FROM tasks t
+	JOIN columns c ON c.id = t.id_column
+	JOIN projects p ON p.id = c.id_project
+	WHERE t.archived_at IS NULL and c.archived_at IS NULL and p.archived_at IS NULL`
+
+func (r *DigestRepository) GetDigest(userID int64, since time.Time, limit int) (*model.Digest, error) {
+
+	const op = "storage.postgresql.digest.GetDigest"
+
+	open := digestTasks + " and t.id_executor = $1 and t.status <> '" + done + "'"
+
+	assigned, err := selectDigestTasks(r.store.db,
+		open+" and (t.due_date IS NULL or t.due_date >= current_date) ORDER BY t.due_date NULLS LAST, t.id LIMIT $2",
+		userID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	overdue, err := selectDigestTasks(r.store.db, open+" and t.due_date < current_date ORDER BY t.due_date, t.id LIMIT $2", userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changed, err := selectDigestTasks(r.store.db, digestTasks+`
+		and c.id_project IN (`+accessibleProjects+`)
+		and EXISTS (SELECT 1 FROM logs l WHERE l.id_task = t.id and l.date_of_operation >= $2::date)
+		ORDER BY t.id DESC LIMIT $3`,
+		userID,
+		since,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &model.Digest{
+		Since:    since,
+		Assigned: assigned,
+		Overdue:  overdue,
+		Changed:  changed,
+	}, nil
+}
+
+func selectDigestTasks(q querier, query string, args ...any) ([]model.DigestTask, error) {
+
+	const op = "storage.postgresql.digest.selectDigestTasks"
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []model.DigestTask
+
+	for rows.Next() {
+		var t model.DigestTask
+		if err := rows.Scan(&t.ID, &t.Name, &t.Project, &t.Status, &t.Priority, &t.Due_date); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,175 @@
+package postgresql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type EventRepository struct {
+	store *Storage
+}
+
+// Create stores the event and fills in its ID and creation time. An event
+// without ID_user is stored as made by nobody in particular. The event is
+// queued for the webhooks of its project in the same transaction, so no
+// stored event is ever left undelivered.
+func (r *EventRepository) Create(event *model.Event) error {
+
+	const op = "storage.postgresql.event.Create"
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	userID := sql.NullInt64{Int64: event.ID_user, Valid: event.ID_user != 0}
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
+		"INSERT INTO events (id_project, type, id_user, data) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		event.ID_project,
+		event.Type,
+		userID,
+		data,
+	).Scan(&event.ID, &event.Created_at)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := enqueueEvent(tx, *event, payload); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *EventRepository) GetByID(id int64) (*model.Event, error) {
+
+	const op = "storage.postgresql.event.GetByID"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, id_project, type, id_user, data, created_at FROM events WHERE id = $1",
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrEventNotFound)
+	}
+
+	return &events[0], nil
+}
+
+// GetAfter returns up to limit events of the project newer than after,
+// oldest first.
+func (r *EventRepository) GetAfter(projectID int, after int64, limit int) ([]model.Event, error) {
+
+	const op = "storage.postgresql.event.GetAfter"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, id_project, type, id_user, data, created_at FROM events WHERE id_project = $1 and id > $2 ORDER BY id LIMIT $3",
+		projectID,
+		after,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) IDRange() (int64, int64, error) {
+
+	const op = "storage.postgresql.event.IDRange"
+
+	var first, last int64
+
+	err := r.store.db.QueryRow("SELECT COALESCE(MIN(id), 0), COALESCE(MAX(id), 0) FROM events").Scan(&first, &last)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return first, last, nil
+}
+
+func (r *EventRepository) Purge(before time.Time) (int64, error) {
+
+	const op = "storage.postgresql.event.Purge"
+
+	res, err := r.store.db.Exec("DELETE FROM events WHERE created_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rowsAffected, nil
+}
+
+func scanEvents(rows *sql.Rows) ([]model.Event, error) {
+
+	defer rows.Close()
+
+	var events []model.Event
+
+	for rows.Next() {
+		var (
+			e      model.Event
+			userID sql.NullInt64
+			data   []byte
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.ID_project,
+			&e.Type,
+			&userID,
+			&data,
+			&e.Created_at,
+		); err != nil {
+			return nil, err
+		}
+		e.ID_user = userID.Int64
+		e.Data = json.RawMessage(data)
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,300 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type ExportRepository struct {
+	store *Storage
+}
+
+// exportReader reads through q, the database or a snapshot transaction.
+type exportReader struct {
+	q querier
+}
+
+func (r *ExportRepository) GetProject(projectID int) (*model.ProjectExport, error) {
+	return exportReader{r.store.db}.GetProject(projectID)
+}
+
+func (r *ExportRepository) EachTask(projectID int, fn func(model.ExportedTask) error) error {
+	return exportReader{r.store.db}.EachTask(projectID, fn)
+}
+
+func (r *ExportRepository) EachLog(projectID int, fn func(model.ExportedLog) error) error {
+	return exportReader{r.store.db}.EachLog(projectID, fn)
+}
+
+func (r *ExportRepository) EachComment(projectID int, fn func(model.ExportedComment) error) error {
+	return exportReader{r.store.db}.EachComment(projectID, fn)
+}
+
+// Snapshot reads in a repeatable read transaction, all its queries see the
+// data committed before the first of them.
+func (r *ExportRepository) Snapshot(fn func(storage.ExportReader) error) error {
+
+	const op = "storage.postgresql.export.Snapshot"
+
+	tx, err := r.store.db.BeginTx(context.Background(), &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(exportReader{tx}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// exportTime writes a timestamp the way the export document keeps them.
+func exportTime(column string) string {
+	return "to_char(" + column + " AT TIME ZONE 'UTC', 'YYYY-MM-DD\"T\"HH24:MI:SS\"Z\"')"
+}
+
+func (r exportReader) GetProject(projectID int) (*model.ProjectExport, error) {
+
+	const op = "storage.postgresql.export.GetProject"
+
+	exp := &model.ProjectExport{
+		Version:     model.ExportVersion,
+		Exported_at: time.Now().UTC().Format(time.RFC3339),
+		Boards:      []model.ExportedBoard{},
+		Columns:     []model.ExportedColumn{},
+		Swimlanes:   []model.ExportedSwimlane{},
+		Labels:      []model.ExportedLabel{},
+	}
+
+	err := r.q.QueryRow(`
+		SELECT p.id, p.name, p.slug, p.description, u.email, `+exportTime("p.archived_at")+`
+		FROM projects p
+		JOIN users u ON u.id = p.id_creator
+		WHERE p.id = $1`,
+		projectID,
+	).Scan(&exp.Project.ID,
+		&exp.Project.Name,
+		&exp.Project.Slug,
+		&exp.Project.Description,
+		&exp.Project.Creator,
+		&exp.Project.Archived_at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrProjectNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.q.Query("SELECT id, name FROM boards WHERE id_project = $1 ORDER BY id", projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b model.ExportedBoard
+		if err := rows.Scan(&b.ID, &b.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		exp.Boards = append(exp.Boards, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = r.q.Query(
+		"SELECT id, id_board, name, wip_limit, "+exportTime("archived_at")+" FROM columns WHERE id_project = $1 ORDER BY id",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c model.ExportedColumn
+		if err := rows.Scan(&c.ID, &c.ID_board, &c.Name, &c.WIP_limit, &c.Archived_at); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		exp.Columns = append(exp.Columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = r.q.Query("SELECT id, name, position FROM swimlanes WHERE id_project = $1 ORDER BY position, id", projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s model.ExportedSwimlane
+		if err := rows.Scan(&s.ID, &s.Name, &s.Position); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		exp.Swimlanes = append(exp.Swimlanes, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = r.q.Query("SELECT id, name FROM labels WHERE id_project = $1 ORDER BY id", projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l model.ExportedLabel
+		if err := rows.Scan(&l.ID, &l.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		exp.Labels = append(exp.Labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exp, nil
+}
+
+func (r exportReader) EachTask(projectID int, fn func(model.ExportedTask) error) error {
+
+	const op = "storage.postgresql.export.EachTask"
+
+	rows, err := r.q.Query(`
+		SELECT t.id, t.id_column, t.id_swimlane, t.name, t.description, t.status, t.priority,
+		ARRAY(SELECT tl.id_label FROM task_labels tl WHERE tl.id_task = t.id ORDER BY tl.id_label),
+		cr.email, ex.email,
+		to_char(t.date_of_create, 'YYYY-MM-DD'), to_char(t.date_of_execution, 'YYYY-MM-DD'), to_char(t.due_date, 'YYYY-MM-DD'),
+		t.estimate, `+exportTime("t.archived_at")+`
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN users cr ON cr.id = t.id_creator
+		LEFT JOIN users ex ON ex.id = t.id_executor
+		WHERE c.id_project = $1
+		ORDER BY t.id`,
+		projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := model.ExportedTask{Labels: []int64{}}
+		if err := rows.Scan(
+			&t.ID,
+			&t.ID_column,
+			&t.ID_swimlane,
+			&t.Name,
+			&t.Description,
+			&t.Status,
+			&t.Priority,
+			pq.Array(&t.Labels),
+			&t.Creator,
+			&t.Executor,
+			&t.Date_of_create,
+			&t.Date_of_execution,
+			&t.Due_date,
+			&t.Estimate,
+			&t.Archived_at,
+		); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r exportReader) EachLog(projectID int, fn func(model.ExportedLog) error) error {
+
+	const op = "storage.postgresql.export.EachLog"
+
+	rows, err := r.q.Query(`
+		SELECT l.id_task, to_char(l.date_of_operation, 'YYYY-MM-DD'), l.info, l.status
+		FROM logs l
+		JOIN tasks t ON t.id = l.id_task
+		JOIN columns c ON c.id = t.id_column
+		WHERE c.id_project = $1
+		ORDER BY l.id_task, l.id`,
+		projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l model.ExportedLog
+		if err := rows.Scan(&l.ID_task, &l.Date_of_operation, &l.Info, &l.Status); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := fn(l); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r exportReader) EachComment(projectID int, fn func(model.ExportedComment) error) error {
+
+	const op = "storage.postgresql.export.EachComment"
+
+	rows, err := r.q.Query(`
+		SELECT cm.id_task, u.email, cm.body, `+exportTime("cm.created_at")+`
+		FROM comments cm
+		JOIN tasks t ON t.id = cm.id_task
+		JOIN columns c ON c.id = t.id_column
+		LEFT JOIN users u ON u.id = cm.id_author
+		WHERE c.id_project = $1
+		ORDER BY cm.id_task, cm.id`,
+		projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cm model.ExportedComment
+		if err := rows.Scan(&cm.ID_task, &cm.Author, &cm.Body, &cm.Created_at); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := fn(cm); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
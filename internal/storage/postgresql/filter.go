@@ -0,0 +1,156 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type FilterRepository struct {
+	store *Storage
+}
+
+// CreateFilter saves the filter, its project has to be one the user works in.
+func (r *FilterRepository) CreateFilter(filter *model.Filter) error {
+
+	const op = "storage.postgresql.filter.CreateFilter"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO filters (id_user, id_project, name, query, shared)
+		SELECT $1, $2, $3, $4, $5
+		WHERE $2::bigint IS NULL or $2 IN (`+accessibleProjects+`)
+		RETURNING id`,
+		filter.ID_user,
+		filter.ID_project,
+		filter.Name,
+		filter.Query,
+		filter.Shared,
+	).Scan(&filter.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrProjectNotFound)
+		}
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrFilterExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// visibleFilters selects the filters of the user $1 and the ones shared in
+// the projects they work in.
+const visibleFilters = `
+	SELECT id, id_user, id_project, name, query, shared
+	FROM filters
+	WHERE (id_user = $1 or (shared and id_project IN (` + accessibleProjects + `)))`
+
+var filterSorts = map[string]sortField{
+	"name": {expr: "name", sqlType: "text"},
+	"id":   {expr: "id", sqlType: "bigint"},
+}
+
+func (r *FilterRepository) GetFilters(userID int, page storage.Page) ([]model.Filter, string, error) {
+
+	const op = "storage.postgresql.filter.GetFilters"
+
+	p, err := newPager(page, filterSorts, "name")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID}
+
+	rows, err := r.store.db.Query(
+		"SELECT f.*, "+p.sortKey()+" FROM ("+visibleFilters+") f WHERE "+p.keyset("id", &args)+" "+p.orderLimit("id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		filters []model.Filter
+		keys    []string
+	)
+
+	for rows.Next() {
+		var (
+			f   model.Filter
+			key string
+		)
+		if err := rows.Scan(
+			&f.ID,
+			&f.ID_user,
+			&f.ID_project,
+			&f.Name,
+			&f.Query,
+			&f.Shared,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		filters = append(filters, f)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	filters, next, err := pageOf(p, filters, keys, func(f model.Filter) int64 { return f.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filters, next, nil
+}
+
+func (r *FilterRepository) GetFilter(userID int, id int) (*model.Filter, error) {
+
+	const op = "storage.postgresql.filter.GetFilter"
+
+	var f model.Filter
+
+	err := r.store.db.QueryRow(visibleFilters+" and id = $2", userID, id).Scan(
+		&f.ID,
+		&f.ID_user,
+		&f.ID_project,
+		&f.Name,
+		&f.Query,
+		&f.Shared,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrFilterNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &f, nil
+}
+
+func (r *FilterRepository) DeleteFilter(userID int, id int) error {
+
+	const op = "storage.postgresql.filter.DeleteFilter"
+
+	res, err := r.store.db.Exec("DELETE FROM filters WHERE id = $1 and id_user = $2", id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrFilterNotFound)
+	}
+
+	return nil
+}
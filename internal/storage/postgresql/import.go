@@ -0,0 +1,293 @@
+package postgresql
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/lib/slug"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type ImportRepository struct {
+	store *Storage
+}
+
+func (r *ImportRepository) Import(doc *model.ProjectDocument, creatorID int, dryRun bool) (*model.ImportReport, error) {
+
+	const op = "storage.postgresql.import.Import"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	users, unknown, err := importUsers(tx, doc.Tasks, doc.Comments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report := &model.ImportReport{
+		Dry_run:       dryRun,
+		Name:          doc.Project.Name,
+		Unknown_users: unknown,
+	}
+
+	report.Slug, err = withFreeSlug(tx, slug.Make(doc.Project.Name), 0, func(slug string) error {
+		return tx.QueryRow(
+			"INSERT INTO projects (name, id_creator, description, slug) VALUES ($1, $2, $3, $4) RETURNING id",
+			doc.Project.Name,
+			creatorID,
+			doc.Project.Description,
+			slug,
+		).Scan(&report.ID_project)
+	})
+	if err != nil {
+		if uniqueConstraint(err) == projectNameKey {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrProjectExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Every part gets a new id, the maps lead from the ids of the document
+	// to them.
+	boards := make(map[int64]int64, len(doc.Boards))
+	for _, b := range doc.Boards {
+		var id int64
+		err := tx.QueryRow(
+			"INSERT INTO boards (id_project, name) VALUES ($1, $2) RETURNING id",
+			report.ID_project,
+			b.Name,
+		).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		boards[b.ID] = id
+	}
+
+	columns := make(map[int64]int64, len(doc.Columns))
+	for _, c := range doc.Columns {
+		var id int64
+		err := tx.QueryRow(
+			"INSERT INTO columns (name, id_project, id_board, wip_limit, archived_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+			c.Name,
+			report.ID_project,
+			boards[c.ID_board],
+			c.WIP_limit,
+			c.Archived_at,
+		).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		columns[c.ID] = id
+	}
+
+	swimlanes := make(map[int64]int64, len(doc.Swimlanes))
+	for _, s := range doc.Swimlanes {
+		var id int64
+		err := tx.QueryRow(
+			"INSERT INTO swimlanes (id_project, name, position) VALUES ($1, $2, $3) RETURNING id",
+			report.ID_project,
+			s.Name,
+			s.Position,
+		).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		swimlanes[s.ID] = id
+	}
+
+	labels := make(map[int64]int64, len(doc.Labels))
+	for _, l := range doc.Labels {
+		var id int64
+		err := tx.QueryRow(
+			"INSERT INTO labels (id_project, name) VALUES ($1, $2) RETURNING id",
+			report.ID_project,
+			l.Name,
+		).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		labels[l.ID] = id
+	}
+
+	tasks := make(map[int64]int64, len(doc.Tasks))
+	for _, t := range doc.Tasks {
+
+		creator := int64(creatorID)
+		if id, ok := users[importEmail(t.Creator)]; ok {
+			creator = id
+		}
+
+		var executor, swimlane *int64
+		if t.Executor != nil {
+			if id, ok := users[importEmail(*t.Executor)]; ok {
+				executor = &id
+			}
+		}
+		if t.ID_swimlane != nil {
+			id := swimlanes[*t.ID_swimlane]
+			swimlane = &id
+		}
+
+		var id int64
+		err := tx.QueryRow(`
+			INSERT INTO tasks (id_column, name, description, date_of_create, date_of_execution, id_executor, id_creator,
+			status, priority, id_swimlane, due_date, estimate, archived_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`,
+			columns[t.ID_column],
+			t.Name,
+			t.Description,
+			t.Date_of_create,
+			t.Date_of_execution,
+			executor,
+			creator,
+			t.Status,
+			t.Priority,
+			swimlane,
+			t.Due_date,
+			t.Estimate,
+			t.Archived_at,
+		).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks[t.ID] = id
+
+		for _, l := range t.Labels {
+			_, err := tx.Exec("INSERT INTO task_labels (id_task, id_label) VALUES ($1, $2) ON CONFLICT DO NOTHING", id, labels[l])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	// Documents exported before logs kept their status only have the text,
+	// the status is read from it the way the migration did.
+	for _, l := range doc.Logs {
+		_, err := tx.Exec(`
+			INSERT INTO logs (id_task, date_of_operation, info, status)
+			VALUES ($1, $2, $3, coalesce($4, substring($3 from '^switch status from .*to (todo|in_progress|done)$')))`,
+			tasks[l.ID_task],
+			l.Date_of_operation,
+			l.Info,
+			l.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for _, c := range doc.Comments {
+		var author *int64
+		if c.Author != nil {
+			if id, ok := users[importEmail(*c.Author)]; ok {
+				author = &id
+			}
+		}
+
+		_, err := tx.Exec(
+			"INSERT INTO comments (id_task, id_author, body, created_at) VALUES ($1, $2, $3, $4)",
+			tasks[c.ID_task],
+			author,
+			c.Body,
+			c.Created_at,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	report.Boards = len(boards)
+	report.Columns = len(columns)
+	report.Swimlanes = len(swimlanes)
+	report.Labels = len(labels)
+	report.Tasks = len(tasks)
+	report.Logs = len(doc.Logs)
+	report.Comments = len(doc.Comments)
+
+	if dryRun {
+		report.ID_project = 0
+		return report, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.Info("project imported", slog.Int64("id", report.ID_project), slog.Int("tasks", report.Tasks))
+
+	return report, nil
+}
+
+// importEmail is the form emails of a document are matched in.
+func importEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// importUsers finds the accounts of the users the tasks and comments name,
+// and the names that have none.
+func importUsers(q querier, tasks []model.ExportedTask, comments []model.ExportedComment) (map[string]int64, []string, error) {
+
+	const op = "storage.postgresql.import.importUsers"
+
+	named := make(map[string]bool)
+	for _, t := range tasks {
+		if e := importEmail(t.Creator); e != "" {
+			named[e] = true
+		}
+		if t.Executor != nil {
+			if e := importEmail(*t.Executor); e != "" {
+				named[e] = true
+			}
+		}
+	}
+	for _, c := range comments {
+		if c.Author != nil {
+			if e := importEmail(*c.Author); e != "" {
+				named[e] = true
+			}
+		}
+	}
+
+	emails := make([]string, 0, len(named))
+	for e := range named {
+		emails = append(emails, e)
+	}
+
+	rows, err := q.Query("SELECT id, lower(email) FROM users WHERE lower(email) = ANY($1)", pq.Array(emails))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := make(map[string]int64, len(emails))
+
+	for rows.Next() {
+		var (
+			id    int64
+			email string
+		)
+		if err := rows.Scan(&id, &email); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users[email] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	unknown := []string{}
+	for _, e := range emails {
+		if _, ok := users[e]; !ok {
+			unknown = append(unknown, e)
+		}
+	}
+	sort.Strings(unknown)
+
+	return users, unknown, nil
+}
@@ -0,0 +1,196 @@
+package postgresql
+
+import (
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type LabelRepository struct {
+	store *Storage
+}
+
+func (r *LabelRepository) CreateLabel(label *model.Label) error {
+
+	const op = "storage.postgresql.label.CreateLabel"
+
+	err := r.store.db.QueryRow(
+		"INSERT INTO labels (id_project, name) VALUES ($1, $2) RETURNING id",
+		label.ID_project,
+		label.Name,
+	).Scan(&label.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrLabelExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) GetLabels(projectID int) ([]model.Label, error) {
+
+	const op = "storage.postgresql.label.GetLabels"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, name, id_project FROM labels WHERE id_project = $1 ORDER BY name",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var labels []model.Label
+
+	for rows.Next() {
+		var l model.Label
+		if err := rows.Scan(
+			&l.ID,
+			&l.Name,
+			&l.ID_project,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return labels, nil
+}
+
+func (r *LabelRepository) DeleteLabel(id int) error {
+
+	const op = "storage.postgresql.label.DeleteLabel"
+
+	res, err := r.store.db.Exec("DELETE FROM labels WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrLabelNotFound)
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) AddToTask(taskID int, labelID int) error {
+
+	const op = "storage.postgresql.label.AddToTask"
+
+	// the label is only attached when it belongs to the project of the task
+	res, err := r.store.db.Exec(`
+		INSERT INTO task_labels (id_task, id_label)
+		SELECT t.id, l.id
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN labels l ON l.id_project = c.id_project
+		WHERE t.id = $1 and l.id = $2
+		ON CONFLICT DO NOTHING`,
+		taskID,
+		labelID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+
+		err = r.store.db.QueryRow(
+			"SELECT EXISTS(SELECT 1 FROM task_labels WHERE id_task = $1 and id_label = $2)",
+			taskID,
+			labelID,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%s: %w", op, storage.ErrLabelNotFound)
+		}
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) RemoveFromTask(taskID int, labelID int) error {
+
+	const op = "storage.postgresql.label.RemoveFromTask"
+
+	res, err := r.store.db.Exec(
+		"DELETE FROM task_labels WHERE id_task = $1 and id_label = $2",
+		taskID,
+		labelID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrLabelNotFound)
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) GetProjectTaskLabels(projectID int) (map[int64][]model.Label, error) {
+
+	const op = "storage.postgresql.label.GetProjectTaskLabels"
+
+	rows, err := r.store.db.Query(`
+		SELECT tl.id_task, l.id, l.name, l.id_project
+		FROM task_labels tl
+		JOIN labels l ON l.id = tl.id_label
+		WHERE l.id_project = $1
+		ORDER BY l.name`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	labels := make(map[int64][]model.Label)
+
+	for rows.Next() {
+		var (
+			taskID int64
+			l      model.Label
+		)
+		if err := rows.Scan(
+			&taskID,
+			&l.ID,
+			&l.Name,
+			&l.ID_project,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		labels[taskID] = append(labels[taskID], l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,346 @@
+package postgresql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type MetricsRepository struct {
+	store *Storage
+}
+
+func (r *MetricsRepository) GetFlow(projectID int, from time.Time, to time.Time) ([]model.TaskFlow, error) {
+
+	const op = "storage.postgresql.metrics.GetFlow"
+
+	// A reopened task counts from its last move to done, cycle time from
+	// the first move to in progress before it. Archived tasks were done all
+	// the same and stay in the history.
+	rows, err := r.store.db.Query(`
+		SELECT t.id, t.name, to_char(t.date_of_create, 'YYYY-MM-DD'), to_char(s.started, 'YYYY-MM-DD'), to_char(d.done, 'YYYY-MM-DD'),
+		d.done - t.date_of_create, d.done - s.started
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN LATERAL (
+			SELECT max(date_of_operation) AS done FROM logs WHERE id_task = t.id and status = $4
+		) d ON true
+		LEFT JOIN LATERAL (
+			SELECT min(date_of_operation) AS started FROM logs
+			WHERE id_task = t.id and status = $5 and date_of_operation <= d.done
+		) s ON true
+		WHERE c.id_project = $1 and t.status = $6 and d.done BETWEEN $2::date and $3::date
+		ORDER BY d.done, t.id`,
+		projectID,
+		from,
+		to,
+		done,
+		inProgress,
+		done,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []model.TaskFlow
+
+	for rows.Next() {
+		var t model.TaskFlow
+		if err := rows.Scan(
+			&t.ID_task,
+			&t.Name,
+			&t.Created_on,
+			&t.Started_on,
+			&t.Done_on,
+			&t.Lead_days,
+			&t.Cycle_days,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+func (r *MetricsRepository) SnapshotColumns(day time.Time) (int64, error) {
+
+	const op = "storage.postgresql.metrics.SnapshotColumns"
+
+	res, err := r.store.db.Exec(`
+		INSERT INTO column_snapshots (day, id_column, id_board, id_project, name, tasks)
+		SELECT $1::date, c.id, c.id_board, c.id_project, c.name, count(t.id)
+		FROM columns c
+		JOIN projects p ON p.id = c.id_project
+		LEFT JOIN tasks t ON t.id_column = c.id and t.archived_at IS NULL
+		WHERE c.archived_at IS NULL and p.archived_at IS NULL
+		GROUP BY c.id
+		ON CONFLICT (day, id_column) DO UPDATE
+		SET id_board = EXCLUDED.id_board, name = EXCLUDED.name, tasks = EXCLUDED.tasks`,
+		day,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *MetricsRepository) GetSnapshots(projectID int, boardID int, from time.Time, to time.Time) ([]model.ColumnCount, error) {
+
+	const op = "storage.postgresql.metrics.GetSnapshots"
+
+	rows, err := r.store.db.Query(`
+		SELECT to_char(day, 'YYYY-MM-DD'), id_column, id_board, name, tasks
+		FROM column_snapshots
+		WHERE id_project = $1 and ($2 = 0 or id_board = $2) and day BETWEEN $3::date and $4::date
+		ORDER BY day, id_column`,
+		projectID,
+		boardID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var snapshots []model.ColumnCount
+
+	for rows.Next() {
+		var s model.ColumnCount
+		if err := rows.Scan(&s.Day, &s.ID_column, &s.ID_board, &s.Name, &s.Tasks); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return snapshots, nil
+}
+
+// reportTasks selects the tasks of the project $1 matching the executor $4
+// and the label $5 of a report filter.
+const reportTasks = `
+	SELECT t.id, t.status, t.date_of_create, t.date_of_execution, t.estimate, t.archived_at
+	FROM tasks t
+	JOIN columns c ON c.id = t.id_column
+	WHERE c.id_project = $1
+	and ($4 = 0 or t.id_executor = $4)
+	and ($5 = 0 or EXISTS (SELECT 1 FROM task_labels tl WHERE tl.id_task = t.id and tl.id_label = $5))`
+
+func (r *MetricsRepository) GetThroughput(projectID int, filter model.ReportFilter, from time.Time, to time.Time) ([]model.ThroughputWeek, error) {
+
+	const op = "storage.postgresql.metrics.GetThroughput"
+
+	rows, err := r.store.db.Query(`
+		WITH t AS (`+reportTasks+`)
+		SELECT to_char(w, 'YYYY-MM-DD'), count(t.id), coalesce(sum(t.estimate), 0)
+		FROM generate_series(date_trunc('week', $2::date), $3::date, interval '1 week') w
+		LEFT JOIN t ON t.status = $6
+		and t.date_of_execution >= greatest(w, $2::date)
+		and t.date_of_execution < w + interval '1 week'
+		and t.date_of_execution <= $3::date
+		GROUP BY w
+		ORDER BY w`,
+		projectID,
+		from,
+		to,
+		filter.ID_executor,
+		filter.ID_label,
+		done,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var weeks []model.ThroughputWeek
+
+	for rows.Next() {
+		var w model.ThroughputWeek
+		if err := rows.Scan(&w.Week, &w.Completed, &w.Estimate); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		weeks = append(weeks, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return weeks, nil
+}
+
+func (r *MetricsRepository) GetBurndown(projectID int, filter model.ReportFilter, from time.Time, to time.Time) ([]model.BurndownDay, error) {
+
+	const op = "storage.postgresql.metrics.GetBurndown"
+
+	// A task is open from the day it was created until the day it was
+	// done. Archived tasks that were never done left the scope.
+	rows, err := r.store.db.Query(`
+		WITH t AS (`+reportTasks+`)
+		SELECT to_char(d, 'YYYY-MM-DD'), count(t.id), coalesce(sum(t.estimate), 0)
+		FROM generate_series($2::date, $3::date, interval '1 day') d
+		LEFT JOIN t ON (t.archived_at IS NULL or t.status = $6)
+		and t.date_of_create <= d
+		and (t.status <> $6 or t.date_of_execution IS NULL or t.date_of_execution > d)
+		GROUP BY d
+		ORDER BY d`,
+		projectID,
+		from,
+		to,
+		filter.ID_executor,
+		filter.ID_label,
+		done,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var days []model.BurndownDay
+
+	for rows.Next() {
+		var (
+			d                   model.BurndownDay
+			remaining, estimate int
+		)
+		if err := rows.Scan(&d.Day, &remaining, &estimate); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		d.Remaining = &remaining
+		d.Remaining_estimate = &estimate
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return days, nil
+}
+
+func (r *MetricsRepository) GetWorkload(projectID int, since time.Time) ([]model.MemberWorkload, error) {
+
+	const op = "storage.postgresql.metrics.GetWorkload"
+
+	// Members are the ones accessibleProjects lets in. The NULL member
+	// gathers the tasks nobody executes. Tasks of archived columns are no
+	// longer open, but done ones still count as completed.
+	rows, err := r.store.db.Query(`
+		WITH t AS (
+			SELECT t.id_creator, t.id_executor, t.status, t.due_date, t.estimate, t.date_of_execution,
+			t.archived_at IS NULL and c.archived_at IS NULL AS live
+			FROM tasks t
+			JOIN columns c ON c.id = t.id_column
+			WHERE c.id_project = $1
+		), m AS (
+			SELECT id_creator AS id FROM projects WHERE id = $1
+			UNION
+			SELECT id_creator FROM t
+			UNION
+			SELECT id_executor FROM t WHERE id_executor IS NOT NULL
+			UNION ALL
+			SELECT NULL
+		)
+		SELECT coalesce(m.id, 0), coalesce(u.name, ''), coalesce(u.email, ''),
+		count(*) FILTER (WHERE t.live and t.status = $2),
+		count(*) FILTER (WHERE t.live and t.status = $3),
+		count(*) FILTER (WHERE t.live and t.status <> $4 and t.due_date < current_date),
+		coalesce(sum(t.estimate) FILTER (WHERE t.live and t.status <> $4), 0),
+		count(*) FILTER (WHERE t.status = $4 and t.date_of_execution >= $5::date)
+		FROM m
+		LEFT JOIN users u ON u.id = m.id
+		LEFT JOIN t ON t.id_executor IS NOT DISTINCT FROM m.id
+		GROUP BY m.id, u.name, u.email
+		ORDER BY count(*) FILTER (WHERE t.live and t.status <> $4) DESC, u.name, m.id`,
+		projectID,
+		todo,
+		inProgress,
+		done,
+		since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var members []model.MemberWorkload
+
+	for rows.Next() {
+		var (
+			w                  model.MemberWorkload
+			todoN, inProgressN int
+		)
+		if err := rows.Scan(&w.ID_user, &w.Name, &w.Email, &todoN, &inProgressN, &w.Overdue, &w.Estimate, &w.Completed); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		w.Open = todoN + inProgressN
+		w.Open_by_status = map[string]int{
+			todo:       todoN,
+			inProgress: inProgressN,
+		}
+		members = append(members, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+func (r *MetricsRepository) GetTimeReport(projectID int, from time.Time, to time.Time) ([]model.TimeRow, error) {
+
+	const op = "storage.postgresql.metrics.GetTimeReport"
+
+	// Archived tasks keep their time, it was spent all the same.
+	rows, err := r.store.db.Query(`
+		WITH e AS (
+			SELECT e.id_user, (e.started_at AT TIME ZONE 'UTC')::date AS day,
+			extract(epoch FROM coalesce(e.ended_at, now()) - e.started_at) AS seconds
+			FROM time_entries e
+			JOIN tasks t ON t.id = e.id_task
+			JOIN columns c ON c.id = t.id_column
+			WHERE c.id_project = $1
+		)
+		SELECT e.id_user, u.name, u.email, to_char(e.day, 'YYYY-MM-DD'), sum(e.seconds)::bigint
+		FROM e
+		JOIN users u ON u.id = e.id_user
+		WHERE e.day BETWEEN $2::date and $3::date
+		GROUP BY e.id_user, u.name, u.email, e.day
+		ORDER BY u.name, e.id_user, e.day`,
+		projectID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var report []model.TimeRow
+
+	for rows.Next() {
+		var t model.TimeRow
+		if err := rows.Scan(&t.ID_user, &t.Name, &t.Email, &t.Day, &t.Seconds); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		report = append(report, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return report, nil
+}
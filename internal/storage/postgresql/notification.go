@@ -0,0 +1,236 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type NotificationRepository struct {
+	store *Storage
+}
+
+// Create leaves n.ID zero when the user turned the type off.
+func (r *NotificationRepository) Create(n *model.Notification) error {
+
+	const op = "storage.postgresql.notification.Create"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO notifications (id_user, type, id_project, id_task, id_actor, message)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE NOT EXISTS (
+			SELECT 1 FROM notification_preferences WHERE id_user = $1 and type = $2 and NOT enabled
+		)
+		RETURNING id, created_at`,
+		n.ID_user,
+		n.Type,
+		n.ID_project,
+		n.ID_task,
+		n.ID_actor,
+		n.Message,
+	).Scan(&n.ID, &n.Created_at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var notificationSorts = map[string]sortField{
+	"id": {expr: "id", sqlType: "bigint"},
+}
+
+func notificationID(n model.Notification) int64 {
+	return n.ID
+}
+
+func (r *NotificationRepository) GetNotifications(userID int, unreadOnly bool, page storage.Page) ([]model.Notification, string, error) {
+
+	const op = "storage.postgresql.notification.GetNotifications"
+
+	p, err := newPager(page, notificationSorts, "-id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID, unreadOnly}
+
+	rows, err := r.store.db.Query(`
+		SELECT id, id_user, type, id_project, id_task, id_actor, message, created_at, read_at, `+p.sortKey()+`
+		FROM notifications
+		WHERE id_user = $1 and (NOT $2 or read_at IS NULL) and `+p.keyset("id", &args)+" "+p.orderLimit("id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		notifications []model.Notification
+		keys          []string
+	)
+
+	for rows.Next() {
+		var (
+			n   model.Notification
+			key string
+		)
+		if err := rows.Scan(
+			&n.ID,
+			&n.ID_user,
+			&n.Type,
+			&n.ID_project,
+			&n.ID_task,
+			&n.ID_actor,
+			&n.Message,
+			&n.Created_at,
+			&n.Read_at,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		notifications = append(notifications, n)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	notifications, next, err := pageOf(p, notifications, keys, notificationID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, next, nil
+}
+
+func (r *NotificationRepository) CountUnread(userID int) (int, error) {
+
+	const op = "storage.postgresql.notification.CountUnread"
+
+	var count int
+
+	err := r.store.db.QueryRow(
+		"SELECT count(*) FROM notifications WHERE id_user = $1 and read_at IS NULL",
+		userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// MarkRead skips the IDs that are not unread notifications of the user and
+// returns how many were marked.
+func (r *NotificationRepository) MarkRead(userID int, ids []int64) (int64, error) {
+
+	const op = "storage.postgresql.notification.MarkRead"
+
+	res, err := r.store.db.Exec(
+		"UPDATE notifications SET read_at = now() WHERE id_user = $1 and id = ANY($2) and read_at IS NULL",
+		userID,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *NotificationRepository) MarkAllRead(userID int) (int64, error) {
+
+	const op = "storage.postgresql.notification.MarkAllRead"
+
+	res, err := r.store.db.Exec(
+		"UPDATE notifications SET read_at = now() WHERE id_user = $1 and read_at IS NULL",
+		userID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *NotificationRepository) GetPreferences(userID int) (map[string]bool, error) {
+
+	const op = "storage.postgresql.notification.GetPreferences"
+
+	rows, err := r.store.db.Query(
+		"SELECT type, enabled FROM notification_preferences WHERE id_user = $1",
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	prefs := make(map[string]bool)
+
+	for rows.Next() {
+		var (
+			t       string
+			enabled bool
+		)
+		if err := rows.Scan(&t, &enabled); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		prefs[t] = enabled
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return prefs, nil
+}
+
+// SetPreferences changes only the types listed in prefs.
+func (r *NotificationRepository) SetPreferences(userID int, prefs []model.NotificationPreference) error {
+
+	const op = "storage.postgresql.notification.SetPreferences"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for _, pref := range prefs {
+		_, err := tx.Exec(`
+			INSERT INTO notification_preferences (id_user, type, enabled) VALUES ($1, $2, $3)
+			ON CONFLICT (id_user, type) DO UPDATE SET enabled = EXCLUDED.enabled`,
+			userID,
+			pref.Type,
+			pref.Enabled,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
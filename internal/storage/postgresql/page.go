@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wehw93/kanban-board/internal/lib/cursor"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// queryArgs collects the arguments of a query built on the fly and hands
+// out their placeholders.
+type queryArgs []any
+
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// sortField is an expression a list can be ordered by. sqlType is used to
+// read the sort value back from the cursor.
+type sortField struct {
+	expr    string
+	sqlType string
+}
+
+type pagePosition struct {
+	Sort string `json:"s"`
+	Desc bool   `json:"d"`
+	Key  string `json:"k"`
+	ID   int64  `json:"i"`
+}
+
+// pager turns a storage.Page into keyset pagination over a list with the
+// given sort fields. defaultSort is a field name, prefixed with '-' for a
+// descending order.
+type pager struct {
+	sort  string
+	desc  bool
+	field sortField
+	limit int
+	after *pagePosition
+}
+
+func newPager(page storage.Page, fields map[string]sortField, defaultSort string) (*pager, error) {
+
+	p := &pager{
+		sort:  page.Sort,
+		desc:  page.Desc,
+		limit: page.Limit,
+	}
+
+	if p.sort == "" {
+		p.sort = strings.TrimPrefix(defaultSort, "-")
+		p.desc = strings.HasPrefix(defaultSort, "-")
+	}
+
+	field, ok := fields[p.sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q: %w", p.sort, storage.ErrInvalidPage)
+	}
+	p.field = field
+
+	if p.limit <= 0 {
+		p.limit = storage.DefaultPageLimit
+	}
+	if p.limit > storage.MaxPageLimit {
+		p.limit = storage.MaxPageLimit
+	}
+
+	if page.Cursor != "" {
+		var after pagePosition
+
+		if err := cursor.Decode(page.Cursor, &after); err != nil {
+			return nil, storage.ErrInvalidPage
+		}
+
+		if after.Sort != p.sort || after.Desc != p.desc {
+			return nil, fmt.Errorf("cursor of another sort order: %w", storage.ErrInvalidPage)
+		}
+
+		p.after = &after
+	}
+
+	return p, nil
+}
+
+// sortKey is the select expression to scan the sort value of a row from.
+func (p *pager) sortKey() string {
+	return "(" + p.field.expr + ")::text"
+}
+
+// keyset is the condition skipping the rows of the previous pages.
+func (p *pager) keyset(id string, args *queryArgs) string {
+
+	if p.after == nil {
+		return "true"
+	}
+
+	cmp := ">"
+	if p.desc {
+		cmp = "<"
+	}
+
+	return "(" + p.field.expr + ", " + id + ") " + cmp +
+		" (" + args.add(p.after.Key) + "::" + p.field.sqlType + ", " + args.add(p.after.ID) + ")"
+}
+
+// orderLimit orders the rows and fetches one more than the page holds to
+// tell whether there is a next page.
+func (p *pager) orderLimit(id string, args *queryArgs) string {
+
+	dir := "ASC"
+	if p.desc {
+		dir = "DESC"
+	}
+
+	return "ORDER BY " + p.field.expr + " " + dir + ", " + id + " " + dir + " LIMIT " + args.add(p.limit+1)
+}
+
+// pageOf drops the extra row and returns the cursor of the next page, empty
+// when this page is the last one. keys are the sortKey values of items.
+func pageOf[T any](p *pager, items []T, keys []string, id func(T) int64) ([]T, string, error) {
+
+	if len(items) <= p.limit {
+		return items, "", nil
+	}
+
+	items = items[:p.limit]
+	last := items[p.limit-1]
+
+	next, err := cursor.Encode(pagePosition{
+		Sort: p.sort,
+		Desc: p.desc,
+		Key:  keys[p.limit-1],
+		ID:   id(last),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, next, nil
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 
+	"github.com/wehw93/kanban-board/internal/lib/slug"
 	"github.com/wehw93/kanban-board/internal/model"
 	"github.com/wehw93/kanban-board/internal/storage"
 )
@@ -14,37 +16,165 @@ type ProjectRepository struct {
 	store *Storage
 }
 
-func (r *ProjectRepository) Create(project *model.Project) error {
+func (r *ProjectRepository) Create(project *model.Project, template *model.Template) error {
 
 	const op = "storage.postgresql.user.create"
 
-	err := r.store.db.QueryRow(
-		"INSERT INTO projects (name,id_creator,description) VALUES ($1, $2,$3) returning ID",
-		project.Name,
-		project.IDCreator,
-		project.Description,
-	).Scan(&project.ID)
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	project.Slug, err = withFreeSlug(tx, slug.Make(project.Name), 0, func(slug string) error {
+		return tx.QueryRow(
+			"INSERT INTO projects (name,id_creator,description,slug) VALUES ($1, $2,$3,$4) returning ID",
+			project.Name,
+			project.IDCreator,
+			project.Description,
+			slug,
+		).Scan(&project.ID)
+	})
+	if err != nil {
+		if uniqueConstraint(err) == projectNameKey {
+			return fmt.Errorf("%s: %w", op, storage.ErrProjectExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var boardID int64
+
+	err = tx.QueryRow("INSERT INTO boards (id_project, name) VALUES ($1, $2) RETURNING id", project.ID, defaultBoardName).Scan(&boardID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if template != nil {
+		for _, c := range template.Columns {
+			_, err := tx.Exec(
+				"INSERT INTO columns (name, id_board, id_project, wip_limit) VALUES ($1, $2, $3, $4)",
+				c.Name,
+				boardID,
+				project.ID,
+				c.WIP_limit,
+			)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		for _, name := range template.Labels {
+			_, err := tx.Exec("INSERT INTO labels (id_project, name) VALUES ($1, $2)", project.ID, name)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	slog.Info("project created", slog.Int64("id", int64(project.ID)))
 
 	return nil
 }
-func (r *ProjectRepository) GetByName(name string) (*model.Project, error) {
 
-	const op = "storage.postgresql.project.getbyname"
+// projectNameKey keeps project names unique per creator, archived projects
+// included, so a restored project never clashes with a newer one.
+const projectNameKey = "projects_creator_name_key"
+
+// projectSlugKey keeps slugs unique across all projects.
+const projectSlugKey = "projects_slug_key"
+
+// maxSlugAttempts bounds how often a project loses the race for a slug.
+const maxSlugAttempts = 5
+
+// withFreeSlug calls save with a free slug made from base and returns the
+// slug saved. A project that took the slug since it was looked up makes
+// save fail on projectSlugKey, then the next free one is tried; save runs
+// in a savepoint so the failure doesn't abort tx.
+func withFreeSlug(tx *sql.Tx, base string, projectID int64, save func(slug string) error) (string, error) {
 
-	project := &model.Project{
-		Name: name,
+	const op = "storage.postgresql.project.withFreeSlug"
+
+	for attempt := 1; ; attempt++ {
+		slug, err := freeSlug(tx, base, projectID)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		if _, err := tx.Exec("SAVEPOINT project_slug"); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = save(slug)
+		if uniqueConstraint(err) == projectSlugKey && attempt < maxSlugAttempts {
+			if _, err := tx.Exec("ROLLBACK TO SAVEPOINT project_slug"); err != nil {
+				return "", fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := tx.Exec("RELEASE SAVEPOINT project_slug"); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		return slug, nil
+	}
+}
+
+// freeSlug returns base, or base with the smallest "-N" suffix, that no
+// project other than projectID uses yet.
+func freeSlug(q querier, base string, projectID int64) (string, error) {
+
+	const op = "storage.postgresql.project.freeSlug"
+
+	rows, err := q.Query(
+		"SELECT slug FROM projects WHERE id <> $2 and (slug = $1 or slug LIKE $1 || '-%')",
+		base,
+		projectID,
+	)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		taken[s] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	candidate := base
+	for n := 2; taken[candidate]; n++ {
+		candidate = base + "-" + strconv.Itoa(n)
+	}
+
+	return candidate, nil
+}
+
+// getProject reads the active project matching the condition.
+func (r *ProjectRepository) getProject(op string, cond string, args ...any) (*model.Project, error) {
+
+	project := &model.Project{}
 
 	err := r.store.db.QueryRow(
-		"SELECT * FROM projects WHERE name = $1",
-		name,
+		"SELECT id, name, slug, id_creator, description FROM projects WHERE "+cond+" and archived_at IS NULL",
+		args...,
 	).Scan(&project.ID,
 		&project.Name,
+		&project.Slug,
 		&project.IDCreator,
 		&project.Description)
 	if err != nil {
@@ -58,50 +188,215 @@ func (r *ProjectRepository) GetByName(name string) (*model.Project, error) {
 	return project, nil
 }
 
-func (r *ProjectRepository) GetTasks(projectID int) ([]model.Task, error) {
+func (r *ProjectRepository) GetByID(id int) (*model.Project, error) {
+	return r.getProject("storage.postgresql.project.GetByID", "id = $1", id)
+}
+
+func (r *ProjectRepository) GetBySlug(slug string) (*model.Project, error) {
+	return r.getProject("storage.postgresql.project.GetBySlug", "slug = $1", slug)
+}
+
+// GetByName returns the oldest active project with the name. Names are only
+// unique per creator, prefer GetByID or GetOwnByName.
+func (r *ProjectRepository) GetByName(name string) (*model.Project, error) {
+	return r.getProject("storage.postgresql.project.getbyname", "id = (SELECT min(id) FROM projects WHERE name = $1 and archived_at IS NULL)", name)
+}
+
+func (r *ProjectRepository) GetOwnByName(userID int, name string) (*model.Project, error) {
+	return r.getProject("storage.postgresql.project.GetOwnByName", "id_creator = $1 and name = $2", userID, name)
+}
+
+// IsMember reports whether the user works in the project, in the same sense
+// search and task queries use.
+func (r *ProjectRepository) IsMember(userID int, projectID int) (bool, error) {
+
+	const op = "storage.postgresql.project.IsMember"
+
+	var member bool
+
+	err := r.store.db.QueryRow(
+		"SELECT $2 IN ("+accessibleProjects+")",
+		userID,
+		projectID,
+	).Scan(&member)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+// projectTaskSorts are the orders tasks of a project or a column can be listed in.
+var projectTaskSorts = map[string]sortField{
+	"id":     {expr: "t.id", sqlType: "bigint"},
+	"name":   {expr: "t.name", sqlType: "text"},
+	"status": {expr: "t.status", sqlType: "text"},
+}
+
+func (r *ProjectRepository) GetTasks(projectID int, page storage.Page) ([]model.Task, string, error) {
 
 	const op = "storage.postgresql.project.get_tasks"
 
+	p, err := newPager(page, projectTaskSorts, "id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{projectID}
+
 	rows, err := r.store.db.Query(
-		`SELECT t.id, t.name, t.description,t.status FROM tasks t 
+		`SELECT t.id, t.name, t.description,t.status, `+p.sortKey()+` FROM tasks t 
 		JOIN columns c ON t.id_column = c.id 
-		WHERE c.id_project = $1`,
-		projectID,
+		WHERE c.id_project = $1
+		and t.archived_at IS NULL and c.archived_at IS NULL
+		and `+p.keyset("t.id", &args)+`
+		`+p.orderLimit("t.id", &args),
+		args...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var tasks []model.Task
+	var (
+		tasks []model.Task
+		keys  []string
+	)
 
 	for rows.Next() {
-		var t model.Task
+		var (
+			t   model.Task
+			key string
+		)
 		if err := rows.Scan(
 			&t.ID,
 			&t.Name,
 			&t.Description,
 			&t.Status,
+			&key,
 		); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, "", fmt.Errorf("%s: %w", op, err)
 		}
 		tasks = append(tasks, t)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, next, err := pageOf(p, tasks, keys, taskID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, next, nil
+}
+
+func taskID(t model.Task) int64 {
+	return t.ID
+}
+
+func (r *ProjectRepository) GetColumns(projectID int) ([]model.Column, error) {
+
+	const op = "storage.postgresql.project.GetColumns"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, name, id_project, id_board, wip_limit FROM columns WHERE id_project = $1 and archived_at IS NULL ORDER BY id",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var columns []model.Column
+
+	for rows.Next() {
+		var c model.Column
+		if err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.ID_project,
+			&c.ID_board,
+			&c.WIP_limit,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return columns, nil
+}
+
+func (r *ProjectRepository) GetBoards(projectID int) ([]model.Board, error) {
+
+	const op = "storage.postgresql.project.GetBoards"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, name, id_project FROM boards WHERE id_project = $1 ORDER BY id",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var boards []model.Board
+
+	for rows.Next() {
+		var b model.Board
+		if err := rows.Scan(
+			&b.ID,
+			&b.Name,
+			&b.ID_project,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		boards = append(boards, b)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return tasks, nil
+	return boards, nil
+}
+
+func (r *ProjectRepository) Archive(userID int, id int) error {
+
+	const op = "storage.postgresql.project.Archive"
+
+	res, err := r.store.db.Exec(
+		"UPDATE projects SET archived_at = now() WHERE id_creator = $1 and id = $2 and archived_at IS NULL",
+		userID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrProjectNotFound)
+	}
+
+	return nil
 }
 
-func (r *ProjectRepository) Delete(userID int, name string) error {
+func (r *ProjectRepository) Unarchive(userID int, id int) error {
 
-	const op = "storage.postgresql.project.delete"
+	const op = "storage.postgresql.project.Unarchive"
 
 	res, err := r.store.db.Exec(
-		"DELETE FROM projects WHERE id_creator = $1 and name = $2",
+		"UPDATE projects SET archived_at = NULL WHERE id = $1 and id_creator = $2 and archived_at IS NOT NULL",
+		id,
 		userID,
-		name,
 	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -119,12 +414,32 @@ func (r *ProjectRepository) Delete(userID int, name string) error {
 	return nil
 }
 
-func (r *ProjectRepository) UpdateName(name string, project model.Project) error {
+// UpdateName renames the project and gives it a slug made from the new name.
+func (r *ProjectRepository) UpdateName(project model.Project) error {
 
 	const op = "storage.postgresql.project.updateName"
 
-	res, err := r.store.db.Exec("UPDATE projects SET name = $1 WHERE name = $2 and id_creator = $3", name, project.Name, project.IDCreator)
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var res sql.Result
+
+	_, err = withFreeSlug(tx, slug.Make(project.Name), project.ID, func(slug string) error {
+		var err error
+		res, err = tx.Exec("UPDATE projects SET name = $1, slug = $2 WHERE id = $3 and id_creator = $4 and archived_at IS NULL",
+			project.Name,
+			slug,
+			project.ID,
+			project.IDCreator)
+		return err
+	})
 	if err != nil {
+		if uniqueConstraint(err) == projectNameKey {
+			return fmt.Errorf("%s: %w", op, storage.ErrProjectExists)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -137,6 +452,10 @@ func (r *ProjectRepository) UpdateName(name string, project model.Project) error
 		return storage.ErrProjectNotFound
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
@@ -144,9 +463,9 @@ func (r *ProjectRepository) UpdateDescription(project model.Project) error {
 
 	const op = "storage.postgresql.project.UpdateDescription"
 
-	res, err := r.store.db.Exec("UPDATE projects SET description = $1 WHERE name = $2 and id_creator = $3",
+	res, err := r.store.db.Exec("UPDATE projects SET description = $1 WHERE id = $2 and id_creator = $3 and archived_at IS NULL",
 		project.Description,
-		project.Name,
+		project.ID,
 		project.IDCreator)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -164,33 +483,62 @@ func (r *ProjectRepository) UpdateDescription(project model.Project) error {
 	return nil
 }
 
-func (r *ProjectRepository) ListProjects() ([]model.Project, error) {
+var projectSorts = map[string]sortField{
+	"id":   {expr: "id", sqlType: "bigint"},
+	"name": {expr: "name", sqlType: "text"},
+}
+
+func (r *ProjectRepository) ListProjects(page storage.Page) ([]model.Project, string, error) {
 
 	const op = "storage.postgresql.project.ListProjects"
 
-	var listProjects []model.Project
+	p, err := newPager(page, projectSorts, "id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var (
+		listProjects []model.Project
+		keys         []string
+		args         queryArgs
+	)
 
-	rows, err := r.store.db.Query("SELECT * FROM projects")
+	rows, err := r.store.db.Query(
+		"SELECT id, name, slug, id_creator, description, "+p.sortKey()+" FROM projects WHERE archived_at IS NULL and "+
+			p.keyset("id", &args)+" "+p.orderLimit("id", &args),
+		args...,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var p model.Project
+		var (
+			pr  model.Project
+			key string
+		)
 		if err := rows.Scan(
-			&p.ID,
-			&p.Name,
-			&p.IDCreator,
-			&p.Description,
+			&pr.ID,
+			&pr.Name,
+			&pr.Slug,
+			&pr.IDCreator,
+			&pr.Description,
+			&key,
 		); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, "", fmt.Errorf("%s: %w", op, err)
 		}
-		listProjects = append(listProjects, p)
+		listProjects = append(listProjects, pr)
+		keys = append(keys, key)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	listProjects, next, err := pageOf(p, listProjects, keys, func(pr model.Project) int64 { return pr.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return listProjects, nil
+	return listProjects, next, nil
 }
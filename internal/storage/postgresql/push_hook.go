@@ -0,0 +1,84 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type PushHookRepository struct {
+	store *Storage
+}
+
+func (r *PushHookRepository) SetSecret(userID int, projectID int, secret string) error {
+
+	const op = "storage.postgresql.push_hook.SetSecret"
+
+	res, err := r.store.db.Exec(`
+		INSERT INTO push_hooks (id_project, secret)
+		SELECT $2, $3
+		WHERE $2 IN (`+ownedProjects+`)
+		ON CONFLICT (id_project) DO UPDATE SET secret = EXCLUDED.secret, created_at = now()`,
+		userID,
+		projectID,
+		secret,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrProjectNotFound)
+	}
+
+	return nil
+}
+
+func (r *PushHookRepository) DeletePushHook(userID int, projectID int) error {
+
+	const op = "storage.postgresql.push_hook.DeletePushHook"
+
+	res, err := r.store.db.Exec(
+		"DELETE FROM push_hooks WHERE id_project = $2 and id_project IN ("+ownedProjects+")",
+		userID,
+		projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrPushHookNotFound)
+	}
+
+	return nil
+}
+
+func (r *PushHookRepository) GetSecret(projectID int) (string, error) {
+
+	const op = "storage.postgresql.push_hook.GetSecret"
+
+	var secret string
+
+	err := r.store.db.QueryRow("SELECT secret FROM push_hooks WHERE id_project = $1", projectID).Scan(&secret)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrPushHookNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, nil
+}
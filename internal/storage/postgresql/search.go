@@ -0,0 +1,228 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+	"github.com/wehw93/kanban-board/internal/taskql"
+)
+
+type SearchRepository struct {
+	store *Storage
+}
+
+// accessibleProjects selects the projects the user $1 works in: the ones
+// they created and the ones where they created or execute a task.
+const accessibleProjects = `
+	SELECT id FROM projects WHERE id_creator = $1
+	UNION
+	SELECT c.id_project FROM tasks t JOIN columns c ON c.id = t.id_column
+	WHERE t.id_creator = $1 or t.id_executor = $1`
+
+// searchHeadline marks the matched words in snippets, clients are expected
+// to escape the rest of the text themselves.
+const searchHeadline = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2"
+
+var searchSorts = map[string]sortField{
+	"rank": {expr: "ts_rank(t.search, q)", sqlType: "real"},
+}
+
+// SearchTasks finds tasks matching the query in the accessible projects,
+// best matches first. Matches in the name rank above the description, which
+// ranks above the comments.
+func (r *SearchRepository) SearchTasks(userID int, filter model.SearchFilter, page storage.Page) ([]model.SearchHit, string, error) {
+
+	const op = "storage.postgresql.search.SearchTasks"
+
+	pg, err := newPager(page, searchSorts, "-rank")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID, filter.Query}
+
+	where := []string{
+		"t.search @@ q",
+		"t.archived_at IS NULL",
+		"c.archived_at IS NULL",
+		"p.archived_at IS NULL",
+		"c.id_project IN (" + accessibleProjects + ")",
+	}
+
+	if filter.Status != "" {
+		where = append(where, "t.status = "+args.add(filter.Status))
+	}
+	if filter.ID_executor != 0 {
+		where = append(where, "t.id_executor = "+args.add(filter.ID_executor))
+	}
+	if filter.ID_label != 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM task_labels tl WHERE tl.id_task = t.id and tl.id_label = "+args.add(filter.ID_label)+")")
+	}
+	if filter.Created_from.Valid {
+		where = append(where, "t.date_of_create >= "+args.add(filter.Created_from.Time)+"::date")
+	}
+	if filter.Created_to.Valid {
+		where = append(where, "t.date_of_create <= "+args.add(filter.Created_to.Time)+"::date")
+	}
+	where = append(where, pg.keyset("t.id", &args))
+
+	query := `
+		SELECT t.id, t.id_column, t.name, t.description, t.date_of_create, t.id_executor,
+		t.status, t.priority, c.id_project, ts_rank(t.search, q),
+		ts_headline('simple', t.name || ' ' || t.description || coalesce(' ' || (
+			SELECT string_agg(cm.body, ' ' ORDER BY cm.id) FROM comments cm WHERE cm.id_task = t.id
+		), ''), q, '` + searchHeadline + `'),
+		` + pg.sortKey() + `
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN projects p ON p.id = c.id_project
+		CROSS JOIN websearch_to_tsquery('simple', $2) q
+		WHERE ` + strings.Join(where, " and ") + `
+		` + pg.orderLimit("t.id", &args)
+
+	rows, err := r.store.db.Query(query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		hits []model.SearchHit
+		keys []string
+	)
+
+	for rows.Next() {
+		var (
+			h   model.SearchHit
+			key string
+		)
+		if err := rows.Scan(
+			&h.Task.ID,
+			&h.Task.ID_column,
+			&h.Task.Name,
+			&h.Task.Description,
+			&h.Task.Date_of_create,
+			&h.Task.ID_executor,
+			&h.Task.Status,
+			&h.Task.Priority,
+			&h.ID_project,
+			&h.Rank,
+			&h.Snippet,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		hits = append(hits, h)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	hits, next, err := pageOf(pg, hits, keys, func(h model.SearchHit) int64 { return h.Task.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hits, next, nil
+}
+
+var taskQuerySorts = map[string]sortField{
+	"id":       {expr: "t.id", sqlType: "bigint"},
+	"name":     {expr: "t.name", sqlType: "text"},
+	"created":  {expr: "t.date_of_create", sqlType: "date"},
+	"priority": {expr: "array_position(" + priorityOrder + ", t.priority)", sqlType: "int"},
+}
+
+// QueryTasks returns tasks of the accessible projects matching the parsed
+// query, newest first by default. projectID of 0 means any project.
+func (r *SearchRepository) QueryTasks(userID int, q taskql.Query, projectID int, page storage.Page) ([]model.Task, string, error) {
+
+	const op = "storage.postgresql.search.QueryTasks"
+
+	pg, err := newPager(page, taskQuerySorts, "-id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID}
+
+	cond, err := compileTaskQuery(q, userID, args.add)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	where := []string{
+		cond,
+		"t.archived_at IS NULL",
+		"c.archived_at IS NULL",
+		"p.archived_at IS NULL",
+		"c.id_project IN (" + accessibleProjects + ")",
+	}
+
+	if projectID != 0 {
+		where = append(where, "c.id_project = "+args.add(projectID))
+	}
+	where = append(where, pg.keyset("t.id", &args))
+
+	query := `
+		SELECT t.id, t.id_column, t.name, t.description, t.date_of_create, t.date_of_execution,
+		t.id_executor, t.id_creator, t.status, t.priority, t.id_swimlane, t.due_date, t.estimate,
+		` + timeSpent("t.id") + `, ` + pg.sortKey() + `
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN projects p ON p.id = c.id_project
+		WHERE ` + strings.Join(where, " and ") + `
+		` + pg.orderLimit("t.id", &args)
+
+	rows, err := r.store.db.Query(query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		tasks []model.Task
+		keys  []string
+	)
+
+	for rows.Next() {
+		var (
+			t   model.Task
+			key string
+		)
+		if err := rows.Scan(
+			&t.ID,
+			&t.ID_column,
+			&t.Name,
+			&t.Description,
+			&t.Date_of_create,
+			&t.Date_of_execution,
+			&t.ID_executor,
+			&t.ID_creator,
+			&t.Status,
+			&t.Priority,
+			&t.ID_swimlane,
+			&t.Due_date,
+			&t.Estimate,
+			&t.Time_spent,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, next, err := pageOf(pg, tasks, keys, taskID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, next, nil
+}
@@ -2,19 +2,66 @@ package postgresql
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/wehw93/kanban-board/internal/storage"
 )
 
 type Storage struct {
-	db                  *sql.DB
-	userrepository      *UserRepository
-	taskrepository      *TaskRepository
-	projectRepository   *ProjectRepository
-	columnRepository    *ColumnRepository
-	task_log_Repository *Task_log_Repository
+	db                     *sql.DB
+	userrepository         *UserRepository
+	taskrepository         *TaskRepository
+	projectRepository      *ProjectRepository
+	boardRepository        *BoardRepository
+	columnRepository       *ColumnRepository
+	task_log_Repository    *Task_log_Repository
+	labelRepository        *LabelRepository
+	swimlaneRepository     *SwimlaneRepository
+	archiveRepository      *ArchiveRepository
+	trashRepository        *TrashRepository
+	searchRepository       *SearchRepository
+	filterRepository       *FilterRepository
+	eventRepository        *EventRepository
+	webhookRepository      *WebhookRepository
+	pushHookRepository     *PushHookRepository
+	notificationRepository *NotificationRepository
+	digestRepository       *DigestRepository
+	metricsRepository      *MetricsRepository
+	exportRepository       *ExportRepository
+	importRepository       *ImportRepository
+	templateRepository     *TemplateRepository
+	taskTemplateRepository *TaskTemplateRepository
+	timeEntryRepository    *TimeEntryRepository
+	commentRepository      *CommentRepository
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx, so helpers can run
+// either standalone or as a part of a transaction.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// uniqueConstraint returns the name of the unique constraint err violates,
+// empty when err is something else.
+func uniqueConstraint(err error) string {
+	var pqErr *pq.Error
+
+	if !isUniqueViolation(err) || !errors.As(err, &pqErr) {
+		return ""
+	}
+
+	return pqErr.Constraint
 }
 
 func New(dsn string) (*Storage, error) {
@@ -59,6 +106,19 @@ func (s *Storage) Project() storage.ProjectRepository {
 	return s.projectRepository
 }
 
+func (s *Storage) Board() storage.BoardRepository {
+
+	if s.boardRepository != nil {
+		return s.boardRepository
+	}
+
+	s.boardRepository = &BoardRepository{
+		store: s,
+	}
+
+	return s.boardRepository
+}
+
 func (s *Storage) Task_log() storage.Task_log_Repository {
 
 	if s.task_log_Repository != nil {
@@ -97,6 +157,240 @@ func (s *Storage) Task() storage.TaskRepository {
 	return s.taskrepository
 }
 
+func (s *Storage) Label() storage.LabelRepository {
+
+	if s.labelRepository != nil {
+		return s.labelRepository
+	}
+
+	s.labelRepository = &LabelRepository{
+		store: s,
+	}
+
+	return s.labelRepository
+}
+
+func (s *Storage) Swimlane() storage.SwimlaneRepository {
+
+	if s.swimlaneRepository != nil {
+		return s.swimlaneRepository
+	}
+
+	s.swimlaneRepository = &SwimlaneRepository{
+		store: s,
+	}
+
+	return s.swimlaneRepository
+}
+
+func (s *Storage) Archive() storage.ArchiveRepository {
+
+	if s.archiveRepository != nil {
+		return s.archiveRepository
+	}
+
+	s.archiveRepository = &ArchiveRepository{
+		store: s,
+	}
+
+	return s.archiveRepository
+}
+
+func (s *Storage) Trash() storage.TrashRepository {
+
+	if s.trashRepository != nil {
+		return s.trashRepository
+	}
+
+	s.trashRepository = &TrashRepository{
+		store: s,
+	}
+
+	return s.trashRepository
+}
+
+func (s *Storage) Search() storage.SearchRepository {
+
+	if s.searchRepository != nil {
+		return s.searchRepository
+	}
+
+	s.searchRepository = &SearchRepository{
+		store: s,
+	}
+
+	return s.searchRepository
+}
+
+func (s *Storage) Filter() storage.FilterRepository {
+
+	if s.filterRepository != nil {
+		return s.filterRepository
+	}
+
+	s.filterRepository = &FilterRepository{
+		store: s,
+	}
+
+	return s.filterRepository
+}
+
+func (s *Storage) Event() storage.EventRepository {
+
+	if s.eventRepository != nil {
+		return s.eventRepository
+	}
+
+	s.eventRepository = &EventRepository{
+		store: s,
+	}
+
+	return s.eventRepository
+}
+
+func (s *Storage) Webhook() storage.WebhookRepository {
+
+	if s.webhookRepository != nil {
+		return s.webhookRepository
+	}
+
+	s.webhookRepository = &WebhookRepository{
+		store: s,
+	}
+
+	return s.webhookRepository
+}
+
+func (s *Storage) PushHook() storage.PushHookRepository {
+
+	if s.pushHookRepository != nil {
+		return s.pushHookRepository
+	}
+
+	s.pushHookRepository = &PushHookRepository{
+		store: s,
+	}
+
+	return s.pushHookRepository
+}
+
+func (s *Storage) Notification() storage.NotificationRepository {
+
+	if s.notificationRepository != nil {
+		return s.notificationRepository
+	}
+
+	s.notificationRepository = &NotificationRepository{
+		store: s,
+	}
+
+	return s.notificationRepository
+}
+
+func (s *Storage) Digest() storage.DigestRepository {
+
+	if s.digestRepository != nil {
+		return s.digestRepository
+	}
+
+	s.digestRepository = &DigestRepository{
+		store: s,
+	}
+
+	return s.digestRepository
+}
+
+func (s *Storage) Metrics() storage.MetricsRepository {
+
+	if s.metricsRepository != nil {
+		return s.metricsRepository
+	}
+
+	s.metricsRepository = &MetricsRepository{
+		store: s,
+	}
+
+	return s.metricsRepository
+}
+
+func (s *Storage) Export() storage.ExportRepository {
+
+	if s.exportRepository != nil {
+		return s.exportRepository
+	}
+
+	s.exportRepository = &ExportRepository{
+		store: s,
+	}
+
+	return s.exportRepository
+}
+
+func (s *Storage) Import() storage.ImportRepository {
+
+	if s.importRepository != nil {
+		return s.importRepository
+	}
+
+	s.importRepository = &ImportRepository{
+		store: s,
+	}
+
+	return s.importRepository
+}
+
+func (s *Storage) Template() storage.TemplateRepository {
+
+	if s.templateRepository != nil {
+		return s.templateRepository
+	}
+
+	s.templateRepository = &TemplateRepository{
+		store: s,
+	}
+
+	return s.templateRepository
+}
+
+func (s *Storage) TaskTemplate() storage.TaskTemplateRepository {
+
+	if s.taskTemplateRepository != nil {
+		return s.taskTemplateRepository
+	}
+
+	s.taskTemplateRepository = &TaskTemplateRepository{
+		store: s,
+	}
+
+	return s.taskTemplateRepository
+}
+
+func (s *Storage) TimeEntry() storage.TimeEntryRepository {
+
+	if s.timeEntryRepository != nil {
+		return s.timeEntryRepository
+	}
+
+	s.timeEntryRepository = &TimeEntryRepository{
+		store: s,
+	}
+
+	return s.timeEntryRepository
+}
+
+func (s *Storage) Comment() storage.CommentRepository {
+
+	if s.commentRepository != nil {
+		return s.commentRepository
+	}
+
+	s.commentRepository = &CommentRepository{
+		store: s,
+	}
+
+	return s.commentRepository
+}
+
 func (s *Storage) Close() {
 
 	s.db.Close()
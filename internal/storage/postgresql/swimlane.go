@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type SwimlaneRepository struct {
+	store *Storage
+}
+
+func (r *SwimlaneRepository) CreateSwimlane(swimlane *model.Swimlane) error {
+
+	const op = "storage.postgresql.swimlane.CreateSwimlane"
+
+	err := r.store.db.QueryRow(
+		"INSERT INTO swimlanes (id_project, name, position) VALUES ($1, $2, $3) RETURNING id",
+		swimlane.ID_project,
+		swimlane.Name,
+		swimlane.Position,
+	).Scan(&swimlane.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *SwimlaneRepository) GetSwimlanes(projectID int) ([]model.Swimlane, error) {
+
+	const op = "storage.postgresql.swimlane.GetSwimlanes"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, name, id_project, position FROM swimlanes WHERE id_project = $1 ORDER BY position, id",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var swimlanes []model.Swimlane
+
+	for rows.Next() {
+		var s model.Swimlane
+		if err := rows.Scan(
+			&s.ID,
+			&s.Name,
+			&s.ID_project,
+			&s.Position,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		swimlanes = append(swimlanes, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return swimlanes, nil
+}
+
+func (r *SwimlaneRepository) DeleteSwimlane(id int) error {
+
+	const op = "storage.postgresql.swimlane.DeleteSwimlane"
+
+	res, err := r.store.db.Exec("DELETE FROM swimlanes WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrSwimlaneNotFound)
+	}
+
+	return nil
+}
+
+func (r *SwimlaneRepository) UpdateSwimlane(swimlane model.Swimlane) error {
+
+	const op = "storage.postgresql.swimlane.UpdateSwimlane"
+
+	res, err := r.store.db.Exec(
+		"UPDATE swimlanes SET name = $1, position = $2 WHERE id = $3",
+		swimlane.Name,
+		swimlane.Position,
+		swimlane.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrSwimlaneNotFound)
+	}
+
+	return nil
+}
+
+// checkSwimlane makes sure the swimlane exists and belongs to the project
+// of the column, tasks can't be put into lanes of another project.
+func checkSwimlane(q querier, swimlaneID int64, columnID int64) error {
+
+	const op = "storage.postgresql.swimlane.checkSwimlane"
+
+	var ok bool
+
+	err := q.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1
+			FROM swimlanes s
+			JOIN columns c ON c.id_project = s.id_project
+			WHERE s.id = $1 and c.id = $2
+		)`,
+		swimlaneID,
+		columnID,
+	).Scan(&ok)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrSwimlaneNotFound)
+	}
+
+	return nil
+}
@@ -4,7 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/wehw93/kanban-board/internal/model"
@@ -16,34 +16,64 @@ type TaskRepository struct {
 }
 
 const (
-	todo       = "todo"
-	inProgress = "in_progress"
-	done       = "done"
+	todo       = model.StatusTodo
+	inProgress = model.StatusInProgress
+	done       = model.StatusDone
 )
 
 func (r *TaskRepository) CreateTask(task *model.Task) error {
 
 	const op = "storage.postgresql.Task.CreateTask"
 
-	err := r.store.db.QueryRow(
-		`INSERT INTO tasks (id_column,name,description,id_creator,status,date_of_create) 
-		VALUES ($1,$2,$3,$4,$5,$6) RETURNING id`,
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := reserveColumnSlot(tx, task.ID_column); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if task.ID_swimlane.Valid {
+		if err := checkSwimlane(tx, task.ID_swimlane.Int64, task.ID_column); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if task.Priority == "" {
+		task.Priority = model.PriorityMedium
+	}
+
+	task.Status = todo
+
+	err = tx.QueryRow(
+		`INSERT INTO tasks (id_column,name,description,id_creator,status,date_of_create,priority,id_swimlane,due_date,estimate) 
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING id`,
 		task.ID_column,
 		task.Name,
 		task.Description,
 		task.ID_creator,
-		todo,
+		task.Status,
 		task.Date_of_create,
+		task.Priority,
+		task.ID_swimlane,
+		task.Due_date,
+		task.Estimate,
 	).Scan(&task.ID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = r.logging(int(task.ID), "create task")
+	err = logging(tx, int(task.ID), "create task")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
@@ -51,7 +81,10 @@ func (r *TaskRepository) ReadTask(task *model.Task) error {
 
 	const op = "storage.postgresql.Task.ReadTask"
 
-	err := r.store.db.QueryRow("SELECT * FROM tasks WHERE id = $1",
+	err := r.store.db.QueryRow(`
+		SELECT id, id_column, name, description, date_of_create, date_of_execution,
+		id_executor, id_creator, status, priority, id_swimlane, due_date, estimate, `+timeSpent("tasks.id")+`
+		FROM tasks WHERE id = $1 and archived_at IS NULL`,
 		task.ID,
 	).Scan(
 		&task.ID,
@@ -63,6 +96,11 @@ func (r *TaskRepository) ReadTask(task *model.Task) error {
 		&task.ID_executor,
 		&task.ID_creator,
 		&task.Status,
+		&task.Priority,
+		&task.ID_swimlane,
+		&task.Due_date,
+		&task.Estimate,
+		&task.Time_spent,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -74,11 +112,36 @@ func (r *TaskRepository) ReadTask(task *model.Task) error {
 	return nil
 }
 
-func (r *TaskRepository) DeleteTask(IDuser int, id int) error {
+// GetProjectID returns the project of the task, archived tasks included.
+func (r *TaskRepository) GetProjectID(id int) (int64, error) {
+
+	const op = "storage.postgresql.Task.GetProjectID"
 
-	const op = "storage.postgresql.Task.DeleteTask"
+	var projectID int64
 
-	res, err := r.store.db.Exec("DELETE FROM tasks WHERE id = $1 and id_creator = $2", id, IDuser)
+	err := r.store.db.QueryRow(
+		"SELECT c.id_project FROM tasks t JOIN columns c ON c.id = t.id_column WHERE t.id = $1",
+		id,
+	).Scan(&projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projectID, nil
+}
+
+func (r *TaskRepository) ArchiveTask(IDuser int, id int) error {
+
+	const op = "storage.postgresql.Task.ArchiveTask"
+
+	res, err := r.store.db.Exec(
+		"UPDATE tasks SET archived_at = now() WHERE id = $1 and id_creator = $2 and archived_at IS NULL",
+		id,
+		IDuser,
+	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -92,6 +155,58 @@ func (r *TaskRepository) DeleteTask(IDuser int, id int) error {
 		return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
 	}
 
+	err = logging(r.store.db, id, "archive task")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UnarchiveTask puts the task back into its column, the column must not be
+// archived itself and has to have room under its WIP limit.
+func (r *TaskRepository) UnarchiveTask(IDuser int, id int) error {
+
+	const op = "storage.postgresql.Task.UnarchiveTask"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var columnID int64
+
+	err = tx.QueryRow(
+		"SELECT id_column FROM tasks WHERE id = $1 and id_creator = $2 and archived_at IS NOT NULL FOR UPDATE",
+		id,
+		IDuser,
+	).Scan(&columnID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := reserveColumnSlot(tx, columnID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec("UPDATE tasks SET archived_at = NULL WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = logging(tx, id, "restore task")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
@@ -99,7 +214,7 @@ func (r *TaskRepository) UpdateTaskName(task *model.Task) error {
 
 	const op = "storage.postgresql.Task.UpdateTaskName"
 
-	res, err := r.store.db.Exec("UPDATE tasks SET name = $1 WHERE id = $2",
+	res, err := r.store.db.Exec("UPDATE tasks SET name = $1 WHERE id = $2 and archived_at IS NULL",
 		task.Name,
 		task.ID)
 	if err != nil {
@@ -122,7 +237,7 @@ func (r *TaskRepository) UpdateTaskDescription(task *model.Task) error {
 
 	const op = "storage.postgresql.Task.UpdateTaskDescription"
 
-	res, err := r.store.db.Exec("UPDATE tasks SET description = $1 WHERE id = $2", task.Description, task.ID)
+	res, err := r.store.db.Exec("UPDATE tasks SET description = $1 WHERE id = $2 and archived_at IS NULL", task.Description, task.ID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -139,58 +254,275 @@ func (r *TaskRepository) UpdateTaskDescription(task *model.Task) error {
 	return nil
 }
 
+func (r *TaskRepository) UpdateTaskPriority(task *model.Task) error {
+
+	const op = "storage.postgresql.Task.UpdateTaskPriority"
+
+	res, err := r.store.db.Exec("UPDATE tasks SET priority = $1 WHERE id = $2 and archived_at IS NULL", task.Priority, task.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) UpdateTaskDueDate(task *model.Task) error {
+
+	const op = "storage.postgresql.Task.UpdateTaskDueDate"
+
+	res, err := r.store.db.Exec(
+		"UPDATE tasks SET due_date = $1 WHERE id = $2 and archived_at IS NULL",
+		task.Due_date,
+		task.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) UpdateTaskEstimate(task *model.Task) error {
+
+	const op = "storage.postgresql.Task.UpdateTaskEstimate"
+
+	res, err := r.store.db.Exec(
+		"UPDATE tasks SET estimate = $1 WHERE id = $2 and archived_at IS NULL",
+		task.Estimate,
+		task.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) UpdateTaskSwimlane(task *model.Task) error {
+
+	const op = "storage.postgresql.Task.UpdateTaskSwimlane"
+
+	err := r.store.db.QueryRow("SELECT id_column FROM tasks WHERE id = $1 and archived_at IS NULL", task.ID).Scan(&task.ID_column)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if task.ID_swimlane.Valid {
+		if err := checkSwimlane(r.store.db, task.ID_swimlane.Int64, task.ID_column); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	res, err := r.store.db.Exec("UPDATE tasks SET id_swimlane = $1 WHERE id = $2 and archived_at IS NULL", task.ID_swimlane, task.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+	}
+
+	return nil
+}
+
+// UpdateTaskExecutor assigns the task to task.ID_executor, or to nobody when
+// it is not valid.
+func (r *TaskRepository) UpdateTaskExecutor(task *model.Task) error {
+
+	const op = "storage.postgresql.Task.UpdateTaskExecutor"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if task.ID_executor.Valid {
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", task.ID_executor).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+	}
+
+	res, err := tx.Exec(
+		"UPDATE tasks SET id_executor = $1 WHERE id = $2 and archived_at IS NULL",
+		task.ID_executor,
+		task.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+	}
+
+	info := "unassign task"
+	if task.ID_executor.Valid {
+		info = "assign task to user " + strconv.FormatInt(task.ID_executor.Int64, 10)
+	}
+
+	if err := logging(tx, int(task.ID), info); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (r *TaskRepository) UpdateTaskColumn(task *model.Task) error {
 
 	const op = "storage.postgresql.Task.UpdateTaskColumn"
 
 	newIdColumn := task.ID_column
 
-	err := r.store.db.QueryRow("SELECT id_column FROM tasks WHERE id = $1",
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var oldStatus string
+
+	err = tx.QueryRow("SELECT id_column, status FROM tasks WHERE id = $1 and archived_at IS NULL FOR UPDATE",
 		task.ID,
-	).Scan(&task.ID_column)
+	).Scan(&task.ID_column, &oldStatus)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	var idProject int64
+	if newIdColumn != task.ID_column {
+		if err := reserveColumnSlot(tx, newIdColumn); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var idProject, idBoard int64
 
-	err = r.store.db.QueryRow(`
-		SELECT id_project
+	err = tx.QueryRow(`
+		SELECT id_project, id_board
 		FROM columns
 		WHERE id = $1
 	`, task.ID_column,
-	).Scan(&idProject)
+	).Scan(&idProject, &idBoard)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	// a task can be moved to any board of its project, the status is then
+	// derived from the columns of the board it lands on
+	var newIdProject, newIdBoard int64
+
+	err = tx.QueryRow(`
+		SELECT id_project, id_board
+		FROM columns
+		WHERE id = $1 and archived_at IS NULL
+	`, newIdColumn,
+	).Scan(&newIdProject, &newIdBoard)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if newIdProject != idProject {
+		return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+	}
+
+	if newIdBoard != idBoard {
+		err = logging(tx, int(task.ID), "move to board "+strconv.FormatInt(newIdBoard, 10))
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	var newStatus string
 
+	// a board without in_progress or done columns can't take the task
 	var inProgressColumnID int
 
-	err = r.store.db.QueryRow(`
+	err = tx.QueryRow(`
 			SELECT id
 			FROM columns
-			WHERE id_project = $1
+			WHERE id_board = $1
 			and name = $2
-		`, idProject,
+			and archived_at IS NULL
+		`, newIdBoard,
 		inProgress,
 	).Scan(&inProgressColumnID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	var doneColumnID int
 
-	err = r.store.db.QueryRow(`
+	err = tx.QueryRow(`
 		SELECT id
 		FROM columns
-		WHERE id_project = $1
+		WHERE id_board = $1
 		and name = $2
-	`, idProject,
+		and archived_at IS NULL
+	`, newIdBoard,
 		done,
 	).Scan(&doneColumnID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -198,12 +530,6 @@ func (r *TaskRepository) UpdateTaskColumn(task *model.Task) error {
 	case newIdColumn == int64(inProgressColumnID):
 		newStatus = inProgress
 
-		slog.Info("NEW STATUS: ", newStatus, inProgressColumnID)
-
-		err = r.logging(int(task.ID), "switch status from "+todo+"to "+newStatus)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
 	case newIdColumn == int64(doneColumnID):
 		newStatus = done
 
@@ -212,25 +538,18 @@ func (r *TaskRepository) UpdateTaskColumn(task *model.Task) error {
 			Valid: true,
 		}
 
-		slog.Info("NEW STATUS: ", newStatus, doneColumnID)
-
-		err = r.logging(int(task.ID), "switch status from "+inProgress+"to "+newStatus)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
 	default:
-		newStatus = "todo"
+		newStatus = todo
 
 		task.Date_of_execution = sql.NullTime{Valid: false}
+	}
 
-		slog.Info("NEW STATUS: ", newStatus, newIdColumn)
-
-		err = r.logging(int(task.ID), "switch status from "+task.Status+"to "+newStatus)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
+	err = loggingStatus(tx, int(task.ID), oldStatus, newStatus)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
-	res, err := r.store.db.Exec(`UPDATE tasks 
+
+	res, err := tx.Exec(`UPDATE tasks 
 	SET status = $1, 
 	id_column = $2, 
 	date_of_execution = $3 
@@ -252,19 +571,58 @@ func (r *TaskRepository) UpdateTaskColumn(task *model.Task) error {
 		return storage.ErrTaskNotFound
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
-func (r *TaskRepository) logging(id_task int, info string) error {
+func (r *TaskRepository) GetDoneColumn(id int) (int64, int64, error) {
+
+	const op = "storage.postgresql.Task.GetDoneColumn"
+
+	var doneColumn, current int64
+
+	err := r.store.db.QueryRow(`
+		SELECT d.id, t.id_column
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN columns d ON d.id_board = c.id_board and d.name = $2 and d.archived_at IS NULL
+		WHERE t.id = $1 and t.archived_at IS NULL`,
+		id,
+		done,
+	).Scan(&doneColumn, &current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return doneColumn, current, nil
+}
+
+func logging(q querier, id_task int, info string) error {
+	return insertLog(q, id_task, info, sql.NullString{})
+}
+
+// loggingStatus logs the task moving from one status to another. The new
+// status is kept apart from the text, reports look the moves up by it.
+func loggingStatus(q querier, id_task int, from string, to string) error {
+	return insertLog(q, id_task, "switch status from "+from+"to "+to, sql.NullString{String: to, Valid: true})
+}
+
+func insertLog(q querier, id_task int, info string, status sql.NullString) error {
 
 	const op = "storage.postgres.Task.logging"
 
 	var id int
 
-	err := r.store.db.QueryRow(`
+	err := q.QueryRow(`
 		INSERT INTO logs 
-		(id_task,date_of_operation,info) 
-		VALUES($1,$2,$3) 
+		(id_task,date_of_operation,info,status) 
+		VALUES($1,$2,$3,$4) 
 		RETURNING id
 	`, id_task,
 		sql.NullTime{
@@ -272,6 +630,7 @@ func (r *TaskRepository) logging(id_task int, info string) error {
 			Valid: true,
 		},
 		info,
+		status,
 	).Scan(&id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -280,34 +639,63 @@ func (r *TaskRepository) logging(id_task int, info string) error {
 	return nil
 }
 
-func (r *TaskRepository) GetLogsTask(id_task int) ([]model.Task_log, error) {
+var logSorts = map[string]sortField{
+	"id": {expr: "id", sqlType: "bigint"},
+}
+
+func (r *TaskRepository) GetLogsTask(id_task int, page storage.Page) ([]model.Task_log, string, error) {
 
 	const op = "storage.Postgresql.Task.GetLogsTask"
 
-	var logs []model.Task_log
+	p, err := newPager(page, logSorts, "id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var (
+		logs []model.Task_log
+		keys []string
+	)
+
+	args := queryArgs{id_task}
 
-	rows, err := r.store.db.Query("SELECT * FROM logs WHERE id_task = $1", id_task)
+	rows, err := r.store.db.Query(
+		"SELECT id, id_task, date_of_operation, info, status, "+p.sortKey()+" FROM logs WHERE id_task = $1 and "+
+			p.keyset("id", &args)+" "+p.orderLimit("id", &args),
+		args...,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("%s; %w", op, err)
+		return nil, "", fmt.Errorf("%s; %w", op, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var l model.Task_log
+		var (
+			l   model.Task_log
+			key string
+		)
 		if err = rows.Scan(
 			&l.ID,
 			&l.ID_Task,
 			&l.Date_of_operation,
 			&l.Info,
+			&l.Status,
+			&key,
 		); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, "", fmt.Errorf("%s: %w", op, err)
 		}
 		logs = append(logs, l)
+		keys = append(keys, key)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	logs, next, err := pageOf(p, logs, keys, func(l model.Task_log) int64 { return l.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return logs, nil
+	return logs, next, nil
 }
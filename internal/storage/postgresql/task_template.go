@@ -0,0 +1,428 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type TaskTemplateRepository struct {
+	store *Storage
+}
+
+const taskTemplateColumns = `
+	tt.id, tt.id_project, tt.id_column, tt.id_creator, tt.name, tt.description, tt.priority,
+	tt.id_executor, tt.id_swimlane, tt.estimate, tt.rule, tt.starts_at, tt.next_at, tt.created_count, tt.failures, tt.retry_at`
+
+// CreateTaskTemplate saves the template in the project of its column, the
+// column has to be live and the swimlane and executor have to exist.
+func (r *TaskTemplateRepository) CreateTaskTemplate(template *model.TaskTemplate) error {
+
+	const op = "storage.postgresql.task_template.CreateTaskTemplate"
+
+	err := r.store.db.QueryRow(
+		"SELECT id_project FROM columns WHERE id = $1 and archived_at IS NULL",
+		template.ID_column,
+	).Scan(&template.ID_project)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if template.ID_swimlane.Valid {
+		if err := checkSwimlane(r.store.db, template.ID_swimlane.Int64, template.ID_column.Int64); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if template.ID_executor.Valid {
+		var exists bool
+		err := r.store.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", template.ID_executor).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+	}
+
+	err = r.store.db.QueryRow(`
+		INSERT INTO task_templates (id_project, id_column, id_creator, name, description, priority,
+			id_executor, id_swimlane, estimate, rule, starts_at, next_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`,
+		template.ID_project,
+		template.ID_column,
+		template.ID_creator,
+		template.Name,
+		template.Description,
+		template.Priority,
+		template.ID_executor,
+		template.ID_swimlane,
+		template.Estimate,
+		template.Rule,
+		template.Starts_at,
+		template.Next_at,
+	).Scan(&template.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *TaskTemplateRepository) GetTaskTemplates(projectID int) ([]model.TaskTemplate, error) {
+
+	const op = "storage.postgresql.task_template.GetTaskTemplates"
+
+	templates, err := selectTaskTemplates(r.store.db,
+		"SELECT "+taskTemplateColumns+" FROM task_templates tt WHERE tt.id_project = $1 ORDER BY tt.name, tt.id",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+func (r *TaskTemplateRepository) GetTaskTemplate(id int) (*model.TaskTemplate, error) {
+
+	const op = "storage.postgresql.task_template.GetTaskTemplate"
+
+	template, err := scanTaskTemplate(r.store.db.QueryRow(
+		"SELECT "+taskTemplateColumns+" FROM task_templates tt WHERE tt.id = $1",
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTaskTemplateNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return template, nil
+}
+
+func (r *TaskTemplateRepository) SetColumn(id int, columnID int64) error {
+
+	const op = "storage.postgresql.task_template.SetColumn"
+
+	res, err := r.store.db.Exec(`
+		UPDATE task_templates tt SET id_column = c.id, retry_at = NULL
+		FROM columns c
+		WHERE tt.id = $1 and c.id = $2 and c.id_project = tt.id_project and c.archived_at IS NULL`,
+		id,
+		columnID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+	}
+
+	return nil
+}
+
+func (r *TaskTemplateRepository) DeleteTaskTemplate(id int) error {
+
+	const op = "storage.postgresql.task_template.DeleteTaskTemplate"
+
+	res, err := r.store.db.Exec("DELETE FROM task_templates WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTaskTemplateNotFound)
+	}
+
+	return nil
+}
+
+var instanceSorts = map[string]sortField{
+	"occurrence": {expr: "i.occurrence", sqlType: "int"},
+}
+
+func (r *TaskTemplateRepository) GetInstances(id int, page storage.Page) ([]model.TaskInstance, string, error) {
+
+	const op = "storage.postgresql.task_template.GetInstances"
+
+	p, err := newPager(page, instanceSorts, "-occurrence")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{id}
+
+	rows, err := r.store.db.Query(`
+		SELECT i.occurrence, i.scheduled_at, i.id_task, i.id_previous, t.name, t.status, `+p.sortKey()+`
+		FROM task_instances i
+		LEFT JOIN tasks t ON t.id = i.id_task
+		WHERE i.id_template = $1 and `+p.keyset("i.occurrence", &args)+" "+p.orderLimit("i.occurrence", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		instances []model.TaskInstance
+		keys      []string
+	)
+
+	for rows.Next() {
+		var (
+			i   model.TaskInstance
+			key string
+		)
+		if err := rows.Scan(
+			&i.Occurrence,
+			&i.Scheduled_at,
+			&i.ID_task,
+			&i.ID_previous,
+			&i.Name,
+			&i.Status,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		instances = append(instances, i)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	instances, next, err := pageOf(p, instances, keys, func(i model.TaskInstance) int64 { return int64(i.Occurrence) })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return instances, next, nil
+}
+
+// GetDue skips templates on archived columns and projects, they are picked
+// up again, at most once for all the repeats they missed, when restored.
+// Templates waiting to retry a failed repeat are skipped until retry_at.
+func (r *TaskTemplateRepository) GetDue(now time.Time, limit int) ([]model.TaskTemplate, error) {
+
+	const op = "storage.postgresql.task_template.GetDue"
+
+	templates, err := selectTaskTemplates(r.store.db, `
+		SELECT `+taskTemplateColumns+`
+		FROM task_templates tt
+		JOIN columns c ON c.id = tt.id_column
+		JOIN projects p ON p.id = tt.id_project
+		WHERE tt.next_at <= $1 and (tt.retry_at IS NULL or tt.retry_at <= $1)
+			and c.archived_at IS NULL and p.archived_at IS NULL
+		ORDER BY tt.next_at, tt.id
+		LIMIT $2`,
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+func (r *TaskTemplateRepository) Spawn(template *model.TaskTemplate, task *model.Task, next sql.NullTime) error {
+
+	const op = "storage.postgresql.task_template.Spawn"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// The template only moves on from the repeat it was read at, so of two
+	// instances spawning the same repeat one gets no row back.
+	var occurrence int
+
+	err = tx.QueryRow(`
+		UPDATE task_templates SET next_at = $2, created_count = created_count + 1, failures = 0, retry_at = NULL
+		WHERE id = $1 and next_at = $3
+		RETURNING created_count`,
+		template.ID,
+		next,
+		template.Next_at,
+	).Scan(&occurrence)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskTemplateNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := reserveColumnSlot(tx, task.ID_column); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	task.Status = todo
+
+	err = tx.QueryRow(`
+		INSERT INTO tasks (id_column, name, description, id_creator, status, date_of_create, priority,
+			id_executor, id_swimlane, estimate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		task.ID_column,
+		task.Name,
+		task.Description,
+		task.ID_creator,
+		task.Status,
+		task.Date_of_create,
+		task.Priority,
+		task.ID_executor,
+		task.ID_swimlane,
+		task.Estimate,
+	).Scan(&task.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var previous sql.NullInt64
+
+	err = tx.QueryRow(
+		"SELECT id_task FROM task_instances WHERE id_template = $1 ORDER BY occurrence DESC LIMIT 1",
+		template.ID,
+	).Scan(&previous)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO task_instances (id_template, occurrence, id_task, id_previous, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		template.ID,
+		occurrence,
+		task.ID,
+		previous,
+		template.Next_at,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	info := "repeat " + strconv.Itoa(occurrence) + " of task template " + strconv.FormatInt(template.ID, 10)
+	if previous.Valid {
+		info += ", previous task " + strconv.FormatInt(previous.Int64, 10)
+	}
+
+	for _, info := range []string{"create task", info} {
+		if err := logging(tx, int(task.ID), info); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	template.Next_at = next
+	template.Created_count = occurrence
+	template.Failures = 0
+	template.Retry_at = sql.NullTime{}
+
+	return nil
+}
+
+func (r *TaskTemplateRepository) Postpone(template *model.TaskTemplate, retryAt time.Time) error {
+
+	const op = "storage.postgresql.task_template.Postpone"
+
+	err := r.store.db.QueryRow(`
+		UPDATE task_templates SET failures = failures + 1, retry_at = $2
+		WHERE id = $1 and next_at = $3
+		RETURNING failures`,
+		template.ID,
+		retryAt,
+		template.Next_at,
+	).Scan(&template.Failures)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskTemplateNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	template.Retry_at = sql.NullTime{Time: retryAt, Valid: true}
+
+	return nil
+}
+
+func selectTaskTemplates(q querier, query string, args ...any) ([]model.TaskTemplate, error) {
+
+	const op = "storage.postgresql.task_template.selectTaskTemplates"
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var templates []model.TaskTemplate
+
+	for rows.Next() {
+		t, err := scanTaskTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		templates = append(templates, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+func scanTaskTemplate(row interface{ Scan(...any) error }) (*model.TaskTemplate, error) {
+
+	var t model.TaskTemplate
+
+	err := row.Scan(
+		&t.ID,
+		&t.ID_project,
+		&t.ID_column,
+		&t.ID_creator,
+		&t.Name,
+		&t.Description,
+		&t.Priority,
+		&t.ID_executor,
+		&t.ID_swimlane,
+		&t.Estimate,
+		&t.Rule,
+		&t.Starts_at,
+		&t.Next_at,
+		&t.Created_count,
+		&t.Failures,
+		&t.Retry_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
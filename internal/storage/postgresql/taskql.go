@@ -0,0 +1,93 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wehw93/kanban-board/internal/taskql"
+)
+
+// priorityOrder gives priorities their rank for <, <=, > and >= comparisons.
+const priorityOrder = "ARRAY['low', 'medium', 'high', 'urgent']"
+
+// compileTaskQuery turns the parsed query into a condition over tasks t
+// joined with columns c. Values are passed through arg, so the condition
+// only ever contains placeholders. userID stands in for "me".
+func compileTaskQuery(q taskql.Query, userID int, arg func(any) string) (string, error) {
+
+	conds := make([]string, 0, len(q.Terms))
+
+	for _, term := range q.Terms {
+		cond, err := compileTerm(term, userID, arg)
+		if err != nil {
+			return "", err
+		}
+
+		if term.Negate {
+			// IS NOT TRUE also keeps the rows where cond is NULL
+			cond = "(" + cond + ") IS NOT TRUE"
+		}
+
+		conds = append(conds, cond)
+	}
+
+	return strings.Join(conds, " and "), nil
+}
+
+func compileTerm(term taskql.Term, userID int, arg func(any) string) (string, error) {
+
+	op := string(term.Op)
+	if term.Op == taskql.OpEq {
+		op = "="
+	}
+
+	switch term.Field {
+	case taskql.FieldText:
+		return "t.search @@ plainto_tsquery('simple', " + arg(term.Text) + ")", nil
+	case taskql.FieldStatus:
+		return "t.status = " + arg(term.Text), nil
+	case taskql.FieldPriority:
+		if term.Op == taskql.OpEq {
+			return "t.priority = " + arg(term.Text), nil
+		}
+		return "array_position(" + priorityOrder + ", t.priority) " + op +
+			" array_position(" + priorityOrder + ", " + arg(term.Text) + "::text)", nil
+	case taskql.FieldExecutor:
+		return compileID("t.id_executor", term, userID, arg), nil
+	case taskql.FieldCreator:
+		return compileID("t.id_creator", term, userID, arg), nil
+	case taskql.FieldLabel:
+		return `EXISTS (
+			SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.id_label
+			WHERE tl.id_task = t.id and l.name = ` + arg(term.Text) + ")", nil
+	case taskql.FieldProject:
+		return compileID("c.id_project", term, userID, arg), nil
+	case taskql.FieldBoard:
+		return compileID("c.id_board", term, userID, arg), nil
+	case taskql.FieldColumn:
+		return compileID("t.id_column", term, userID, arg), nil
+	case taskql.FieldLane:
+		return compileID("t.id_swimlane", term, userID, arg), nil
+	case taskql.FieldCreated:
+		return "t.date_of_create " + op + " " + arg(term.Date) + "::date", nil
+	case taskql.FieldDue:
+		if term.Kind == taskql.KindNone {
+			return "t.due_date IS NULL", nil
+		}
+		return "t.due_date " + op + " " + arg(term.Date) + "::date", nil
+	}
+
+	return "", fmt.Errorf("unsupported field %q", term.Field)
+}
+
+func compileID(column string, term taskql.Term, userID int, arg func(any) string) string {
+
+	switch term.Kind {
+	case taskql.KindNone:
+		return column + " IS NULL"
+	case taskql.KindMe:
+		return column + " = " + arg(userID)
+	}
+
+	return column + " = " + arg(term.Int)
+}
@@ -0,0 +1,89 @@
+package postgresql
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/taskql"
+)
+
+func TestCompileTaskQuery(t *testing.T) {
+
+	day := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+		args  []any
+	}{
+		{
+			name:  "due on a day",
+			query: "due:2026-03-15",
+			want:  "t.due_date = $1::date",
+			args:  []any{day},
+		},
+		{
+			name:  "due before a day",
+			query: "due:<2026-03-15",
+			want:  "t.due_date < $1::date",
+			args:  []any{day},
+		},
+		{
+			name:  "due on or after a day",
+			query: "due:>=2026-03-15",
+			want:  "t.due_date >= $1::date",
+			args:  []any{day},
+		},
+		{
+			name:  "no due date",
+			query: "due:none",
+			want:  "t.due_date IS NULL",
+		},
+		{
+			name:  "any due date",
+			query: "-due:none",
+			want:  "(t.due_date IS NULL) IS NOT TRUE",
+		},
+		{
+			name:  "created is the creation date",
+			query: "created:<2026-03-15",
+			want:  "t.date_of_create < $1::date",
+			args:  []any{day},
+		},
+		{
+			name:  "terms are joined",
+			query: "status:done executor:me due:<=2026-03-15",
+			want:  "t.status = $1 and t.id_executor = $2 and t.due_date <= $3::date",
+			args:  []any{"done", 7, day},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := taskql.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+
+			var args []any
+			arg := func(v any) string {
+				args = append(args, v)
+				return "$" + strconv.Itoa(len(args))
+			}
+
+			got, err := compileTaskQuery(q, 7, arg)
+			if err != nil {
+				t.Fatalf("compileTaskQuery(%q) error = %v", tt.query, err)
+			}
+			if got != tt.want {
+				t.Errorf("compileTaskQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("compileTaskQuery(%q) args = %v, want %v", tt.query, args, tt.args)
+			}
+		})
+	}
+}
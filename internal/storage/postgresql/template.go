@@ -0,0 +1,133 @@
+package postgresql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type TemplateRepository struct {
+	store *Storage
+}
+
+func (r *TemplateRepository) CreateTemplate(template *model.Template) error {
+
+	const op = "storage.postgresql.template.CreateTemplate"
+
+	columns, err := json.Marshal(template.Columns)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = r.store.db.QueryRow(
+		"INSERT INTO project_templates (id_user, name, columns, labels) VALUES ($1, $2, $3, $4) RETURNING id",
+		template.ID_user,
+		template.Name,
+		columns,
+		pq.Array(template.Labels),
+	).Scan(&template.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTemplateExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *TemplateRepository) GetTemplates(userID int) ([]model.Template, error) {
+
+	const op = "storage.postgresql.template.GetTemplates"
+
+	rows, err := r.store.db.Query(
+		"SELECT id, id_user, name, columns, labels FROM project_templates WHERE id_user = $1 ORDER BY name",
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var templates []model.Template
+
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		templates = append(templates, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+func (r *TemplateRepository) GetTemplate(userID int, name string) (*model.Template, error) {
+
+	const op = "storage.postgresql.template.GetTemplate"
+
+	t, err := scanTemplate(r.store.db.QueryRow(
+		"SELECT id, id_user, name, columns, labels FROM project_templates WHERE id_user = $1 and name = $2",
+		userID,
+		name,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTemplateNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return t, nil
+}
+
+func (r *TemplateRepository) DeleteTemplate(userID int, id int) error {
+
+	const op = "storage.postgresql.template.DeleteTemplate"
+
+	res, err := r.store.db.Exec("DELETE FROM project_templates WHERE id = $1 and id_user = $2", id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTemplateNotFound)
+	}
+
+	return nil
+}
+
+func scanTemplate(row interface{ Scan(...any) error }) (*model.Template, error) {
+
+	var (
+		t       model.Template
+		columns []byte
+	)
+
+	if err := row.Scan(&t.ID, &t.ID_user, &t.Name, &columns, pq.Array(&t.Labels)); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(columns, &t.Columns); err != nil {
+		return nil, err
+	}
+
+	if t.Labels == nil {
+		t.Labels = []string{}
+	}
+
+	return &t, nil
+}
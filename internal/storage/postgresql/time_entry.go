@@ -0,0 +1,251 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type TimeEntryRepository struct {
+	store *Storage
+}
+
+// entrySeconds is the duration of an entry, running timers count up to now.
+const entrySeconds = "extract(epoch FROM coalesce(ended_at, now()) - started_at)::bigint"
+
+const timeEntryColumns = "id, id_task, id_user, started_at, ended_at, " + entrySeconds + ", note"
+
+// timeSpent is the select expression summing the time tracked on the task
+// with the given id.
+func timeSpent(taskID string) string {
+	return "(SELECT coalesce(sum(" + entrySeconds + "), 0)::bigint FROM time_entries WHERE id_task = " + taskID + ")"
+}
+
+func (r *TimeEntryRepository) CreateEntry(entry *model.TimeEntry) error {
+
+	const op = "storage.postgresql.time_entry.CreateEntry"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO time_entries (id_task, id_user, started_at, ended_at, note)
+		SELECT $1, $2, $3, $4, $5
+		WHERE EXISTS (SELECT 1 FROM tasks WHERE id = $1 and archived_at IS NULL)
+		RETURNING id, `+entrySeconds,
+		entry.ID_task,
+		entry.ID_user,
+		entry.Started_at,
+		entry.Ended_at,
+		entry.Note,
+	).Scan(&entry.ID, &entry.Duration)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *TimeEntryRepository) StartTimer(entry *model.TimeEntry) error {
+
+	const op = "storage.postgresql.time_entry.StartTimer"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO time_entries (id_task, id_user, started_at, note)
+		SELECT $1, $2, now(), $3
+		WHERE EXISTS (SELECT 1 FROM tasks WHERE id = $1 and archived_at IS NULL)
+		RETURNING id, started_at`,
+		entry.ID_task,
+		entry.ID_user,
+		entry.Note,
+	).Scan(&entry.ID, &entry.Started_at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+		}
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTimerRunning)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *TimeEntryRepository) StopTimer(userID int, note string) (*model.TimeEntry, error) {
+
+	const op = "storage.postgresql.time_entry.StopTimer"
+
+	entry, err := scanTimeEntry(r.store.db.QueryRow(`
+		UPDATE time_entries SET ended_at = greatest(now(), started_at), note = coalesce(nullif($2, ''), note)
+		WHERE id_user = $1 and ended_at IS NULL
+		RETURNING `+timeEntryColumns,
+		userID,
+		note,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTimerNotRunning)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (r *TimeEntryRepository) GetTimer(userID int) (*model.TimeEntry, error) {
+
+	const op = "storage.postgresql.time_entry.GetTimer"
+
+	entry, err := scanTimeEntry(r.store.db.QueryRow(
+		"SELECT "+timeEntryColumns+" FROM time_entries WHERE id_user = $1 and ended_at IS NULL",
+		userID,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTimerNotRunning)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+var timeEntrySorts = map[string]sortField{
+	"started_at": {expr: "started_at", sqlType: "timestamptz"},
+	"id":         {expr: "id", sqlType: "bigint"},
+}
+
+func (r *TimeEntryRepository) GetEntries(taskID int, page storage.Page) ([]model.TimeEntry, string, error) {
+
+	const op = "storage.postgresql.time_entry.GetEntries"
+
+	p, err := newPager(page, timeEntrySorts, "-started_at")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{taskID}
+
+	rows, err := r.store.db.Query(
+		"SELECT "+timeEntryColumns+", "+p.sortKey()+" FROM time_entries WHERE id_task = $1 and "+
+			p.keyset("id", &args)+" "+p.orderLimit("id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		entries []model.TimeEntry
+		keys    []string
+	)
+
+	for rows.Next() {
+		var (
+			e   model.TimeEntry
+			key string
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.ID_task,
+			&e.ID_user,
+			&e.Started_at,
+			&e.Ended_at,
+			&e.Duration,
+			&e.Note,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, e)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, next, err := pageOf(p, entries, keys, func(e model.TimeEntry) int64 { return e.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, next, nil
+}
+
+func (r *TimeEntryRepository) DeleteEntry(userID int, id int) error {
+
+	const op = "storage.postgresql.time_entry.DeleteEntry"
+
+	res, err := r.store.db.Exec("DELETE FROM time_entries WHERE id = $1 and id_user = $2", id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTimeEntryNotFound)
+	}
+
+	return nil
+}
+
+// selectTimeEntries reads the entries of a task for its trash snapshot, a
+// running timer is stopped at now as the task goes away.
+func selectTimeEntries(q querier, taskID int64) ([]model.TimeEntry, error) {
+
+	const op = "storage.postgresql.time_entry.selectTimeEntries"
+
+	rows, err := q.Query(`
+		SELECT id, id_task, id_user, started_at, coalesce(ended_at, greatest(now(), started_at)), `+entrySeconds+`, note
+		FROM time_entries WHERE id_task = $1 ORDER BY id`,
+		taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []model.TimeEntry
+
+	for rows.Next() {
+		e, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func scanTimeEntry(row interface{ Scan(...any) error }) (*model.TimeEntry, error) {
+
+	var e model.TimeEntry
+
+	err := row.Scan(
+		&e.ID,
+		&e.ID_task,
+		&e.ID_user,
+		&e.Started_at,
+		&e.Ended_at,
+		&e.Duration,
+		&e.Note,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
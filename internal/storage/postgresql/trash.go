@@ -0,0 +1,636 @@
+package postgresql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type TrashRepository struct {
+	store *Storage
+}
+
+const taskSnapshotColumns = `id, id_column, name, description, date_of_create, date_of_execution,
+	id_executor, id_creator, status, priority, id_swimlane, archived_at, due_date, estimate`
+
+// TrashColumn deletes the column with all of its tasks and keeps them in the
+// trash of the project. Archived tasks of the column are captured as well.
+func (r *TrashRepository) TrashColumn(userID int, id int) (*model.TrashItem, error) {
+
+	const op = "storage.postgresql.trash.TrashColumn"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var snapshot model.ColumnSnapshot
+
+	err = tx.QueryRow(
+		"SELECT id, name, id_project, id_board, wip_limit, archived_at FROM columns WHERE id = $1 FOR UPDATE",
+		id,
+	).Scan(
+		&snapshot.Column.ID,
+		&snapshot.Column.Name,
+		&snapshot.Column.ID_project,
+		&snapshot.Column.ID_board,
+		&snapshot.Column.WIP_limit,
+		&snapshot.Column.Archived_at,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, err := selectTasks(tx, "SELECT "+taskSnapshotColumns+" FROM tasks WHERE id_column = $1 ORDER BY id", id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, t := range tasks {
+		ts, err := snapshotTask(tx, t)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		snapshot.Tasks = append(snapshot.Tasks, ts)
+	}
+
+	item := &model.TrashItem{
+		ID_project: snapshot.Column.ID_project,
+		Kind:       model.TrashKindColumn,
+		Name:       snapshot.Column.Name,
+		ID_user:    sql.NullInt64{Int64: int64(userID), Valid: true},
+	}
+
+	if err := insertTrashItem(tx, item, snapshot); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM columns WHERE id = $1", id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return item, nil
+}
+
+// TrashTask deletes the task created by the user and keeps it in the trash
+// of the project.
+func (r *TrashRepository) TrashTask(userID int, id int) (*model.TrashItem, error) {
+
+	const op = "storage.postgresql.trash.TrashTask"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	tasks, err := selectTasks(tx,
+		"SELECT "+taskSnapshotColumns+" FROM tasks WHERE id = $1 and id_creator = $2 FOR UPDATE",
+		id,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrTaskNotFound)
+	}
+
+	snapshot, err := snapshotTask(tx, tasks[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	item := &model.TrashItem{
+		Kind:    model.TrashKindTask,
+		Name:    snapshot.Task.Name,
+		ID_user: sql.NullInt64{Int64: int64(userID), Valid: true},
+	}
+
+	err = tx.QueryRow("SELECT id_project FROM columns WHERE id = $1", snapshot.Task.ID_column).Scan(&item.ID_project)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertTrashItem(tx, item, snapshot); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM tasks WHERE id = $1", id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return item, nil
+}
+
+var trashSorts = map[string]sortField{
+	"deleted": {expr: "deleted_at", sqlType: "timestamptz"},
+	"id":      {expr: "id", sqlType: "bigint"},
+}
+
+// GetProjectID returns the project of the trash item, restorable or not.
+func (r *TrashRepository) GetProjectID(id int) (int64, error) {
+
+	const op = "storage.postgresql.trash.GetProjectID"
+
+	var projectID int64
+
+	err := r.store.db.QueryRow("SELECT id_project FROM trash WHERE id = $1", id).Scan(&projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTrashItemNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projectID, nil
+}
+
+func (r *TrashRepository) GetItems(projectID int, since time.Time, page storage.Page) ([]model.TrashItem, string, error) {
+
+	const op = "storage.postgresql.trash.GetItems"
+
+	p, err := newPager(page, trashSorts, "-deleted")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{projectID, since}
+
+	rows, err := r.store.db.Query(`
+		SELECT id, id_project, kind, name, id_user, snapshot, deleted_at, `+p.sortKey()+`
+		FROM trash
+		WHERE id_project = $1 and deleted_at >= $2 and `+p.keyset("id", &args)+`
+		`+p.orderLimit("id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		items []model.TrashItem
+		keys  []string
+	)
+
+	for rows.Next() {
+		var (
+			item model.TrashItem
+			key  string
+		)
+		if err := rows.Scan(
+			&item.ID,
+			&item.ID_project,
+			&item.Kind,
+			&item.Name,
+			&item.ID_user,
+			&item.Snapshot,
+			&item.Deleted_at,
+			&key,
+		); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		items = append(items, item)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	items, next, err := pageOf(p, items, keys, func(item model.TrashItem) int64 { return item.ID })
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, next, nil
+}
+
+// Restore puts a trash item deleted after since back on the board with its
+// original IDs. A task goes back to its original column, or to the fallback
+// column of the same project when the original one is gone; a fallback of 0
+// means there is none. A column needs its board to still exist. Tasks whose
+// creator was deleted since are restored as created by the user. The item
+// is returned as it was in the trash.
+func (r *TrashRepository) Restore(userID int, id int, fallbackColumnID int64, since time.Time) (*model.TrashItem, error) {
+
+	const op = "storage.postgresql.trash.Restore"
+
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	item := model.TrashItem{ID: int64(id)}
+
+	err = tx.QueryRow(
+		"SELECT id_project, kind, name, id_user, snapshot, deleted_at FROM trash WHERE id = $1 and deleted_at >= $2 FOR UPDATE",
+		id,
+		since,
+	).Scan(&item.ID_project, &item.Kind, &item.Name, &item.ID_user, &item.Snapshot, &item.Deleted_at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTrashItemNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch item.Kind {
+	case model.TrashKindColumn:
+		err = restoreColumn(tx, item, int64(userID))
+	case model.TrashKindTask:
+		err = restoreSingleTask(tx, item, fallbackColumnID, int64(userID))
+	default:
+		err = fmt.Errorf("unknown trash kind %q", item.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM trash WHERE id = $1", id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &item, nil
+}
+
+func (r *TrashRepository) Purge(before time.Time) (int64, error) {
+
+	const op = "storage.postgresql.trash.Purge"
+
+	res, err := r.store.db.Exec("DELETE FROM trash WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rowsAffected, nil
+}
+
+func insertTrashItem(q querier, item *model.TrashItem, snapshot any) error {
+
+	const op = "storage.postgresql.trash.insertTrashItem"
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	item.Snapshot = data
+
+	err = q.QueryRow(`
+		INSERT INTO trash (id_project, kind, name, id_user, snapshot)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, deleted_at`,
+		item.ID_project,
+		item.Kind,
+		item.Name,
+		item.ID_user,
+		[]byte(data),
+	).Scan(&item.ID, &item.Deleted_at)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// selectTasks reads every row before returning, so the caller is free to run
+// more queries on the same transaction.
+func selectTasks(q querier, query string, args ...any) ([]model.Task, error) {
+
+	const op = "storage.postgresql.trash.selectTasks"
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []model.Task
+
+	for rows.Next() {
+		var t model.Task
+		if err := rows.Scan(
+			&t.ID,
+			&t.ID_column,
+			&t.Name,
+			&t.Description,
+			&t.Date_of_create,
+			&t.Date_of_execution,
+			&t.ID_executor,
+			&t.ID_creator,
+			&t.Status,
+			&t.Priority,
+			&t.ID_swimlane,
+			&t.Archived_at,
+			&t.Due_date,
+			&t.Estimate,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+func snapshotTask(q querier, task model.Task) (model.TaskSnapshot, error) {
+
+	const op = "storage.postgresql.trash.snapshotTask"
+
+	snapshot := model.TaskSnapshot{Task: task}
+
+	rows, err := q.Query("SELECT id, id_task, date_of_operation, info, status FROM logs WHERE id_task = $1 ORDER BY id", task.ID)
+	if err != nil {
+		return snapshot, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for rows.Next() {
+		var l model.Task_log
+		if err := rows.Scan(&l.ID, &l.ID_Task, &l.Date_of_operation, &l.Info, &l.Status); err != nil {
+			rows.Close()
+			return snapshot, fmt.Errorf("%s: %w", op, err)
+		}
+		snapshot.Logs = append(snapshot.Logs, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return snapshot, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = q.Query("SELECT id_label FROM task_labels WHERE id_task = $1", task.ID)
+	if err != nil {
+		return snapshot, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var labelID int64
+		if err := rows.Scan(&labelID); err != nil {
+			return snapshot, fmt.Errorf("%s: %w", op, err)
+		}
+		snapshot.Labels = append(snapshot.Labels, labelID)
+	}
+	if err := rows.Err(); err != nil {
+		return snapshot, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// running timers are kept stopped at the time of deletion
+	snapshot.Time_entries, err = selectTimeEntries(q, task.ID)
+	if err != nil {
+		return snapshot, fmt.Errorf("%s: %w", op, err)
+	}
+
+	snapshot.Comments, err = selectComments(q, task.ID)
+	if err != nil {
+		return snapshot, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return snapshot, nil
+}
+
+func restoreColumn(tx *sql.Tx, item model.TrashItem, userID int64) error {
+
+	const op = "storage.postgresql.trash.restoreColumn"
+
+	var snapshot model.ColumnSnapshot
+
+	if err := json.Unmarshal(item.Snapshot, &snapshot); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	c := snapshot.Column
+
+	res, err := tx.Exec(`
+		INSERT INTO columns (id, name, id_board, id_project, wip_limit, archived_at)
+		SELECT $1, $2, id, id_project, $4, $5 FROM boards WHERE id = $3`,
+		c.ID,
+		c.Name,
+		c.ID_board,
+		c.WIP_limit,
+		c.Archived_at,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrBoardNotFound)
+	}
+
+	for _, ts := range snapshot.Tasks {
+		if err := restoreTask(tx, ts, c.ID, item.ID_project, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func restoreSingleTask(tx *sql.Tx, item model.TrashItem, fallbackColumnID int64, userID int64) error {
+
+	const op = "storage.postgresql.trash.restoreSingleTask"
+
+	var snapshot model.TaskSnapshot
+
+	if err := json.Unmarshal(item.Snapshot, &snapshot); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	columnID := snapshot.Task.ID_column
+
+	exists, err := columnInProject(tx, columnID, item.ID_project)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !exists {
+		if fallbackColumnID == 0 {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+
+		exists, err = columnInProject(tx, fallbackColumnID, item.ID_project)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%s: %w", op, storage.ErrColumnNotFound)
+		}
+
+		columnID = fallbackColumnID
+	}
+
+	if !snapshot.Task.Archived_at.Valid {
+		if err := reserveColumnSlot(tx, columnID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := restoreTask(tx, snapshot, columnID, item.ID_project, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func columnInProject(q querier, columnID int64, projectID int64) (bool, error) {
+
+	const op = "storage.postgresql.trash.columnInProject"
+
+	var ok bool
+
+	err := q.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM columns WHERE id = $1 and id_project = $2 and archived_at IS NULL)",
+		columnID,
+		projectID,
+	).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ok, nil
+}
+
+// restoreTask inserts the task into the column together with its logs and
+// labels. The swimlane, labels and executor that were deleted in the
+// meantime are dropped, a deleted creator is replaced with userID.
+func restoreTask(q querier, snapshot model.TaskSnapshot, columnID int64, projectID int64, userID int64) error {
+
+	const op = "storage.postgresql.trash.restoreTask"
+
+	t := snapshot.Task
+
+	_, err := q.Exec(`
+		INSERT INTO tasks (id, id_column, name, description, date_of_create, date_of_execution,
+		id_executor, id_creator, status, priority, id_swimlane, archived_at, due_date, estimate)
+		VALUES ($1, $2, $3, $4, $5, $6,
+		(SELECT id FROM users WHERE id = $7), coalesce((SELECT id FROM users WHERE id = $8), $16), $9, $10,
+		(SELECT id FROM swimlanes WHERE id = $11 and id_project = $12), $13, $14, $15)`,
+		t.ID,
+		columnID,
+		t.Name,
+		t.Description,
+		t.Date_of_create,
+		t.Date_of_execution,
+		t.ID_executor,
+		t.ID_creator,
+		t.Status,
+		t.Priority,
+		t.ID_swimlane,
+		projectID,
+		t.Archived_at,
+		t.Due_date,
+		t.Estimate,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, l := range snapshot.Logs {
+		_, err := q.Exec(
+			"INSERT INTO logs (id, id_task, date_of_operation, info, status) VALUES ($1, $2, $3, $4, $5)",
+			l.ID,
+			t.ID,
+			l.Date_of_operation,
+			l.Info,
+			l.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if len(snapshot.Labels) > 0 {
+		_, err := q.Exec(`
+			INSERT INTO task_labels (id_task, id_label)
+			SELECT $1, id FROM labels WHERE id = ANY($2) and id_project = $3`,
+			t.ID,
+			pq.Array(snapshot.Labels),
+			projectID,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Entries of users deleted since are left out. Timers running when the
+	// task was trashed were stopped in the snapshot, one still running here
+	// would clash with the one the user may have started since.
+	for _, e := range snapshot.Time_entries {
+		_, err := q.Exec(`
+			INSERT INTO time_entries (id, id_task, id_user, started_at, ended_at, note)
+			SELECT $1, $2, $3, $4::timestamptz, coalesce($5::timestamptz, greatest(now(), $4::timestamptz)), $6
+			WHERE EXISTS (SELECT 1 FROM users WHERE id = $3)`,
+			e.ID,
+			t.ID,
+			e.ID_user,
+			e.Started_at,
+			e.Ended_at,
+			e.Note,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// comments of authors deleted since are kept without an author
+	for _, c := range snapshot.Comments {
+		_, err := q.Exec(`
+			INSERT INTO comments (id, id_task, id_author, body, created_at)
+			VALUES ($1, $2, (SELECT id FROM users WHERE id = $3), $4, $5)`,
+			c.ID,
+			t.ID,
+			c.ID_author,
+			c.Body,
+			c.Created_at,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := logging(q, int(t.ID), "restore task from trash"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
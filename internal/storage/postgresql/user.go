@@ -104,14 +104,35 @@ func (r *UserRepository) GetByID(userID int) (model.User, error) {
 	return user, nil
 }
 
+// GetByEmail finds the user by email, ignoring the case.
+func (r *UserRepository) GetByEmail(email string) (model.User, error) {
+
+	const op = "storage.postgresql.user.GetByEmail"
+
+	var user model.User
+
+	err := r.store.db.QueryRow(
+		"SELECT id, name, email FROM users WHERE lower(email) = lower($1)",
+		email,
+	).Scan(&user.ID, &user.Name, &user.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.User{}, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return model.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
 func (r *UserRepository) GetProjects(userID int) ([]model.Project, error) {
 
 	const op = "storage.postgresql.user.get_projects"
 
 	rows, err := r.store.db.Query(`
-		SELECT id, name, description 
+		SELECT id, name, slug, description 
 		FROM projects 
-		WHERE id_creator = $1`,
+		WHERE id_creator = $1 and archived_at IS NULL`,
 		userID,
 	)
 	if err != nil {
@@ -126,6 +147,7 @@ func (r *UserRepository) GetProjects(userID int) ([]model.Project, error) {
 		if err := rows.Scan(
 			&p.ID,
 			&p.Name,
+			&p.Slug,
 			&p.Description,
 		); err != nil {
 			return nil, fmt.Errorf("%s: scan error: %w", op, err)
@@ -145,7 +167,12 @@ func (r *UserRepository) GetTasks(userID int) ([]model.Task, error) {
 	const op = "storage.postgresql.user.get_tasks"
 
 	rows, err := r.store.db.Query(
-		"SELECT id, name, description, status FROM tasks WHERE id_executor = $1",
+		`SELECT t.id, t.name, t.description, t.status
+		FROM tasks t
+		JOIN columns c ON c.id = t.id_column
+		JOIN projects p ON p.id = c.id_project
+		WHERE t.id_executor = $1
+		and t.archived_at IS NULL and c.archived_at IS NULL and p.archived_at IS NULL`,
 		userID,
 	)
 	if err != nil {
@@ -0,0 +1,367 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type WebhookRepository struct {
+	store *Storage
+}
+
+// ownedProjects selects the projects created by the user $1.
+const ownedProjects = `SELECT id FROM projects WHERE id_creator = $1`
+
+func (r *WebhookRepository) CreateWebhook(userID int, webhook *model.Webhook) error {
+
+	const op = "storage.postgresql.webhook.CreateWebhook"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO webhooks (id_project, url, secret, events, active)
+		SELECT $2, $3, $4, $5, $6
+		WHERE $2 IN (`+ownedProjects+`)
+		RETURNING id, created_at`,
+		userID,
+		webhook.ID_project,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.Events),
+		webhook.Active,
+	).Scan(&webhook.ID, &webhook.Created_at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrProjectNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhooks(userID int, projectID int) ([]model.Webhook, error) {
+
+	const op = "storage.postgresql.webhook.GetWebhooks"
+
+	rows, err := r.store.db.Query(`
+		SELECT id, id_project, url, secret, events, active, created_at
+		FROM webhooks
+		WHERE id_project = $2 and id_project IN (`+ownedProjects+`)
+		ORDER BY id`,
+		userID,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+
+	for rows.Next() {
+		var w model.Webhook
+		if err := rows.Scan(
+			&w.ID,
+			&w.ID_project,
+			&w.URL,
+			&w.Secret,
+			pq.Array(&w.Events),
+			&w.Active,
+			&w.Created_at,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetWebhook(userID int, id int) (*model.Webhook, error) {
+
+	const op = "storage.postgresql.webhook.GetWebhook"
+
+	var w model.Webhook
+
+	err := r.store.db.QueryRow(`
+		SELECT id, id_project, url, secret, events, active, created_at
+		FROM webhooks
+		WHERE id = $2 and id_project IN (`+ownedProjects+`)`,
+		userID,
+		id,
+	).Scan(
+		&w.ID,
+		&w.ID_project,
+		&w.URL,
+		&w.Secret,
+		pq.Array(&w.Events),
+		&w.Active,
+		&w.Created_at,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &w, nil
+}
+
+func (r *WebhookRepository) UpdateWebhook(userID int, webhook model.Webhook) error {
+
+	const op = "storage.postgresql.webhook.UpdateWebhook"
+
+	res, err := r.store.db.Exec(`
+		UPDATE webhooks SET url = $3, secret = $4, events = $5, active = $6
+		WHERE id = $2 and id_project IN (`+ownedProjects+`)`,
+		userID,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.Events),
+		webhook.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) DeleteWebhook(userID int, id int) error {
+
+	const op = "storage.postgresql.webhook.DeleteWebhook"
+
+	res, err := r.store.db.Exec(
+		"DELETE FROM webhooks WHERE id = $2 and id_project IN ("+ownedProjects+")",
+		userID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+const deliveryColumns = `d.id, d.id_webhook, d.id_event, d.event_type, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.response_code, d.last_error, d.created_at, d.delivered_at`
+
+func scanDelivery(row interface{ Scan(...any) error }, d *model.WebhookDelivery, extra ...any) error {
+	return row.Scan(append([]any{
+		&d.ID,
+		&d.ID_webhook,
+		&d.ID_event,
+		&d.Event_type,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.Next_attempt_at,
+		&d.Response_code,
+		&d.Last_error,
+		&d.Created_at,
+		&d.Delivered_at,
+	}, extra...)...)
+}
+
+var deliverySorts = map[string]sortField{
+	"id": {expr: "d.id", sqlType: "bigint"},
+}
+
+func deliveryID(d model.WebhookDelivery) int64 {
+	return d.ID
+}
+
+func (r *WebhookRepository) GetDeliveries(userID int, webhookID int, page storage.Page) ([]model.WebhookDelivery, string, error) {
+
+	const op = "storage.postgresql.webhook.GetDeliveries"
+
+	if _, err := r.GetWebhook(userID, webhookID); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	p, err := newPager(page, deliverySorts, "-id")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{webhookID}
+
+	rows, err := r.store.db.Query(
+		"SELECT "+deliveryColumns+", "+p.sortKey()+" FROM webhook_deliveries d WHERE d.id_webhook = $1 and "+
+			p.keyset("d.id", &args)+" "+p.orderLimit("d.id", &args),
+		args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		deliveries []model.WebhookDelivery
+		keys       []string
+	)
+
+	for rows.Next() {
+		var (
+			d   model.WebhookDelivery
+			key string
+		)
+		if err := scanDelivery(rows, &d, &key); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		deliveries = append(deliveries, d)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	deliveries, next, err := pageOf(p, deliveries, keys, deliveryID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, next, nil
+}
+
+func (r *WebhookRepository) Enqueue(delivery *model.WebhookDelivery, lease time.Duration) error {
+
+	const op = "storage.postgresql.webhook.Enqueue"
+
+	err := r.store.db.QueryRow(`
+		INSERT INTO webhook_deliveries (id_webhook, id_event, event_type, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, now() + $5 * interval '1 second')
+		RETURNING id, status, next_attempt_at, created_at`,
+		delivery.ID_webhook,
+		delivery.ID_event,
+		delivery.Event_type,
+		delivery.Payload,
+		lease.Seconds(),
+	).Scan(
+		&delivery.ID,
+		&delivery.Status,
+		&delivery.Next_attempt_at,
+		&delivery.Created_at,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) ClaimDue(limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+
+	const op = "storage.postgresql.webhook.ClaimDue"
+
+	// SKIP LOCKED lets several instances claim at the same time without
+	// handing out the same delivery twice.
+	rows, err := r.store.db.Query(`
+		WITH due AS (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.id_webhook
+			WHERE d.status = 'pending' and d.next_attempt_at <= now() and w.active
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d SET next_attempt_at = now() + $2 * interval '1 second'
+		FROM due, webhooks w
+		WHERE d.id = due.id and w.id = d.id_webhook
+		RETURNING `+deliveryColumns+`, w.url, w.secret`,
+		limit,
+		lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+
+	for rows.Next() {
+		var d model.WebhookDelivery
+		if err := scanDelivery(rows, &d, &d.URL, &d.Secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(delivery model.WebhookDelivery) error {
+
+	const op = "storage.postgresql.webhook.RecordAttempt"
+
+	_, err := r.store.db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, response_code = $5, last_error = $6, delivered_at = $7
+		WHERE id = $1`,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.Next_attempt_at,
+		delivery.Response_code,
+		delivery.Last_error,
+		delivery.Delivered_at,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// enqueueEvent queues the stored event for every active webhook of its
+// project subscribed to its type.
+func enqueueEvent(q querier, event model.Event, payload []byte) error {
+
+	const op = "storage.postgresql.webhook.enqueueEvent"
+
+	_, err := q.Exec(`
+		INSERT INTO webhook_deliveries (id_webhook, id_event, event_type, payload)
+		SELECT id, $2, $3, $4 FROM webhooks
+		WHERE id_project = $1 and active and (cardinality(events) = 0 or $3 = ANY(events))`,
+		event.ID_project,
+		event.ID,
+		event.Type,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package storage
+
+// PushHookRepository keeps the secrets of incoming push webhooks, one per
+// project. Like webhooks they are managed by the project creator only.
+type PushHookRepository interface {
+	// SetSecret creates the push hook of the project or replaces its secret.
+	SetSecret(userID int, projectID int, secret string) error
+	DeletePushHook(userID int, projectID int) error
+	GetSecret(projectID int) (string, error)
+}
@@ -0,0 +1,11 @@
+package storage
+
+import (
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/taskql"
+)
+
+type SearchRepository interface {
+	SearchTasks(userID int, filter model.SearchFilter, page Page) ([]model.SearchHit, string, error)
+	QueryTasks(userID int, q taskql.Query, projectID int, page Page) ([]model.Task, string, error)
+}
@@ -5,15 +5,63 @@ import "errors"
 type Store interface {
 	User() UserRepository
 	Project() ProjectRepository
+	Board() BoardRepository
 	Column() ColumnRepository
 	Task_log() Task_log_Repository
 	Task() TaskRepository
+	Label() LabelRepository
+	Swimlane() SwimlaneRepository
+	Archive() ArchiveRepository
+	Trash() TrashRepository
+	Search() SearchRepository
+	Filter() FilterRepository
+	Event() EventRepository
+	Webhook() WebhookRepository
+	PushHook() PushHookRepository
+	Notification() NotificationRepository
+	Digest() DigestRepository
+	Metrics() MetricsRepository
+	Export() ExportRepository
+	Import() ImportRepository
+	Template() TemplateRepository
+	TaskTemplate() TaskTemplateRepository
+	TimeEntry() TimeEntryRepository
+	Comment() CommentRepository
 }
 
 var (
 	ErrUserExists      = errors.New("user already exists")
 	ErrUserNotFound    = errors.New("user not found")
 	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectExists   = errors.New("project already exists")
+	ErrBoardNotFound   = errors.New("board not found")
 	ErrColumnNotFound  = errors.New("column not found")
 	ErrTaskNotFound    = errors.New("task not found")
+	ErrLabelNotFound   = errors.New("label not found")
+	ErrLabelExists     = errors.New("label already exists")
+
+	ErrSwimlaneNotFound = errors.New("swimlane not found")
+
+	ErrWIPLimitExceeded = errors.New("column WIP limit exceeded")
+
+	ErrTrashItemNotFound = errors.New("trash item not found")
+
+	ErrInvalidPage = errors.New("invalid page cursor or sort field")
+
+	ErrFilterNotFound = errors.New("filter not found")
+	ErrFilterExists   = errors.New("filter already exists")
+
+	ErrEventNotFound = errors.New("event not found")
+
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrPushHookNotFound = errors.New("push hook not found")
+
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrTemplateExists   = errors.New("template already exists")
+
+	ErrTaskTemplateNotFound = errors.New("task template not found")
+
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	ErrTimerRunning      = errors.New("a timer is already running")
+	ErrTimerNotRunning   = errors.New("no timer is running")
 )
@@ -0,0 +1,10 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type SwimlaneRepository interface {
+	CreateSwimlane(swimlane *model.Swimlane) error
+	GetSwimlanes(projectID int) ([]model.Swimlane, error)
+	DeleteSwimlane(id int) error
+	UpdateSwimlane(swimlane model.Swimlane) error
+}
@@ -5,9 +5,19 @@ import "github.com/wehw93/kanban-board/internal/model"
 type TaskRepository interface {
 	CreateTask(task *model.Task) error
 	ReadTask(task *model.Task) error
-	DeleteTask(IDuser int, id int) error
+	GetProjectID(id int) (int64, error)
+	ArchiveTask(IDuser int, id int) error
+	UnarchiveTask(IDuser int, id int) error
 	UpdateTaskName(task *model.Task) error
 	UpdateTaskDescription(task *model.Task) error
 	UpdateTaskColumn(task *model.Task) error
-	GetLogsTask(id_task int) ([]model.Task_log, error)
+	UpdateTaskPriority(task *model.Task) error
+	UpdateTaskSwimlane(task *model.Task) error
+	UpdateTaskExecutor(task *model.Task) error
+	UpdateTaskDueDate(task *model.Task) error
+	UpdateTaskEstimate(task *model.Task) error
+	GetLogsTask(id_task int, page Page) ([]model.Task_log, string, error)
+	// GetDoneColumn returns the done column of the board the task is on
+	// and the column the task is in now.
+	GetDoneColumn(id int) (done int64, current int64, err error)
 }
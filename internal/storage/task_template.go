@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type TaskTemplateRepository interface {
+	CreateTaskTemplate(template *model.TaskTemplate) error
+	GetTaskTemplates(projectID int) ([]model.TaskTemplate, error)
+	GetTaskTemplate(id int) (*model.TaskTemplate, error)
+	// SetColumn moves the template to another live column of its project.
+	SetColumn(id int, columnID int64) error
+	DeleteTaskTemplate(id int) error
+	// GetInstances returns the tasks created from the template, the latest
+	// first by default.
+	GetInstances(id int, page Page) ([]model.TaskInstance, string, error)
+	// GetDue returns up to limit templates on live columns whose next
+	// repeat is due at now, the longest waiting first.
+	GetDue(now time.Time, limit int) ([]model.TaskTemplate, error)
+	// Spawn creates the task of the repeat the template is due for and
+	// moves the template on to next. It fails with ErrTaskTemplateNotFound
+	// when the repeat was already created by someone else.
+	Spawn(template *model.TaskTemplate, task *model.Task, next sql.NullTime) error
+	// Postpone counts a failed try of the due repeat and keeps the template
+	// out of GetDue until retryAt. It fails with ErrTaskTemplateNotFound
+	// when the template has moved on meanwhile.
+	Postpone(template *model.TaskTemplate, retryAt time.Time) error
+}
@@ -0,0 +1,10 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type TemplateRepository interface {
+	CreateTemplate(template *model.Template) error
+	GetTemplates(userID int) ([]model.Template, error)
+	GetTemplate(userID int, name string) (*model.Template, error)
+	DeleteTemplate(userID int, id int) error
+}
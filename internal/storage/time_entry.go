@@ -0,0 +1,18 @@
+package storage
+
+import "github.com/wehw93/kanban-board/internal/model"
+
+type TimeEntryRepository interface {
+	// CreateEntry adds a finished entry to a live task.
+	CreateEntry(entry *model.TimeEntry) error
+	// StartTimer adds a running entry to a live task, it fails with
+	// ErrTimerRunning when the user already runs one.
+	StartTimer(entry *model.TimeEntry) error
+	// StopTimer ends the running entry of the user, a note that isn't
+	// empty replaces the one given at the start.
+	StopTimer(userID int, note string) (*model.TimeEntry, error)
+	GetTimer(userID int) (*model.TimeEntry, error)
+	GetEntries(taskID int, page Page) ([]model.TimeEntry, string, error)
+	// DeleteEntry deletes an entry of the user, running or not.
+	DeleteEntry(userID int, id int) error
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type TrashRepository interface {
+	TrashColumn(userID int, id int) (*model.TrashItem, error)
+	TrashTask(userID int, id int) (*model.TrashItem, error)
+	GetProjectID(id int) (int64, error)
+	GetItems(projectID int, since time.Time, page Page) ([]model.TrashItem, string, error)
+	Restore(userID int, id int, fallbackColumnID int64, since time.Time) (*model.TrashItem, error)
+	Purge(before time.Time) (int64, error)
+}
@@ -6,6 +6,7 @@ type UserRepository interface {
 	Create(u *model.User) error
 	Login(email string) (model.User, error)
 	GetByID(user_id int) (model.User, error)
+	GetByEmail(email string) (model.User, error)
 	GetProjects(user_id int) ([]model.Project, error)
 	GetTasks(user_id int) ([]model.Task, error)
 	Delete(user_id int) error
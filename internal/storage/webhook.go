@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+// WebhookRepository keeps webhooks and their outbox. Webhooks are managed
+// by the creator of their project only, for anyone else they don't exist.
+type WebhookRepository interface {
+	CreateWebhook(userID int, webhook *model.Webhook) error
+	GetWebhooks(userID int, projectID int) ([]model.Webhook, error)
+	GetWebhook(userID int, id int) (*model.Webhook, error)
+	UpdateWebhook(userID int, webhook model.Webhook) error
+	DeleteWebhook(userID int, id int) error
+	GetDeliveries(userID int, webhookID int, page Page) ([]model.WebhookDelivery, string, error)
+	// Enqueue queues a delivery outside of the event flow. It isn't due
+	// before the lease is over, so the caller can attempt it right away.
+	Enqueue(delivery *model.WebhookDelivery, lease time.Duration) error
+	// ClaimDue hands out up to limit due deliveries of active webhooks and
+	// holds them back from other claims for the lease.
+	ClaimDue(limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	// RecordAttempt saves the outcome of an attempt made on the delivery.
+	RecordAttempt(delivery model.WebhookDelivery) error
+}
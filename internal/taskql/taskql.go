@@ -0,0 +1,284 @@
+// Package taskql parses the small query language used to filter tasks, e.g.
+//
+//	status:todo executor:me priority:>=high created:>2025-01-01 -label:bug "login page"
+//
+// A query is a list of terms joined with AND. A term is either field:value
+// or a bare word matched against the task text. A leading '-' negates the
+// term, double quotes keep spaces inside a value. Dates and priorities can
+// be compared with <, <=, > and >= right after the colon.
+package taskql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+const (
+	FieldText     = "text"
+	FieldStatus   = "status"
+	FieldPriority = "priority"
+	FieldExecutor = "executor"
+	FieldCreator  = "creator"
+	FieldLabel    = "label"
+	FieldProject  = "project"
+	FieldBoard    = "board"
+	FieldColumn   = "column"
+	FieldLane     = "lane"
+	FieldCreated  = "created"
+	FieldDue      = "due"
+)
+
+// MaxTerms bounds the size of the SQL a single query compiles into.
+const MaxTerms = 20
+
+const dateLayout = "2006-01-02"
+
+type Op string
+
+const (
+	OpEq  Op = ":"
+	OpLt  Op = "<"
+	OpLte Op = "<="
+	OpGt  Op = ">"
+	OpGte Op = ">="
+)
+
+type ValueKind int
+
+const (
+	KindText ValueKind = iota
+	KindInt
+	KindDate
+	// KindMe stands for the user running the query.
+	KindMe
+	// KindNone matches tasks where the field is empty.
+	KindNone
+)
+
+type Term struct {
+	Field  string
+	Op     Op
+	Negate bool
+	Kind   ValueKind
+	Text   string
+	Int    int64
+	Date   time.Time
+}
+
+type Query struct {
+	Terms []Term
+}
+
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query error at %d: %s", e.Pos, e.Msg)
+}
+
+type fieldSpec struct {
+	ordered bool
+	parse   func(v string) (Term, error)
+}
+
+var fields = map[string]fieldSpec{
+	FieldStatus:   {parse: enumValue(model.ValidStatus)},
+	FieldPriority: {parse: enumValue(model.ValidPriority), ordered: true},
+	FieldExecutor: {parse: userValue(true)},
+	FieldCreator:  {parse: userValue(false)},
+	FieldLabel:    {parse: textValue},
+	FieldProject:  {parse: idValue(false)},
+	FieldBoard:    {parse: idValue(false)},
+	FieldColumn:   {parse: idValue(false)},
+	FieldLane:     {parse: idValue(true)},
+	FieldCreated:  {parse: dateValue(false), ordered: true},
+	FieldDue:      {parse: dateValue(true), ordered: true},
+}
+
+// Parse checks the query and resolves the type of every value.
+func Parse(input string) (Query, error) {
+
+	var q Query
+
+	runes := []rune(input)
+	pos := 0
+
+	for {
+		for pos < len(runes) && unicode.IsSpace(runes[pos]) {
+			pos++
+		}
+		if pos == len(runes) {
+			break
+		}
+
+		start := pos
+
+		negate, key, value, next, err := scanTerm(runes, pos)
+		if err != nil {
+			return Query{}, &SyntaxError{Pos: start, Msg: err.Error()}
+		}
+		pos = next
+
+		term, err := parseTerm(key, value)
+		if err != nil {
+			return Query{}, &SyntaxError{Pos: start, Msg: err.Error()}
+		}
+		term.Negate = negate
+
+		q.Terms = append(q.Terms, term)
+
+		if len(q.Terms) > MaxTerms {
+			return Query{}, &SyntaxError{Pos: start, Msg: fmt.Sprintf("too many terms, at most %d are allowed", MaxTerms)}
+		}
+	}
+
+	if len(q.Terms) == 0 {
+		return Query{}, &SyntaxError{Msg: "empty query"}
+	}
+
+	return q, nil
+}
+
+// scanTerm reads one term up to the next unquoted space. key is empty for
+// a bare word.
+func scanTerm(runes []rune, pos int) (negate bool, key string, value string, next int, err error) {
+
+	if runes[pos] == '-' {
+		negate = true
+		pos++
+	}
+
+	var (
+		buf      strings.Builder
+		quoted   bool
+		keyFound bool
+	)
+
+	for ; pos < len(runes); pos++ {
+		r := runes[pos]
+
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			return negate, key, buf.String(), pos, checkWord(keyFound, key, buf.String())
+		case r == ':' && !quoted && !keyFound:
+			keyFound = true
+			key = strings.ToLower(buf.String())
+			buf.Reset()
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return false, "", "", pos, fmt.Errorf("unterminated quote")
+	}
+
+	return negate, key, buf.String(), pos, checkWord(keyFound, key, buf.String())
+}
+
+func checkWord(keyFound bool, key string, value string) error {
+	if keyFound && key == "" {
+		return fmt.Errorf("missing field name before ':'")
+	}
+	if value == "" {
+		return fmt.Errorf("missing value")
+	}
+	return nil
+}
+
+func parseTerm(key string, value string) (Term, error) {
+
+	if key == "" {
+		return Term{Field: FieldText, Op: OpEq, Kind: KindText, Text: value}, nil
+	}
+
+	spec, ok := fields[key]
+	if !ok {
+		return Term{}, fmt.Errorf("unknown field %q", key)
+	}
+
+	op := OpEq
+	for _, o := range []Op{OpLte, OpGte, OpLt, OpGt} {
+		if strings.HasPrefix(value, string(o)) {
+			op = o
+			value = strings.TrimPrefix(value, string(o))
+			break
+		}
+	}
+
+	if op != OpEq && !spec.ordered {
+		return Term{}, fmt.Errorf("field %q can't be compared with %s", key, op)
+	}
+
+	term, err := spec.parse(value)
+	if err != nil {
+		return Term{}, fmt.Errorf("%s: %w", key, err)
+	}
+
+	if op != OpEq && term.Kind == KindNone {
+		return Term{}, fmt.Errorf("%s: none can't be compared with %s", key, op)
+	}
+
+	term.Field = key
+	term.Op = op
+
+	return term, nil
+}
+
+func textValue(v string) (Term, error) {
+	return Term{Kind: KindText, Text: v}, nil
+}
+
+func enumValue(valid func(string) bool) func(string) (Term, error) {
+	return func(v string) (Term, error) {
+		v = strings.ToLower(v)
+		if !valid(v) {
+			return Term{}, fmt.Errorf("unknown value %q", v)
+		}
+		return Term{Kind: KindText, Text: v}, nil
+	}
+}
+
+func idValue(allowNone bool) func(string) (Term, error) {
+	return func(v string) (Term, error) {
+		if allowNone && strings.EqualFold(v, "none") {
+			return Term{Kind: KindNone}, nil
+		}
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return Term{}, fmt.Errorf("%q is not an ID", v)
+		}
+		return Term{Kind: KindInt, Int: id}, nil
+	}
+}
+
+func userValue(allowNone bool) func(string) (Term, error) {
+	return func(v string) (Term, error) {
+		if strings.EqualFold(v, "me") {
+			return Term{Kind: KindMe}, nil
+		}
+		return idValue(allowNone)(v)
+	}
+}
+
+func dateValue(allowNone bool) func(string) (Term, error) {
+	return func(v string) (Term, error) {
+		if allowNone && strings.EqualFold(v, "none") {
+			return Term{Kind: KindNone}, nil
+		}
+		d, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return Term{}, fmt.Errorf("%q is not a date, use YYYY-MM-DD", v)
+		}
+		return Term{Kind: KindDate, Date: d}, nil
+	}
+}
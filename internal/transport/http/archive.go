@@ -0,0 +1,265 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+const (
+	archiveKindProject = "project"
+	archiveKindColumn  = "column"
+	archiveKindTask    = "task"
+)
+
+// ListArchivedProjects godoc
+// @Summary Архивные проекты
+// @Description Возвращает архивные проекты текущего пользователя
+// @Tags Archive
+// @Produce json
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(archived, -archived, id, -id, name, -name)
+// @Success 200 {object} response.SuccessResponse{data=[]model.Project} "Список архивных проектов"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры страницы"
+// @Failure 401 {object} response.ErrorResponse "Не авторизован"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении архива"
+// @Security BearerAuth
+// @Router /api/archive/projects [get]
+func (s *Server) ListArchivedProjects() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListArchivedProjects"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		projects, next, err := s.boardSvc.ListArchivedProjects(userID, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to list archived projects", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list archived projects",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       projects,
+			NextCursor: next,
+		})
+	}
+}
+
+// ReadArchive godoc
+// @Summary Архив проекта
+// @Description Возвращает архивные колонки и задачи проекта
+// @Tags Archive
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=response.ArchiveResponse} "Архив проекта"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении архива"
+// @Security BearerAuth
+// @Router /api/archive [get]
+func (s *Server) ReadArchive() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ReadArchive"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		resp, err := s.boardSvc.ReadArchive(userID, projectID)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", slog.Int("project_id", projectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to read archive", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to read archive",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   resp,
+		})
+	}
+}
+
+type RestoreRequest struct {
+	Kind string `json:"kind" validate:"required" enums:"project,column,task"`
+	ID   int    `json:"id" validate:"required"`
+}
+
+// Restore godoc
+// @Summary Восстановление из архива
+// @Description Возвращает архивный проект, колонку или задачу на доску. Задача восстанавливается только если в её колонке не превышен WIP-лимит
+// @Tags Archive
+// @Accept json
+// @Produce json
+// @Param input body RestoreRequest true "Что восстановить"
+// @Success 200 {object} response.SuccessResponse "Восстановлено из архива"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте колонки"
+// @Failure 404 {object} response.ErrorResponse "Объект не найден в архиве"
+// @Failure 409 {object} response.ErrorResponse "Достигнут WIP-лимит колонки"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при восстановлении"
+// @Security BearerAuth
+// @Router /api/archive/restore [post]
+func (s *Server) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.Restore"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req RestoreRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("restoring from archive",
+			slog.String("kind", req.Kind),
+			slog.Int("id", req.ID),
+			slog.Int("user_id", userID),
+		)
+
+		var err error
+
+		switch req.Kind {
+		case archiveKindProject:
+			err = s.boardSvc.RestoreProject(userID, req.ID)
+		case archiveKindColumn:
+			err = s.boardSvc.RestoreColumn(userID, req.ID)
+		case archiveKindTask:
+			err = s.boardSvc.RestoreTask(userID, req.ID)
+		default:
+			log.Error("unknown archive kind", slog.String("kind", req.Kind))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "kind must be one of project, column, task",
+			})
+			return
+		}
+
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrWIPLimitExceeded) {
+			log.Warn("wip limit reached", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "WIP limit of the column is reached",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrProjectNotFound) ||
+			errors.Is(err, storage.ErrColumnNotFound) ||
+			errors.Is(err, storage.ErrTaskNotFound) {
+			log.Warn("nothing to restore", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Not found in archive",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to restore",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: req.Kind + " restored successfully",
+		})
+	}
+}
@@ -4,21 +4,26 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/render"
 	"github.com/wehw93/kanban-board/internal/lib/http/response"
 	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
 	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
 )
 
 type CreateProjectRequest struct {
 	Name        string `json:"name" validate:"required"`
 	Description string `json:"description"`
+	// Template names a built-in or saved template to lay the project out
+	// after, without one the default board starts empty.
+	Template string `json:"template" example:"Basic Kanban"`
 }
 
 // CreateProject godoc
 // @Summary Создать новый проект
-// @Description Создает новый проект для текущего пользователя
+// @Description Создает новый проект для текущего пользователя. Названия проектов одного создателя не повторяются, slug для ссылок строится из названия. С template доска по умолчанию сразу получает колонки с WIP-лимитами и проект - метки шаблона
 // @Tags Projects
 // @Security BearerAuth
 // @Accept json
@@ -27,6 +32,8 @@ type CreateProjectRequest struct {
 // @Success 201 {object} response.SuccessResponse{data=model.Project} "Проект успешно создан"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 401 {object} response.ErrorResponse "Не авторизован"
+// @Failure 404 {object} response.ErrorResponse "Шаблон не найден"
+// @Failure 409 {object} response.ErrorResponse "Проект с таким названием уже есть"
 // @Failure 422 {object} response.ErrorResponse "Ошибка при создании проекта"
 // @Router /api/projects [post]
 func (s *Server) CreateProject() http.HandlerFunc {
@@ -70,7 +77,24 @@ func (s *Server) CreateProject() http.HandlerFunc {
 			Description: req.Description,
 		}
 
-		if err := s.boardSvc.CreateProject(project); err != nil {
+		err := s.boardSvc.CreateProject(project, req.Template)
+		if errors.Is(err, storage.ErrTemplateNotFound) {
+			log.Warn("template not found", slog.String("template", req.Template))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Template not found",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrProjectExists) {
+			log.Warn("project already exists", slog.String("project_name", req.Name))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "Project with this name already exists",
+			})
+			return
+		}
+		if err != nil {
 			log.Error("failed to create project",
 				sl.Err(err),
 			)
@@ -93,19 +117,131 @@ func (s *Server) CreateProject() http.HandlerFunc {
 	}
 }
 
+// GetProject godoc
+// @Summary Получить проект
+// @Description Возвращает проект по ID или slug и одну страницу его задач
+// @Tags Projects
+// @Security BearerAuth
+// @Produce json
+// @Param id query int false "ID проекта"
+// @Param slug query string false "Slug проекта, если ID не указан"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id, name, -name, status, -status)
+// @Success 200 {object} response.SuccessResponse{data=response.ReadProjectResponse} "Успешный запрос"
+// @Failure 400 {object} response.ErrorResponse "Не указан ID или slug"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден"
+// @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /api/projects [get]
+func (s *Server) GetProject() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.GetProject"
+
+		log := s.logger.With(slog.String("op", op))
+
+		query := r.URL.Query()
+
+		var id int
+
+		if v := query.Get("id"); v != "" {
+			var err error
+			if id, err = strconv.Atoi(v); err != nil {
+				log.Error("failed to conv id", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+		}
+
+		slug := query.Get("slug")
+
+		if id == 0 && slug == "" {
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "id or slug is required",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var (
+			resp *response.ReadProjectResponse
+			next string
+		)
+
+		if id != 0 {
+			resp, next, err = s.boardSvc.ReadProject(id, page)
+		} else {
+			resp, next, err = s.boardSvc.ReadProjectBySlug(slug, page)
+		}
+		s.renderProject(w, r, log, resp, next, err)
+	}
+}
+
+// renderProject writes the result of reading a project.
+func (s *Server) renderProject(w http.ResponseWriter, r *http.Request, log *slog.Logger, resp *response.ReadProjectResponse, next string, err error) {
+
+	if errors.Is(err, storage.ErrInvalidPage) {
+		log.Warn("invalid page", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid cursor or sort field",
+		})
+		return
+	}
+	if errors.Is(err, storage.ErrProjectNotFound) {
+		log.Warn("project not found", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusNotFound,
+			Message: "Project not found",
+		})
+		return
+	}
+	if err != nil {
+		log.Error("failed to read project", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusInternalServerError,
+			Message: "Failed to read project",
+		})
+		return
+	}
+
+	render.JSON(w, r, response.SuccessResponse{
+		Status:     http.StatusOK,
+		Data:       resp,
+		NextCursor: next,
+	})
+}
+
 type ReadProjectRequest struct {
 	Name string `json:"name" validate:"required"`
 }
 
 // ReadProject godoc
 // @Summary Получить проект по имени
-// @Description Возвращает информацию о проекте по его названию
+// @Description Возвращает информацию о проекте по его названию и одну страницу его задач. Названия уникальны только у одного создателя, используйте GET /api/projects
+// @Deprecated
 // @Tags Projects
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param input body ReadProjectRequest true "Имя проекта"
-// @Success 200 {object} response.SuccessResponse{data=model.Project} "Успешный запрос"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id, name, -name, status, -status)
+// @Success 200 {object} response.SuccessResponse{data=response.ReadProjectResponse} "Успешный запрос"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 404 {object} response.ErrorResponse "Проект не найден"
 // @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
@@ -131,39 +267,39 @@ func (s *Server) ReadProject() http.HandlerFunc {
 
 		log.Info("reading data of project", slog.String("name", req.Name))
 
-		resp, err := s.boardSvc.ReadProject(req.Name)
+		page, err := pageFromQuery(r)
 		if err != nil {
-			log.Error("failed to read project", slog.String("name", req.Name), sl.Err(err))
+			log.Error("failed to parse page", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
-				Status:  http.StatusInternalServerError,
-				Message: "Project not found",
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
 			})
 			return
 		}
 
-		render.JSON(w, r, response.SuccessResponse{
-			Status: http.StatusOK,
-			Data:   resp,
-		})
+		resp, next, err := s.boardSvc.ReadProjectByName(req.Name, page)
+		s.renderProject(w, r, log, resp, next, err)
 	}
 }
 
 type DeleteProjectRequest struct {
-	Name string `json:"name" validate:"required"`
+	ID int `json:"id"`
+	// Name finds the project among the user's own ones when ID is not set.
+	Name string `json:"name"`
 }
 
 // DeleteProject godoc
-// @Summary Удалить проект
-// @Description Удаляет проект по его названию (только для создателя проекта)
+// @Summary Архивировать проект
+// @Description Переносит проект в архив по его ID или названию (только для создателя проекта). Архивные проекты удаляются навсегда по истечении срока хранения
 // @Tags Projects
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param input body DeleteProjectRequest true "Имя проекта"
-// @Success 200 {object} response.SuccessResponse "Проект успешно удален"
+// @Param input body DeleteProjectRequest true "ID или имя проекта"
+// @Success 200 {object} response.SuccessResponse "Проект перенесен в архив"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 401 {object} response.ErrorResponse "Не авторизован"
-// @Failure 403 {object} response.ErrorResponse "Нет прав на удаление"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден среди проектов пользователя"
 // @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
 // @Router /api/projects [delete]
 func (s *Server) DeleteProject() http.HandlerFunc {
@@ -195,23 +331,43 @@ func (s *Server) DeleteProject() http.HandlerFunc {
 			return
 		}
 
-		log.Info("deleting project",
+		log.Info("archiving project",
+			slog.Int("id", req.ID),
 			slog.String("name", req.Name),
 			slog.Int("user_id", userID),
 		)
 
-		if err := s.boardSvc.DeleteProject(userID, req.Name); err != nil {
-			log.Error("failed to delete project", sl.Err(err))
+		id, err := s.ownProjectID(userID, req.ID, req.Name)
+		if err == nil {
+			err = s.boardSvc.ArchiveProject(userID, id)
+		}
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			log.Warn("project not found", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Project not found",
+			})
+			return
+		}
+		if errors.Is(err, errNoProjectRef) {
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "id or name is required",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to archive project", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusInternalServerError,
-				Message: "Failed to delete project",
+				Message: "Failed to archive project",
 			})
 			return
 		}
 
 		render.JSON(w, r, response.SuccessResponse{
 			Status:  http.StatusOK,
-			Message: "project deleted successfully",
+			Message: "project archived successfully",
 		})
 	}
 }
@@ -223,17 +379,19 @@ type UpdateProjectRequest struct {
 
 // UpdateProject godoc
 // @Summary Обновить проект
-// @Description Обновляет данные проекта (название и/или описание)
+// @Description Обновляет данные проекта (название и/или описание). Вместе с названием меняется slug
 // @Tags Projects
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param name query string true "Название проекта для обновления"
+// @Param id query int false "ID проекта"
+// @Param name query string false "Название своего проекта, если ID не указан"
 // @Param input body UpdateProjectRequest true "Новые данные проекта"
 // @Success 200 {object} response.SuccessResponse "Проект успешно обновлен"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 401 {object} response.ErrorResponse "Не авторизован"
-// @Failure 403 {object} response.ErrorResponse "Нет прав на обновление"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден среди проектов пользователя"
+// @Failure 409 {object} response.ErrorResponse "Проект с таким названием уже есть"
 // @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
 // @Router /api/projects [put]
 func (s *Server) UpdateProject() http.HandlerFunc {
@@ -253,19 +411,53 @@ func (s *Server) UpdateProject() http.HandlerFunc {
 			return
 		}
 
-		name := r.URL.Query().Get("name")
-		if name == "" {
-			log.Error("empty project name in URL")
+		query := r.URL.Query()
+
+		var id int
+
+		if v := query.Get("id"); v != "" {
+			var err error
+			if id, err = strconv.Atoi(v); err != nil {
+				log.Error("failed to conv id", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+		}
+
+		name := query.Get("name")
+
+		id, err := s.ownProjectID(userID, id, name)
+		if errors.Is(err, errNoProjectRef) {
+			log.Error("empty project id and name in URL")
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusBadRequest,
-				Message: "Project name is required in URL",
+				Message: "Project id or name is required in URL",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			log.Warn("project not found", slog.String("name", name))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Project not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to find project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
 			})
 			return
 		}
 
 		var req UpdateProjectRequest
 
-		err := render.DecodeJSON(r.Body, &req)
+		err = render.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
@@ -276,21 +468,30 @@ func (s *Server) UpdateProject() http.HandlerFunc {
 		}
 
 		log.Info("updating project",
-			slog.String("original_name", name),
+			slog.Int("id", id),
 			slog.Int("user_id", userID),
 			slog.Any("new_data", req),
 		)
 
 		var updateErrors []error
 
-		project := model.Project{IDCreator: int64(userID), Name: name}
+		project := model.Project{ID: int64(id), IDCreator: int64(userID)}
 
 		if req.Name != nil {
-			if err := s.boardSvc.UpdateProjectName(*req.Name, project); err != nil {
+			project.Name = *req.Name
+			err := s.boardSvc.UpdateProjectName(project)
+			if errors.Is(err, storage.ErrProjectExists) {
+				log.Warn("project already exists", slog.String("name", *req.Name))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusConflict,
+					Message: "Project with this name already exists",
+				})
+				return
+			}
+			if err != nil {
 				log.Error("failed to update name", sl.Err(err))
 				updateErrors = append(updateErrors, errors.New("failed to update name"))
 			}
-			project.Name = *req.Name
 		}
 
 		if req.Description != nil {
@@ -322,7 +523,11 @@ func (s *Server) UpdateProject() http.HandlerFunc {
 // @Tags Projects
 // @Security BearerAuth
 // @Produce json
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id, name, -name)
 // @Success 200 {object} response.SuccessResponse{data=[]model.Project} "Список проектов"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры страницы"
 // @Failure 401 {object} response.ErrorResponse "Не авторизован"
 // @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
 // @Router /api/projects/list [get]
@@ -333,8 +538,25 @@ func (s *Server) ListProjects() http.HandlerFunc {
 
 		log := s.logger.With(slog.String("op", op))
 
-		listProjects, err := s.boardSvc.ListProjects()
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
 
+		listProjects, next, err := s.boardSvc.ListProjects(page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
 		if err != nil {
 			log.Error("failed to read list of projects", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
@@ -345,9 +567,33 @@ func (s *Server) ListProjects() http.HandlerFunc {
 		}
 
 		render.JSON(w, r, response.SuccessResponse{
-			Status:  http.StatusOK,
-			Message: "projects:",
-			Data:    listProjects,
+			Status:     http.StatusOK,
+			Message:    "projects:",
+			Data:       listProjects,
+			NextCursor: next,
 		})
 	}
 }
+
+var errNoProjectRef = errors.New("neither project id nor name is given")
+
+// ownProjectID returns id when it is set, otherwise the ID of the user's own
+// project called name, which is how clients of the name based API refer to
+// projects.
+func (s *Server) ownProjectID(userID int, id int, name string) (int, error) {
+
+	if id != 0 {
+		return id, nil
+	}
+
+	if name == "" {
+		return 0, errNoProjectRef
+	}
+
+	project, err := s.boardSvc.FindOwnProject(userID, name)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(project.ID), nil
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type CreateBoardRequest struct {
+	Name      string `json:"name" validate:"required"`
+	ProjectID int    `json:"id_project" validate:"required"`
+}
+
+// CreateBoard godoc
+// @Summary Создание доски
+// @Description Создает новую доску в проекте, у каждой доски свой набор колонок
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param input body CreateBoardRequest true "Данные доски"
+// @Success 201 {object} response.SuccessResponse{data=model.Board} "Доска успешно создана"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 422 {object} response.ErrorResponse "Ошибка при создании доски"
+// @Security BearerAuth
+// @Router /api/boards [post]
+func (s *Server) CreateBoard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateBoard"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req CreateBoardRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("create board request",
+			slog.Int("project_id", req.ProjectID),
+			slog.String("board_name", req.Name),
+		)
+
+		b := &model.Board{
+			Name:       req.Name,
+			ID_project: int64(req.ProjectID),
+		}
+
+		if err := s.boardSvc.CreateBoard(b); err != nil {
+			log.Error("failed to create board", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusUnprocessableEntity,
+				Message: "Failed to create board",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   b,
+		})
+	}
+}
+
+// ListBoards godoc
+// @Summary Список досок проекта
+// @Tags Boards
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=[]model.Board} "Список досок"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении досок"
+// @Security BearerAuth
+// @Router /api/boards/list [get]
+func (s *Server) ListBoards() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListBoards"
+
+		log := s.logger.With(slog.String("op", op))
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		boards, err := s.boardSvc.ListBoards(projectID)
+		if err != nil {
+			log.Error("failed to list boards", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list boards",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   boards,
+		})
+	}
+}
+
+type UpdateBoardRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// UpdateBoard godoc
+// @Summary Переименование доски
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param id query int true "ID доски"
+// @Param input body UpdateBoardRequest true "Новое имя доски"
+// @Success 200 {object} response.SuccessResponse "Доска успешно обновлена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при обновлении доски"
+// @Security BearerAuth
+// @Router /api/boards [put]
+func (s *Server) UpdateBoard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.UpdateBoard"
+
+		log := s.logger.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var req UpdateBoardRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		if err := s.boardSvc.UpdateBoardName(model.Board{ID: int64(id), Name: req.Name}); err != nil {
+			log.Error("failed to update board", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to update board",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "board updated successfully",
+		})
+	}
+}
+
+type DeleteBoardRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteBoard godoc
+// @Summary Удаление доски
+// @Description Удаляет доску вместе с её колонками и задачами
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param input body DeleteBoardRequest true "ID доски"
+// @Success 200 {object} response.SuccessResponse "Доска успешно удалена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении доски"
+// @Security BearerAuth
+// @Router /api/boards [delete]
+func (s *Server) DeleteBoard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteBoard"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req DeleteBoardRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("deleting board", slog.Int("board_id", req.ID))
+
+		if err := s.boardSvc.DeleteBoard(req.ID); err != nil {
+			log.Error("failed to delete board", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete board",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "board deleted successfully",
+		})
+	}
+}
+
+// ReadBoard godoc
+// @Summary Доска
+// @Description Возвращает матрицу задач доски колонка × дорожка. Дорожки берутся из проекта (manual) или строятся по исполнителю, метке или приоритету задач
+// @Tags Boards
+// @Produce json
+// @Param id query int true "ID доски"
+// @Param group_by query string false "Группировка дорожек" Enums(manual, executor, label, priority)
+// @Success 200 {object} response.SuccessResponse{data=response.BoardResponse} "Доска проекта"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении доски"
+// @Security BearerAuth
+// @Router /api/boards/read [get]
+func (s *Server) ReadBoard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ReadBoard"
+
+		log := s.logger.With(slog.String("op", op))
+
+		boardID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		groupBy := r.URL.Query().Get("group_by")
+
+		log.Info("reading board",
+			slog.Int("board_id", boardID),
+			slog.String("group_by", groupBy),
+		)
+
+		resp, err := s.boardSvc.ReadBoard(boardID, groupBy)
+		if errors.Is(err, board.ErrUnknownGrouping) {
+			log.Error("unknown grouping", slog.String("group_by", groupBy))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "group_by must be one of manual, executor, label, priority",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrBoardNotFound) {
+			log.Warn("board not found", slog.Int("board_id", boardID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Board not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to read board", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to read board",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   resp,
+		})
+	}
+}
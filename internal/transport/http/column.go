@@ -1,6 +1,7 @@
 package http
 
 import (
+	"database/sql"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -10,22 +11,25 @@ import (
 	"github.com/wehw93/kanban-board/internal/lib/http/response"
 	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
 	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
 )
 
 type CreateColumnRequest struct {
-	Name      string `json:"name" validate:"required"`
-	ProjectID int    `json:"id_project" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	BoardID  int    `json:"id_board" validate:"required"`
+	WIPLimit *int   `json:"wip_limit"`
 }
 
 // CreateColumn godoc
 // @Summary Создание новой колонки
-// @Description Создает новую колонку в указанном проекте
+// @Description Создает новую колонку на указанной доске
 // @Tags Columns
 // @Accept json
 // @Produce json
 // @Param input body CreateColumnRequest true "Данные колонки"
 // @Success 201 {object} response.SuccessResponse{data=model.Column} "Колонка успешно создана"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Доска не найдена"
 // @Failure 422 {object} response.ErrorResponse "Ошибка при создании колонки"
 // @Security BearerAuth
 // @Router /api/columns [post]
@@ -48,16 +52,38 @@ func (s *Server) CreateColumn() http.HandlerFunc {
 		}
 
 		log.Info("create column request",
-			slog.Int("project_id", req.ProjectID),
+			slog.Int("board_id", req.BoardID),
 			slog.String("column_name", req.Name),
 		)
 
+		if req.WIPLimit != nil && *req.WIPLimit <= 0 {
+			log.Error("invalid wip limit", slog.Int("wip_limit", *req.WIPLimit))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "wip_limit must be positive",
+			})
+			return
+		}
+
 		column := &model.Column{
-			Name:       req.Name,
-			ID_project: int64(req.ProjectID),
+			Name:     req.Name,
+			ID_board: int64(req.BoardID),
+		}
+
+		if req.WIPLimit != nil {
+			column.WIP_limit = sql.NullInt64{Int64: int64(*req.WIPLimit), Valid: true}
 		}
 
-		if err := s.boardSvc.CreateColumn(column); err != nil {
+		err := s.boardSvc.CreateColumn(column)
+		if errors.Is(err, storage.ErrBoardNotFound) {
+			log.Warn("board not found", slog.Int("board_id", req.BoardID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Board not found",
+			})
+			return
+		}
+		if err != nil {
 			log.Error("failed to create column",
 				sl.Err(err),
 			)
@@ -70,7 +96,7 @@ func (s *Server) CreateColumn() http.HandlerFunc {
 
 		log.Info("column created successfully",
 			slog.Int("column_id", int(column.ID)),
-			slog.Int64("project_id", column.ID_project),
+			slog.Int64("board_id", column.ID_board),
 		)
 
 		render.JSON(w, r, response.SuccessResponse{
@@ -80,19 +106,25 @@ func (s *Server) CreateColumn() http.HandlerFunc {
 	}
 }
 
+// ReadColumnRequest finds a column by name on a board, it is read only when
+// the id query parameter is not set.
 type ReadColumnRequest struct {
-	Name      string `json:"name" validate:"required"`
-	IDProject int    `json:"id_project" validate:"required"`
+	Name    string `json:"name" validate:"required"`
+	IDBoard int    `json:"id_board" validate:"required"`
 }
 
 // ReadColumn godoc
 // @Summary Получение информации о колонке
-// @Description Возвращает информацию о колонке по имени и ID проекта
+// @Description Возвращает информацию о колонке по её ID. Без ID колонка ищется по имени и ID доски из тела запроса
 // @Tags Columns
 // @Accept json
 // @Produce json
-// @Param input body ReadColumnRequest true "Параметры запроса"
-// @Success 200 {object} response.SuccessResponse{data=model.Column} "Информация о колонке"
+// @Param id query int false "ID колонки"
+// @Param input body ReadColumnRequest false "Имя колонки и ID доски, если ID не указан"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id, name, -name, status, -status)
+// @Success 200 {object} response.SuccessResponse{data=response.ReadColumnResponse} "Информация о колонке"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 404 {object} response.ErrorResponse "Колонка не найдена"
 // @Security BearerAuth
@@ -104,35 +136,75 @@ func (s *Server) ReadColumn() http.HandlerFunc {
 
 		log := s.logger.With("op", op)
 
-		var req ReadColumnRequest
+		var column model.Column
+
+		if v := r.URL.Query().Get("id"); v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil {
+				log.Error("failed to conv id", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+			column.ID = int64(id)
+		} else {
+			var req ReadColumnRequest
+
+			if err := render.DecodeJSON(r.Body, &req); err != nil {
+				log.Error("failed to decode request body", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "invalid request body",
+				})
+				return
+			}
 
-		err := render.DecodeJSON(r.Body, &req)
+			column.Name = req.Name
+			column.ID_board = int64(req.IDBoard)
+		}
 
+		page, err := pageFromQuery(r)
 		if err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
+			log.Error("failed to parse page", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusBadRequest,
-				Message: "invalid request body",
+				Message: "bad request",
 			})
+			return
 		}
 
-		column := model.Column{
-			Name:       req.Name,
-			ID_project: int64(req.IDProject),
+		resp, next, err := s.boardSvc.ReadColumn(column, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrColumnNotFound) {
+			log.Warn("column not found", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Column not found",
+			})
+			return
 		}
-
-		resp, err := s.boardSvc.ReadColumn(column)
 		if err != nil {
 			log.Error("failed to read column", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusBadRequest,
 				Message: "failed to read column",
 			})
+			return
 		}
 
 		render.JSON(w, r, response.SuccessResponse{
-			Status: http.StatusOK,
-			Data:   resp,
+			Status:     http.StatusOK,
+			Data:       resp,
+			NextCursor: next,
 		})
 	}
 }
@@ -142,13 +214,13 @@ type DeleteColumnRequest struct {
 }
 
 // DeleteColumn godoc
-// @Summary Удаление колонки
-// @Description Удаляет колонку по её ID
+// @Summary Архивирование колонки
+// @Description Переносит колонку по её ID в архив вместе с её задачами
 // @Tags Columns
 // @Accept json
 // @Produce json
 // @Param input body DeleteColumnRequest true "ID колонки"
-// @Success 200 {object} response.SuccessResponse "Колонка успешно удалена"
+// @Success 200 {object} response.SuccessResponse "Колонка перенесена в архив"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 500 {object} response.ErrorResponse "Ошибка сервера при удалении колонки"
 // @Security BearerAuth
@@ -172,28 +244,30 @@ func (s *Server) DeleteColumn() http.HandlerFunc {
 			return
 		}
 
-		log.Info("deleting column",
+		log.Info("archiving column",
 			slog.Int("column_id", req.ID),
 		)
 
-		if err := s.boardSvc.DeleteColumn(req.ID); err != nil {
-			log.Error("failed to delete column", sl.Err(err))
+		if err := s.boardSvc.ArchiveColumn(req.ID); err != nil {
+			log.Error("failed to archive column", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusInternalServerError,
-				Message: "Failed to delete column",
+				Message: "Failed to archive column",
 			})
 			return
 		}
 
 		render.JSON(w, r, response.SuccessResponse{
 			Status:  http.StatusOK,
-			Message: "Column deleted successfully",
+			Message: "Column archived successfully",
 		})
 	}
 }
 
 type UpdateColumnRequest struct {
 	Name *string `json:"name"`
+	// WIPLimit sets the maximum number of tasks in the column, 0 removes the limit.
+	WIPLimit *int `json:"wip_limit"`
 }
 
 // UpdateColumn godoc
@@ -218,7 +292,7 @@ func (s *Server) UpdateColumn() http.HandlerFunc {
 
 		id, err := strconv.Atoi(r.URL.Query().Get("id"))
 		if err != nil {
-			log.Error("failed to get id from url", err)
+			log.Error("failed to get id from url", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusInternalServerError,
 				Message: "failed to get id rom url",
@@ -264,6 +338,23 @@ func (s *Server) UpdateColumn() http.HandlerFunc {
 			column.Name = *req.Name
 		}
 
+		if req.WIPLimit != nil {
+			if *req.WIPLimit < 0 {
+				log.Error("invalid wip limit", slog.Int("wip_limit", *req.WIPLimit))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "wip_limit can't be negative",
+				})
+				return
+			}
+
+			column.WIP_limit = sql.NullInt64{Int64: int64(*req.WIPLimit), Valid: *req.WIPLimit > 0}
+			if err := s.boardSvc.UpdateColumnWIPLimit(column); err != nil {
+				log.Error("failed to update wip limit", sl.Err(err))
+				updateErrors = append(updateErrors, errors.New("failed to update wip limit"))
+			}
+		}
+
 		if len(updateErrors) > 0 {
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusInternalServerError,
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// renderCommentError answers the errors comment endpoints share.
+func renderCommentError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+
+	switch {
+	case errors.Is(err, board.ErrEmptyComment):
+		log.Warn("empty comment", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: board.ErrEmptyComment.Error(),
+		})
+	case errors.Is(err, storage.ErrInvalidPage):
+		log.Warn("invalid page", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid cursor or sort field",
+		})
+	case errors.Is(err, service.ErrNotProjectMember):
+		log.Warn("user is not a project member", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusForbidden,
+			Message: "You do not work in this project",
+		})
+	case errors.Is(err, storage.ErrTaskNotFound):
+		log.Warn("task not found", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusNotFound,
+			Message: "Task not found",
+		})
+	default:
+		log.Error("failed to handle comment", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusInternalServerError,
+			Message: "failed to handle comment",
+		})
+	}
+}
+
+type CreateCommentRequest struct {
+	IDTask int    `json:"id_task" validate:"required"`
+	Body   string `json:"body" validate:"required"`
+}
+
+// CreateComment godoc
+// @Summary Комментарий к задаче
+// @Description Добавляет к задаче комментарий текущего пользователя
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param input body CreateCommentRequest true "Задача и текст комментария"
+// @Success 201 {object} response.SuccessResponse{data=model.Comment} "Комментарий добавлен"
+// @Failure 400 {object} response.ErrorResponse "Пустой комментарий"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Задача не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при добавлении комментария"
+// @Security BearerAuth
+// @Router /api/tasks/comments [post]
+func (s *Server) CreateComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateComment"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req CreateCommentRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		comment := &model.Comment{
+			ID_task:   int64(req.IDTask),
+			ID_author: sql.NullInt64{Int64: int64(userID), Valid: true},
+			Body:      req.Body,
+		}
+
+		if err := s.boardSvc.CreateComment(comment); err != nil {
+			renderCommentError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   comment,
+		})
+	}
+}
+
+// ListComments godoc
+// @Summary Комментарии задачи
+// @Description Возвращает комментарии к задаче, по умолчанию старые первыми. У комментариев удаленных пользователей нет автора
+// @Tags Tasks
+// @Produce json
+// @Param id query int true "ID задачи"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id)
+// @Success 200 {object} response.SuccessResponse{data=[]model.Comment} "Комментарии"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID или параметры страницы"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Задача не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении комментариев"
+// @Security BearerAuth
+// @Router /api/tasks/comments [get]
+func (s *Server) ListComments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListComments"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		comments, next, err := s.boardSvc.ListComments(userID, taskID, page)
+		if err != nil {
+			renderCommentError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       comments,
+			NextCursor: next,
+		})
+	}
+}
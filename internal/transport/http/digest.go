@@ -0,0 +1,180 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/service/board"
+)
+
+// GetDigestSettings godoc
+// @Summary Настройки email-дайджеста
+// @Description Дайджест приходит на email пользователя и содержит назначенные ему задачи, просроченные задачи и задачи его проектов, измененные за период. По умолчанию он еженедельный. Пустой дайджест не отправляется
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=model.DigestSettings} "Настройки"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении настроек"
+// @Security BearerAuth
+// @Router /api/users/me/digest [get]
+func (s *Server) GetDigestSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.GetDigestSettings"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		settings, err := s.boardSvc.GetDigestSettings(userID)
+		if err != nil {
+			log.Error("failed to get digest settings", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to get digest settings",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   settings,
+		})
+	}
+}
+
+type DigestSettingsRequest struct {
+	Frequency string `json:"frequency" validate:"required" enums:"off,daily,weekly"`
+}
+
+// SetDigestSettings godoc
+// @Summary Изменение частоты email-дайджеста
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param input body DigestSettingsRequest true "Частота, off отключает дайджест"
+// @Success 200 {object} response.SuccessResponse "Настройки сохранены"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса или частота"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при сохранении настроек"
+// @Security BearerAuth
+// @Router /api/users/me/digest [put]
+func (s *Server) SetDigestSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.SetDigestSettings"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req DigestSettingsRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.SetDigestFrequency(userID, req.Frequency)
+		if errors.Is(err, board.ErrInvalidDigestFrequency) {
+			log.Warn("invalid digest frequency", slog.String("frequency", req.Frequency))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "frequency must be one of off, daily, weekly",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to set digest frequency", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to set digest settings",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "digest settings updated successfully",
+		})
+	}
+}
+
+// UnsubscribeDigest godoc
+// @Summary Отписка от email-дайджеста
+// @Description Ссылка из письма с дайджестом, работает без авторизации. POST поддерживается для отписки в один клик из почтового клиента
+// @Tags Notifications
+// @Produce json
+// @Param user query int true "ID пользователя"
+// @Param token query string true "Подпись ссылки"
+// @Success 200 {object} response.SuccessResponse "Дайджест отключен"
+// @Failure 400 {object} response.ErrorResponse "Неверная ссылка"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при отключении дайджеста"
+// @Router /digest/unsubscribe [get]
+// @Router /digest/unsubscribe [post]
+func (s *Server) UnsubscribeDigest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.UnsubscribeDigest"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, err := strconv.Atoi(r.URL.Query().Get("user"))
+		if err != nil {
+			log.Error("failed to conv user id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid unsubscribe link",
+			})
+			return
+		}
+
+		err = s.boardSvc.Unsubscribe(userID, r.URL.Query().Get("token"))
+		if errors.Is(err, board.ErrInvalidUnsubscribe) {
+			log.Warn("invalid unsubscribe token", slog.Int("user_id", userID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid unsubscribe link",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to unsubscribe", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to unsubscribe",
+			})
+			return
+		}
+
+		log.Info("unsubscribed from digests", slog.Int("user_id", userID))
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "you will no longer receive digests",
+		})
+	}
+}
@@ -2,9 +2,11 @@ package http
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
@@ -19,14 +21,18 @@ import (
 )
 
 type Server struct {
-	server   *http.Server
-	router   *chi.Mux
-	logger   *slog.Logger
-	boardSvc service.BoardService
-	authSvc  service.AuthService
+	server       *http.Server
+	router       *chi.Mux
+	logger       *slog.Logger
+	boardSvc     service.BoardService
+	authSvc      service.AuthService
+	analyticsSvc service.AnalyticsService
+	transferSvc  service.TransferService
+	// pingInterval keeps idle event streams alive through proxies.
+	pingInterval time.Duration
 }
 
-func NewServer(cfg *config.Config, logger *slog.Logger, BoardSvc service.BoardService, AuthSvc service.AuthService) *Server {
+func NewServer(cfg *config.Config, logger *slog.Logger, BoardSvc service.BoardService, AuthSvc service.AuthService, AnalyticsSvc service.AnalyticsService, TransferSvc service.TransferService) *Server {
 
 	router := chi.NewRouter()
 
@@ -35,10 +41,13 @@ func NewServer(cfg *config.Config, logger *slog.Logger, BoardSvc service.BoardSe
 	router.Use(middleware.Recoverer)
 
 	return &Server{
-		boardSvc: BoardSvc,
-		authSvc:  AuthSvc,
-		router:   router,
-		logger:   logger,
+		boardSvc:     BoardSvc,
+		authSvc:      AuthSvc,
+		analyticsSvc: AnalyticsSvc,
+		transferSvc:  TransferSvc,
+		router:       router,
+		logger:       logger,
+		pingInterval: cfg.Events.PingInterval,
 		server: &http.Server{
 			Addr:        cfg.HTTP_Server.Address,
 			Handler:     router,
@@ -56,41 +65,158 @@ func (s *Server) InitRoutes() {
 		r.Post("/login", s.LoginUser())
 	})
 
+	// Browsers can't set headers on a WebSocket handshake, so the socket
+	// checks the token itself instead of going through AuthentificationUser.
+	s.router.Get("/ws/projects", s.ProjectSocket())
+
+	// Git hosts authenticate pushes with the signature of the project's
+	// push hook, not with a user token.
+	s.router.Post("/hooks/push/{id}", s.ReceivePush())
+
+	// Unsubscribe links in digests are signed for the user they were sent to.
+	s.router.Get("/digest/unsubscribe", s.UnsubscribeDigest())
+	s.router.Post("/digest/unsubscribe", s.UnsubscribeDigest())
+
 	s.router.Route("/api", func(r chi.Router) {
-		r.Use(middleware.AllowContentType("application/json"))
 		r.Use(middleware.SetHeader("Content-Type", "application/json"))
 		r.Use(s.AuthentificationUser)
 
-		r.Route("/users", func(r chi.Router) {
-			r.Get("/me", s.ReadUser())
-			r.Put("/me", s.UpdateUser())
-			r.Delete("/me", s.DeleteUser())
-		})
+		// Imports take the file the way it was exported, Jira exports are CSV.
+		r.With(middleware.AllowContentType("application/json", "text/csv")).Post("/projects/import", s.ImportProject())
 
-		r.Route("/projects", func(r chi.Router) {
-			r.Post("/", s.CreateProject())
-			r.Get("/read", s.ReadProject())
-			r.Delete("/", s.DeleteProject())
-			r.Put("/", s.UpdateProject())
-			r.Get("/list", s.ListProjects())
-		})
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AllowContentType("application/json"))
 
-		r.Route("/columns", func(r chi.Router) {
-			r.Post("/", s.CreateColumn())
-			r.Get("/", s.ReadColumn())
-			r.Delete("/", s.DeleteColumn())
-			r.Put("/", s.UpdateColumn())
-		})
+			r.Route("/users", func(r chi.Router) {
+				r.Get("/me", s.ReadUser())
+				r.Put("/me", s.UpdateUser())
+				r.Delete("/me", s.DeleteUser())
+				r.Get("/me/notifications", s.ListNotifications())
+				r.Post("/me/notifications/read", s.MarkNotificationsRead())
+				r.Post("/me/notifications/read-all", s.MarkAllNotificationsRead())
+				r.Get("/me/notification-preferences", s.GetNotificationPreferences())
+				r.Put("/me/notification-preferences", s.SetNotificationPreferences())
+				r.Get("/me/digest", s.GetDigestSettings())
+				r.Put("/me/digest", s.SetDigestSettings())
+			})
+
+			r.Route("/projects", func(r chi.Router) {
+				r.Post("/", s.CreateProject())
+				r.Get("/", s.GetProject())
+				r.Get("/read", s.ReadProject())
+				r.Delete("/", s.DeleteProject())
+				r.Put("/", s.UpdateProject())
+				r.Get("/list", s.ListProjects())
+				r.Get("/{id}/events", s.ProjectEvents())
+				r.Get("/{id}/metrics", s.ProjectMetrics())
+				r.Get("/{id}/metrics/cfd", s.CumulativeFlow())
+				r.Get("/{id}/metrics/throughput", s.Throughput())
+				r.Get("/{id}/metrics/burndown", s.Burndown())
+				r.Get("/{id}/workload", s.Workload())
+				r.Get("/{id}/time", s.TimeReport())
+				r.Get("/{id}/export", s.ExportProject())
+				r.Post("/{id}/clone", s.CloneProject())
+			})
+
+			r.Route("/templates", func(r chi.Router) {
+				r.Post("/", s.CreateTemplate())
+				r.Get("/", s.ListTemplates())
+				r.Delete("/", s.DeleteTemplate())
+			})
+
+			r.Route("/timer", func(r chi.Router) {
+				r.Get("/", s.GetTimer())
+				r.Post("/start", s.StartTimer())
+				r.Post("/stop", s.StopTimer())
+			})
+
+			r.Route("/task-templates", func(r chi.Router) {
+				r.Post("/", s.CreateTaskTemplate())
+				r.Get("/", s.ListTaskTemplates())
+				r.Put("/", s.SetTaskTemplateColumn())
+				r.Delete("/", s.DeleteTaskTemplate())
+				r.Get("/instances", s.ListTaskInstances())
+			})
+
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/", s.ReadArchive())
+				r.Get("/projects", s.ListArchivedProjects())
+				r.Post("/restore", s.Restore())
+			})
+
+			r.Get("/search/tasks", s.SearchTasks())
+
+			r.Route("/filters", func(r chi.Router) {
+				r.Post("/", s.CreateFilter())
+				r.Get("/", s.ListFilters())
+				r.Delete("/", s.DeleteFilter())
+				r.Get("/run", s.RunFilter())
+			})
+
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Post("/", s.CreateWebhook())
+				r.Get("/", s.ListWebhooks())
+				r.Put("/", s.UpdateWebhook())
+				r.Delete("/", s.DeleteWebhook())
+				r.Get("/deliveries", s.ListWebhookDeliveries())
+				r.Post("/test", s.TestWebhook())
+				r.Put("/push", s.SetPushHook())
+				r.Delete("/push", s.DeletePushHook())
+			})
+
+			r.Route("/trash", func(r chi.Router) {
+				r.Get("/", s.ListTrash())
+				r.Post("/columns", s.TrashColumn())
+				r.Post("/tasks", s.TrashTask())
+				r.Post("/restore", s.RestoreFromTrash())
+			})
 
-		r.Route("/tasks", func(r chi.Router) {
-			r.Post("/", s.CreateTask())
-			r.Get("/", s.ReadTask())
-			r.Delete("/", s.DeleteTask())
-			r.Put("/", s.UpdateTask())
-			r.Get("/logs", s.GetLogsTask())
-			s.router.Get("/swagger/*", httpSwagger.Handler(
-				httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
-			))
+			r.Route("/boards", func(r chi.Router) {
+				r.Post("/", s.CreateBoard())
+				r.Get("/read", s.ReadBoard())
+				r.Get("/list", s.ListBoards())
+				r.Put("/", s.UpdateBoard())
+				r.Delete("/", s.DeleteBoard())
+			})
+
+			r.Route("/swimlanes", func(r chi.Router) {
+				r.Post("/", s.CreateSwimlane())
+				r.Get("/", s.ListSwimlanes())
+				r.Put("/", s.UpdateSwimlane())
+				r.Delete("/", s.DeleteSwimlane())
+			})
+
+			r.Route("/labels", func(r chi.Router) {
+				r.Post("/", s.CreateLabel())
+				r.Get("/", s.ListLabels())
+				r.Delete("/", s.DeleteLabel())
+			})
+
+			r.Route("/columns", func(r chi.Router) {
+				r.Post("/", s.CreateColumn())
+				r.Get("/", s.ReadColumn())
+				r.Delete("/", s.DeleteColumn())
+				r.Put("/", s.UpdateColumn())
+			})
+
+			r.Route("/tasks", func(r chi.Router) {
+				r.Post("/", s.CreateTask())
+				r.Get("/", s.ReadTask())
+				r.Delete("/", s.DeleteTask())
+				r.Put("/", s.UpdateTask())
+				r.Get("/logs", s.GetLogsTask())
+				r.Get("/query", s.QueryTasks())
+				r.Post("/labels", s.AddTaskLabel())
+				r.Delete("/labels", s.RemoveTaskLabel())
+				r.Post("/time", s.CreateTimeEntry())
+				r.Get("/time", s.ListTimeEntries())
+				r.Delete("/time", s.DeleteTimeEntry())
+				r.Post("/comments", s.CreateComment())
+				r.Get("/comments", s.ListComments())
+				s.router.Get("/swagger/*", httpSwagger.Handler(
+					httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
+				))
+			})
 		})
 
 		s.router.Get("/swagger/*", httpSwagger.WrapHandler)
@@ -124,9 +250,7 @@ func (s *Server) AuthentificationUser(next http.Handler) http.Handler {
 			return
 		}
 
-		jwtSecret := s.authSvc.GetJWTSecret()
-
-		claims, err := helpers_jwt.ParseToken(parts[1], jwtSecret)
+		user_id, err := s.userFromToken(parts[1])
 		if err != nil {
 			log.Error("failed to parse token", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
@@ -136,22 +260,29 @@ func (s *Server) AuthentificationUser(next http.Handler) http.Handler {
 			return
 		}
 
-		user_id, ok := claims["uid"].(float64)
-		if !ok {
-			log.Error("invalid user ID in token")
-			render.JSON(w, r, response.ErrorResponse{
-				Status:  http.StatusUnauthorized,
-				Message: "Invalid user ID in token",
-			})
-			return
-		}
-
-		ctx := context.WithValue(r.Context(), "userID", int(user_id))
+		ctx := context.WithValue(r.Context(), "userID", user_id)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+var errNoUserInToken = errors.New("invalid user ID in token")
+
+func (s *Server) userFromToken(token string) (int, error) {
+
+	claims, err := helpers_jwt.ParseToken(token, s.authSvc.GetJWTSecret())
+	if err != nil {
+		return 0, err
+	}
+
+	user_id, ok := claims["uid"].(float64)
+	if !ok {
+		return 0, errNoUserInToken
+	}
+
+	return int(user_id), nil
+}
+
 func (s *Server) Start() error {
 	return s.server.ListenAndServe()
 }
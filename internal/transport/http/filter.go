@@ -0,0 +1,426 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+	"github.com/wehw93/kanban-board/internal/taskql"
+)
+
+// QueryTasks godoc
+// @Summary Поиск задач по запросу
+// @Description Возвращает задачи, подходящие под запрос вида `status:todo executor:me priority:>=high created:>2025-01-01 -label:bug "текст"`. Поля: status, priority, executor, creator, label, project, board, column, lane, created, due. Значения me и none подставляют текущего пользователя и пустое поле
+// @Tags Filters
+// @Produce json
+// @Param q query string true "Запрос"
+// @Param id_project query int false "Ограничить проектом"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id, name, -name, created, -created, priority, -priority)
+// @Success 200 {object} response.SuccessResponse{data=[]model.Task} "Найденные задачи"
+// @Failure 400 {object} response.ErrorResponse "Ошибка в запросе"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при выполнении запроса"
+// @Security BearerAuth
+// @Router /api/tasks/query [get]
+func (s *Server) QueryTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.QueryTasks"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		query := r.URL.Query()
+
+		var projectID int
+
+		if v := query.Get("id_project"); v != "" {
+			var err error
+			if projectID, err = strconv.Atoi(v); err != nil {
+				log.Error("failed to conv id_project", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		tasks, next, err := s.boardSvc.QueryTasks(userID, query.Get("q"), projectID, page)
+		if err != nil {
+			s.renderQueryError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       tasks,
+			NextCursor: next,
+		})
+	}
+}
+
+type CreateFilterRequest struct {
+	Name      string `json:"name" validate:"required"`
+	Query     string `json:"query" validate:"required"`
+	ProjectID int    `json:"id_project"`
+	// Shared makes the filter visible to everyone working in the project.
+	Shared bool `json:"shared"`
+}
+
+// CreateFilter godoc
+// @Summary Сохранение фильтра
+// @Description Сохраняет запрос задач под именем. Фильтр с проектом можно открыть для всех, кто работает в проекте
+// @Tags Filters
+// @Accept json
+// @Produce json
+// @Param input body CreateFilterRequest true "Данные фильтра"
+// @Success 201 {object} response.SuccessResponse{data=model.Filter} "Фильтр сохранен"
+// @Failure 400 {object} response.ErrorResponse "Ошибка в запросе фильтра"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден"
+// @Failure 409 {object} response.ErrorResponse "Фильтр с таким именем уже есть"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при сохранении фильтра"
+// @Security BearerAuth
+// @Router /api/filters [post]
+func (s *Server) CreateFilter() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateFilter"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req CreateFilterRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		filter := &model.Filter{
+			ID_user: int64(userID),
+			Name:    req.Name,
+			Query:   req.Query,
+			Shared:  req.Shared,
+		}
+		if req.ProjectID != 0 {
+			filter.ID_project = sql.NullInt64{Int64: int64(req.ProjectID), Valid: true}
+		}
+
+		err := s.boardSvc.CreateFilter(filter)
+		if errors.Is(err, storage.ErrFilterExists) {
+			log.Warn("filter already exists", slog.String("name", req.Name))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "Filter already exists",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			log.Warn("project not found", slog.Int("project_id", req.ProjectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Project not found",
+			})
+			return
+		}
+		if errors.Is(err, board.ErrSharedWithoutProject) {
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Only filters with id_project can be shared",
+			})
+			return
+		}
+		if err != nil {
+			s.renderQueryError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   filter,
+		})
+	}
+}
+
+// ListFilters godoc
+// @Summary Список фильтров
+// @Description Возвращает фильтры пользователя и фильтры, открытые в его проектах
+// @Tags Filters
+// @Produce json
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(name, -name, id, -id)
+// @Success 200 {object} response.SuccessResponse{data=[]model.Filter} "Список фильтров"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры страницы"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении фильтров"
+// @Security BearerAuth
+// @Router /api/filters [get]
+func (s *Server) ListFilters() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListFilters"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		filters, next, err := s.boardSvc.ListFilters(userID, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to list filters", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list filters",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       filters,
+			NextCursor: next,
+		})
+	}
+}
+
+type DeleteFilterRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteFilter godoc
+// @Summary Удаление фильтра
+// @Description Удаляет сохраненный фильтр (только свой)
+// @Tags Filters
+// @Accept json
+// @Produce json
+// @Param input body DeleteFilterRequest true "ID фильтра"
+// @Success 200 {object} response.SuccessResponse "Фильтр удален"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Фильтр не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении фильтра"
+// @Security BearerAuth
+// @Router /api/filters [delete]
+func (s *Server) DeleteFilter() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteFilter"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req DeleteFilterRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.DeleteFilter(userID, req.ID)
+		if errors.Is(err, storage.ErrFilterNotFound) {
+			log.Warn("filter not found", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Filter not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete filter", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete filter",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "filter deleted successfully",
+		})
+	}
+}
+
+// RunFilter godoc
+// @Summary Выполнение сохраненного фильтра
+// @Description Возвращает задачи по сохраненному фильтру, me в запросе означает текущего пользователя
+// @Tags Filters
+// @Produce json
+// @Param id query int true "ID фильтра"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id, name, -name, created, -created, priority, -priority)
+// @Success 200 {object} response.SuccessResponse{data=[]model.Task} "Найденные задачи"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры"
+// @Failure 404 {object} response.ErrorResponse "Фильтр не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при выполнении фильтра"
+// @Security BearerAuth
+// @Router /api/filters/run [get]
+func (s *Server) RunFilter() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.RunFilter"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		tasks, next, err := s.boardSvc.RunFilter(userID, id, page)
+		if errors.Is(err, storage.ErrFilterNotFound) {
+			log.Warn("filter not found", slog.Int("id", id))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Filter not found",
+			})
+			return
+		}
+		if err != nil {
+			s.renderQueryError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       tasks,
+			NextCursor: next,
+		})
+	}
+}
+
+// renderQueryError answers 400 for mistakes in a task query or page and
+// 500 for everything else.
+func (s *Server) renderQueryError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+
+	var syntaxErr *taskql.SyntaxError
+
+	if errors.As(err, &syntaxErr) {
+		log.Warn("invalid task query", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: syntaxErr.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, storage.ErrInvalidPage) {
+		log.Warn("invalid page", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid cursor or sort field",
+		})
+		return
+	}
+
+	log.Error("failed to query tasks", sl.Err(err))
+	render.JSON(w, r, response.ErrorResponse{
+		Status:  http.StatusInternalServerError,
+		Message: "failed to query tasks",
+	})
+}
@@ -0,0 +1,283 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type CreateLabelRequest struct {
+	Name      string `json:"name" validate:"required"`
+	ProjectID int    `json:"id_project" validate:"required"`
+}
+
+// CreateLabel godoc
+// @Summary Создание метки
+// @Description Создает новую метку в проекте
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param input body CreateLabelRequest true "Данные метки"
+// @Success 201 {object} response.SuccessResponse{data=model.Label} "Метка успешно создана"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 409 {object} response.ErrorResponse "Метка уже существует"
+// @Failure 422 {object} response.ErrorResponse "Ошибка при создании метки"
+// @Security BearerAuth
+// @Router /api/labels [post]
+func (s *Server) CreateLabel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateLabel"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req CreateLabelRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		label := &model.Label{
+			Name:       req.Name,
+			ID_project: int64(req.ProjectID),
+		}
+
+		err := s.boardSvc.CreateLabel(label)
+		if errors.Is(err, storage.ErrLabelExists) {
+			log.Warn("label already exists", slog.String("name", req.Name))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "Label already exists",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create label", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusUnprocessableEntity,
+				Message: "Failed to create label",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   label,
+		})
+	}
+}
+
+// ListLabels godoc
+// @Summary Список меток проекта
+// @Tags Labels
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=[]model.Label} "Список меток"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении меток"
+// @Security BearerAuth
+// @Router /api/labels [get]
+func (s *Server) ListLabels() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListLabels"
+
+		log := s.logger.With(slog.String("op", op))
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		labels, err := s.boardSvc.ListLabels(projectID)
+		if err != nil {
+			log.Error("failed to list labels", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list labels",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   labels,
+		})
+	}
+}
+
+type DeleteLabelRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteLabel godoc
+// @Summary Удаление метки
+// @Description Удаляет метку и снимает её со всех задач
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param input body DeleteLabelRequest true "ID метки"
+// @Success 200 {object} response.SuccessResponse "Метка успешно удалена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении метки"
+// @Security BearerAuth
+// @Router /api/labels [delete]
+func (s *Server) DeleteLabel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteLabel"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req DeleteLabelRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		if err := s.boardSvc.DeleteLabel(req.ID); err != nil {
+			log.Error("failed to delete label", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete label",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "label deleted successfully",
+		})
+	}
+}
+
+type TaskLabelRequest struct {
+	TaskID  int `json:"id_task" validate:"required"`
+	LabelID int `json:"id_label" validate:"required"`
+}
+
+// AddTaskLabel godoc
+// @Summary Добавить метку задаче
+// @Description Вешает метку проекта на задачу
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param input body TaskLabelRequest true "ID задачи и метки"
+// @Success 200 {object} response.SuccessResponse "Метка добавлена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Метка не найдена в проекте задачи"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при добавлении метки"
+// @Security BearerAuth
+// @Router /api/tasks/labels [post]
+func (s *Server) AddTaskLabel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.AddTaskLabel"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req TaskLabelRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.AddTaskLabel(req.TaskID, req.LabelID)
+		if errors.Is(err, storage.ErrLabelNotFound) {
+			log.Warn("label not found in task project",
+				slog.Int("task_id", req.TaskID),
+				slog.Int("label_id", req.LabelID),
+			)
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Label not found in the project of the task",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to add label", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to add label",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "label added to task",
+		})
+	}
+}
+
+// RemoveTaskLabel godoc
+// @Summary Снять метку с задачи
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param input body TaskLabelRequest true "ID задачи и метки"
+// @Success 200 {object} response.SuccessResponse "Метка снята"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при снятии метки"
+// @Security BearerAuth
+// @Router /api/tasks/labels [delete]
+func (s *Server) RemoveTaskLabel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.RemoveTaskLabel"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req TaskLabelRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		if err := s.boardSvc.RemoveTaskLabel(req.TaskID, req.LabelID); err != nil {
+			log.Error("failed to remove label", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to remove label",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "label removed from task",
+		})
+	}
+}
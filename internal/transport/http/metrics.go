@@ -0,0 +1,608 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/csvsafe"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/service/analytics"
+)
+
+// Reports are JSON unless format=csv asks for a spreadsheet.
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// writeCSV sends the table as a file download.
+func writeCSV(w http.ResponseWriter, filename string, header []string, rows [][]string) error {
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	cw := csvsafe.NewWriter(w)
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// dateRangeFromQuery reads the from and to days of a report, a missing one
+// is left zero for the service to default.
+func dateRangeFromQuery(r *http.Request) (time.Time, time.Time, error) {
+
+	var from, to time.Time
+
+	if v := r.URL.Query().Get("from"); v != "" {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = d
+	}
+
+	if v := r.URL.Query().Get("to"); v != "" {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = d
+	}
+
+	return from, to, nil
+}
+
+// reportFilterFromQuery reads the optional id_label and id_executor of a
+// report.
+func reportFilterFromQuery(r *http.Request) (model.ReportFilter, error) {
+
+	var (
+		filter model.ReportFilter
+		err    error
+	)
+
+	if v := r.URL.Query().Get("id_label"); v != "" {
+		if filter.ID_label, err = strconv.Atoi(v); err != nil {
+			return model.ReportFilter{}, err
+		}
+	}
+
+	if v := r.URL.Query().Get("id_executor"); v != "" {
+		if filter.ID_executor, err = strconv.Atoi(v); err != nil {
+			return model.ReportFilter{}, err
+		}
+	}
+
+	return filter, nil
+}
+
+// renderAnalyticsError answers the errors every report shares.
+func renderAnalyticsError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+
+	switch {
+	case errors.Is(err, analytics.ErrInvalidRange):
+		log.Warn("invalid date range", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "from must not be after to, and the range must not exceed a year",
+		})
+	case errors.Is(err, service.ErrNotProjectMember):
+		log.Warn("user is not a project member", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusForbidden,
+			Message: "You do not work in this project",
+		})
+	default:
+		log.Error("failed to build report", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusInternalServerError,
+			Message: "failed to build report",
+		})
+	}
+}
+
+// ProjectMetrics godoc
+// @Summary Lead time и cycle time проекта
+// @Description Считает по истории задач, завершенных за период: lead time - от создания до переноса в done, cycle time - от первого переноса в in_progress до done. Задачи, миновавшие in_progress, не входят в cycle time. Время в днях, по умолчанию период - последние 30 дней, не больше года
+// @Tags Analytics
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Param from query string false "Начало периода, YYYY-MM-DD"
+// @Param to query string false "Конец периода включительно, YYYY-MM-DD"
+// @Success 200 {object} response.SuccessResponse{data=model.FlowMetrics} "Метрики"
+// @Failure 400 {object} response.ErrorResponse "Неверный период"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при расчете метрик"
+// @Security BearerAuth
+// @Router /api/projects/{id}/metrics [get]
+func (s *Server) ProjectMetrics() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ProjectMetrics"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		from, to, err := dateRangeFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse date range", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "from and to must be YYYY-MM-DD dates",
+			})
+			return
+		}
+
+		metrics, err := s.analyticsSvc.FlowMetrics(userID, projectID, from, to)
+		if err != nil {
+			renderAnalyticsError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   metrics,
+		})
+	}
+}
+
+// CumulativeFlow godoc
+// @Summary Данные для диаграммы накопленного потока (CFD)
+// @Description Число задач в каждой колонке проекта по дням. Снимки колонок записываются раз в snapshot_interval, за день остается последний. Дни без снимков пропускаются. В JSON counts каждого дня идут в порядке columns, с format=csv - таблица с колонкой day и колонкой на каждую колонку доски. По умолчанию период - последние 30 дней, не больше года
+// @Tags Analytics
+// @Produce json,text/csv
+// @Param id path int true "ID проекта"
+// @Param id_board query int false "Только колонки этой доски"
+// @Param from query string false "Начало периода, YYYY-MM-DD"
+// @Param to query string false "Конец периода включительно, YYYY-MM-DD"
+// @Param format query string false "Формат ответа" Enums(json, csv)
+// @Success 200 {object} response.SuccessResponse{data=model.CumulativeFlow} "Данные CFD"
+// @Failure 400 {object} response.ErrorResponse "Неверный период или параметры"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при построении CFD"
+// @Security BearerAuth
+// @Router /api/projects/{id}/metrics/cfd [get]
+func (s *Server) CumulativeFlow() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CumulativeFlow"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var boardID int
+
+		if v := r.URL.Query().Get("id_board"); v != "" {
+			if boardID, err = strconv.Atoi(v); err != nil {
+				log.Error("failed to conv board id", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != formatJSON && format != formatCSV {
+			log.Error("unknown format", slog.String("format", format))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "format must be json or csv",
+			})
+			return
+		}
+
+		from, to, err := dateRangeFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse date range", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "from and to must be YYYY-MM-DD dates",
+			})
+			return
+		}
+
+		cfd, err := s.analyticsSvc.CumulativeFlow(userID, projectID, boardID, from, to)
+		if err != nil {
+			renderAnalyticsError(w, r, log, err)
+			return
+		}
+
+		if format == formatCSV {
+			header := []string{"day"}
+			for _, c := range cfd.Columns {
+				header = append(header, c.Name)
+			}
+
+			rows := make([][]string, 0, len(cfd.Days))
+			for _, d := range cfd.Days {
+				row := []string{d.Day}
+				for _, n := range d.Counts {
+					row = append(row, strconv.Itoa(n))
+				}
+				rows = append(rows, row)
+			}
+
+			if err := writeCSV(w, fmt.Sprintf("cfd-%d-%s-%s.csv", projectID, cfd.From, cfd.To), header, rows); err != nil {
+				log.Error("failed to write csv", sl.Err(err))
+			}
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   cfd,
+		})
+	}
+}
+
+// Throughput godoc
+// @Summary Пропускная способность проекта
+// @Description Число задач, завершенных за каждую неделю периода (с понедельника), и сумма их оценок. Первая и последняя неделя считаются только по дням внутри периода. Можно оставить задачи одной метки или одного исполнителя. По умолчанию период - последние 30 дней, не больше года
+// @Tags Analytics
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Param id_label query int false "Только задачи с этой меткой"
+// @Param id_executor query int false "Только задачи этого исполнителя"
+// @Param from query string false "Начало периода, YYYY-MM-DD"
+// @Param to query string false "Конец периода включительно, YYYY-MM-DD"
+// @Success 200 {object} response.SuccessResponse{data=model.Throughput} "Отчет"
+// @Failure 400 {object} response.ErrorResponse "Неверный период или параметры"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при построении отчета"
+// @Security BearerAuth
+// @Router /api/projects/{id}/metrics/throughput [get]
+func (s *Server) Throughput() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.Throughput"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		filter, err := reportFilterFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse filter", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "id_label and id_executor must be numbers",
+			})
+			return
+		}
+
+		from, to, err := dateRangeFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse date range", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "from and to must be YYYY-MM-DD dates",
+			})
+			return
+		}
+
+		report, err := s.analyticsSvc.Throughput(userID, projectID, filter, from, to)
+		if err != nil {
+			renderAnalyticsError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   report,
+		})
+	}
+}
+
+// Burndown godoc
+// @Summary Burndown проекта
+// @Description Число открытых задач и сумма их оценок на конец каждого дня от from до целевой даты to, и идеальная линия от начального объема до нуля в целевую дату. У дней после сегодняшнего есть только идеальная линия. Задача открыта со дня создания до дня переноса в done. Можно оставить задачи одной метки или одного исполнителя. По умолчанию from - за 30 дней до to, to - сегодня
+// @Tags Analytics
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Param id_label query int false "Только задачи с этой меткой"
+// @Param id_executor query int false "Только задачи этого исполнителя"
+// @Param from query string false "Начало периода, YYYY-MM-DD"
+// @Param to query string false "Целевая дата, YYYY-MM-DD"
+// @Success 200 {object} response.SuccessResponse{data=model.Burndown} "Отчет"
+// @Failure 400 {object} response.ErrorResponse "Неверный период или параметры"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при построении отчета"
+// @Security BearerAuth
+// @Router /api/projects/{id}/metrics/burndown [get]
+func (s *Server) Burndown() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.Burndown"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		filter, err := reportFilterFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse filter", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "id_label and id_executor must be numbers",
+			})
+			return
+		}
+
+		from, to, err := dateRangeFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse date range", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "from and to must be YYYY-MM-DD dates",
+			})
+			return
+		}
+
+		report, err := s.analyticsSvc.Burndown(userID, projectID, filter, from, to)
+		if err != nil {
+			renderAnalyticsError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   report,
+		})
+	}
+}
+
+// Workload godoc
+// @Summary Загрузка участников проекта
+// @Description Для каждого участника проекта: открытые задачи по статусам, просроченные задачи, сумма оценок открытых задач и задачи, завершенные за последние days дней, включая сегодня. Участники с наибольшим числом открытых задач идут первыми, открытые задачи без исполнителя собраны в unassigned. По умолчанию days - 14, не больше 366
+// @Tags Analytics
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Param days query int false "Период для завершенных задач в днях"
+// @Success 200 {object} response.SuccessResponse{data=model.Workload} "Загрузка участников"
+// @Failure 400 {object} response.ErrorResponse "Неверный период"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при построении отчета"
+// @Security BearerAuth
+// @Router /api/projects/{id}/workload [get]
+func (s *Server) Workload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.Workload"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var days int
+		if v := r.URL.Query().Get("days"); v != "" {
+			days, err = strconv.Atoi(v)
+			if err != nil || days < 1 || days > 366 {
+				log.Warn("invalid days", slog.String("days", v))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "days must be a number from 1 to 366",
+				})
+				return
+			}
+		}
+
+		workload, err := s.analyticsSvc.Workload(userID, projectID, days)
+		if err != nil {
+			renderAnalyticsError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   workload,
+		})
+	}
+}
+
+// TimeReport godoc
+// @Summary Отчет о затраченном времени
+// @Description Время, записанное по задачам проекта (в том числе архивным), по пользователям и дням. Запись относится к дню своего начала по UTC, идущие таймеры считаются до текущего момента. Время в секундах, с format=csv - таблица с колонками user_id, name, email, day, seconds и hours. По умолчанию период - последние 30 дней, не больше года
+// @Tags Analytics
+// @Produce json,text/csv
+// @Param id path int true "ID проекта"
+// @Param from query string false "Начало периода, YYYY-MM-DD"
+// @Param to query string false "Конец периода включительно, YYYY-MM-DD"
+// @Param format query string false "Формат ответа" Enums(json, csv)
+// @Success 200 {object} response.SuccessResponse{data=model.TimeReport} "Отчет"
+// @Failure 400 {object} response.ErrorResponse "Неверный период или параметры"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при построении отчета"
+// @Security BearerAuth
+// @Router /api/projects/{id}/time [get]
+func (s *Server) TimeReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.TimeReport"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != formatJSON && format != formatCSV {
+			log.Error("unknown format", slog.String("format", format))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "format must be json or csv",
+			})
+			return
+		}
+
+		from, to, err := dateRangeFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse date range", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "from and to must be YYYY-MM-DD dates",
+			})
+			return
+		}
+
+		report, err := s.analyticsSvc.TimeReport(userID, projectID, from, to)
+		if err != nil {
+			renderAnalyticsError(w, r, log, err)
+			return
+		}
+
+		if format == formatCSV {
+			header := []string{"user_id", "name", "email", "day", "seconds", "hours"}
+
+			var rows [][]string
+			for _, u := range report.Users {
+				for _, d := range u.Days {
+					rows = append(rows, []string{
+						strconv.FormatInt(u.ID_user, 10),
+						u.Name,
+						u.Email,
+						d.Day,
+						strconv.FormatInt(d.Seconds, 10),
+						strconv.FormatFloat(float64(d.Seconds)/3600, 'f', 2, 64),
+					})
+				}
+			}
+
+			if err := writeCSV(w, fmt.Sprintf("time-%d-%s-%s.csv", projectID, report.From, report.To), header, rows); err != nil {
+				log.Error("failed to write csv", sl.Err(err))
+			}
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   report,
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// ListNotifications godoc
+// @Summary Уведомления пользователя
+// @Description Возвращает уведомления об изменениях задач, которые пользователь создал или выполняет, новые первыми, и число всех непрочитанных. О своих изменениях пользователь не уведомляется
+// @Tags Notifications
+// @Produce json
+// @Param unread query bool false "Только непрочитанные"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id)
+// @Success 200 {object} response.SuccessResponse{data=response.Notifications} "Уведомления"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры страницы"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении уведомлений"
+// @Security BearerAuth
+// @Router /api/users/me/notifications [get]
+func (s *Server) ListNotifications() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListNotifications"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		unreadOnly := r.URL.Query().Get("unread") == "true"
+
+		notifications, unread, next, err := s.boardSvc.ListNotifications(userID, unreadOnly, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to list notifications", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list notifications",
+			})
+			return
+		}
+
+		if notifications == nil {
+			notifications = []model.Notification{}
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data: response.Notifications{
+				Unread: unread,
+				Items:  notifications,
+			},
+			NextCursor: next,
+		})
+	}
+}
+
+type MarkNotificationsReadRequest struct {
+	IDs []int64 `json:"ids" validate:"required"`
+}
+
+// MarkNotificationsRead godoc
+// @Summary Отметить уведомления прочитанными
+// @Description Отмечает прочитанными уведомления с указанными ID. Чужие и уже прочитанные уведомления пропускаются
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param input body MarkNotificationsReadRequest true "ID уведомлений"
+// @Success 200 {object} response.SuccessResponse{data=response.MarkedRead} "Сколько уведомлений отмечено"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при обновлении уведомлений"
+// @Security BearerAuth
+// @Router /api/users/me/notifications/read [post]
+func (s *Server) MarkNotificationsRead() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.MarkNotificationsRead"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req MarkNotificationsReadRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil || len(req.IDs) == 0 {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		marked, err := s.boardSvc.MarkNotificationsRead(userID, req.IDs)
+		if err != nil {
+			log.Error("failed to mark notifications read", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to mark notifications read",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   response.MarkedRead{Marked: marked},
+		})
+	}
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Отметить все уведомления прочитанными
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=response.MarkedRead} "Сколько уведомлений отмечено"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при обновлении уведомлений"
+// @Security BearerAuth
+// @Router /api/users/me/notifications/read-all [post]
+func (s *Server) MarkAllNotificationsRead() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.MarkAllNotificationsRead"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		marked, err := s.boardSvc.MarkAllNotificationsRead(userID)
+		if err != nil {
+			log.Error("failed to mark notifications read", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to mark notifications read",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   response.MarkedRead{Marked: marked},
+		})
+	}
+}
+
+// GetNotificationPreferences godoc
+// @Summary Настройки уведомлений
+// @Description Возвращает все типы уведомлений и включены ли они. По умолчанию включены все
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=[]model.NotificationPreference} "Настройки"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении настроек"
+// @Security BearerAuth
+// @Router /api/users/me/notification-preferences [get]
+func (s *Server) GetNotificationPreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.GetNotificationPreferences"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		prefs, err := s.boardSvc.GetNotificationPreferences(userID)
+		if err != nil {
+			log.Error("failed to get notification preferences", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to get notification preferences",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   prefs,
+		})
+	}
+}
+
+// SetNotificationPreferences godoc
+// @Summary Изменение настроек уведомлений
+// @Description Включает или выключает перечисленные типы уведомлений, остальные не меняются. Возвращает все настройки
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param input body []model.NotificationPreference true "Настройки"
+// @Success 200 {object} response.SuccessResponse{data=[]model.NotificationPreference} "Настройки"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса или неизвестный тип"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при сохранении настроек"
+// @Security BearerAuth
+// @Router /api/users/me/notification-preferences [put]
+func (s *Server) SetNotificationPreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.SetNotificationPreferences"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req []model.NotificationPreference
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.SetNotificationPreferences(userID, req)
+		if errors.Is(err, board.ErrUnknownNotificationType) {
+			log.Warn("unknown notification type", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Unknown notification type",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to set notification preferences", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to set notification preferences",
+			})
+			return
+		}
+
+		prefs, err := s.boardSvc.GetNotificationPreferences(userID)
+		if err != nil {
+			log.Error("failed to get notification preferences", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to get notification preferences",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   prefs,
+		})
+	}
+}
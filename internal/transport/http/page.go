@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// pageFromQuery reads the limit, cursor and sort parameters shared by the
+// paginated lists. sort is a field name, a leading '-' sorts descending.
+func pageFromQuery(r *http.Request) (storage.Page, error) {
+
+	query := r.URL.Query()
+
+	page := storage.Page{
+		Cursor: query.Get("cursor"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if page.Limit, err = strconv.Atoi(limit); err != nil {
+			return storage.Page{}, err
+		}
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		page.Sort = strings.TrimPrefix(sort, "-")
+		page.Desc = strings.HasPrefix(sort, "-")
+	}
+
+	return page, nil
+}
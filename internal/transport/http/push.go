@@ -0,0 +1,251 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/lib/webhook"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// maxPushBody limits the size of an incoming push payload.
+const maxPushBody = 1 << 20
+
+type PushHookRequest struct {
+	ProjectID int `json:"id_project" validate:"required"`
+}
+
+// SetPushHook godoc
+// @Summary Входящий вебхук для коммитов
+// @Description Включает прием push-событий от GitHub, GitLab или любого другого источника и выдает новый секрет, прежний перестает действовать. Коммит с "fixes #123" (также fix, fixed, closes, resolves) перемещает задачу 123 в колонку done её доски от имени автора коммита, если его email принадлежит пользователю, работающему в проекте. GitHub: Content type application/json, Secret - выданный секрет. GitLab: Secret token - выданный секрет. Остальные источники подписывают тело так же, как исходящие вебхуки доски (X-Board-Timestamp, X-Board-Signature). Доступно только создателю проекта
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param input body PushHookRequest true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=response.PushHook} "Адрес и секрет вебхука"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при создании вебхука"
+// @Security BearerAuth
+// @Router /api/webhooks/push [put]
+func (s *Server) SetPushHook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.SetPushHook"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req PushHookRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		secret, err := s.boardSvc.SetPushHook(userID, req.ProjectID)
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			log.Warn("project not found", slog.Int("project_id", req.ProjectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Project not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to set push hook", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to set push hook",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data: response.PushHook{
+				ProjectID: uint(req.ProjectID),
+				Path:      "/hooks/push/" + strconv.Itoa(req.ProjectID),
+				Secret:    secret,
+			},
+		})
+	}
+}
+
+// DeletePushHook godoc
+// @Summary Отключение входящего вебхука
+// @Description Перестает принимать push-события для проекта
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param input body PushHookRequest true "ID проекта"
+// @Success 200 {object} response.SuccessResponse "Вебхук отключен"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Вебхук не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при отключении вебхука"
+// @Security BearerAuth
+// @Router /api/webhooks/push [delete]
+func (s *Server) DeletePushHook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeletePushHook"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req PushHookRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.DeletePushHook(userID, req.ProjectID)
+		if errors.Is(err, storage.ErrPushHookNotFound) {
+			log.Warn("push hook not found", slog.Int("project_id", req.ProjectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Push hook not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete push hook", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete push hook",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "push hook deleted successfully",
+		})
+	}
+}
+
+// ReceivePush godoc
+// @Summary Прием push-события
+// @Description Адрес для git-хостинга. Проверяет подпись, находит в сообщениях коммитов ссылки вида "fixes #123" и перемещает задачи в колонку done от имени автора коммита, который оставляет на задаче комментарий с коммитом. В ответе - что сделано по каждой ссылке. Запросы, не являющиеся push (например ping), только проверяются
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Param X-GitHub-Event header string false "Событие GitHub"
+// @Param X-Hub-Signature-256 header string false "Подпись GitHub"
+// @Param X-Gitlab-Event header string false "Событие GitLab"
+// @Param X-Gitlab-Token header string false "Секрет GitLab"
+// @Param X-Board-Timestamp header int false "Время отправки, unix"
+// @Param X-Board-Signature header string false "Подпись остальных источников"
+// @Param input body webhook.Push true "Push-событие"
+// @Success 200 {object} response.SuccessResponse{data=response.PushResult} "Результат обработки"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат события"
+// @Failure 401 {object} response.ErrorResponse "Неверная подпись"
+// @Failure 404 {object} response.ErrorResponse "Вебхук не найден"
+// @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /hooks/push/{id} [post]
+func (s *Server) ReceivePush() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ReceivePush"
+
+		log := s.logger.With(slog.String("op", op))
+
+		// git hosts judge a delivery by the HTTP status, so unlike the API
+		// this endpoint sets it
+		fail := func(status int, message string) {
+			render.Status(r, status)
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  status,
+				Message: message,
+			})
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			fail(http.StatusBadRequest, "bad request")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPushBody))
+		if err != nil {
+			log.Error("failed to read body", sl.Err(err))
+			fail(http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		in := webhook.NewIncoming(r.Header, body)
+
+		result, err := s.boardSvc.ReceivePush(projectID, in)
+		if errors.Is(err, storage.ErrPushHookNotFound) {
+			log.Warn("push hook not found", slog.Int("project_id", projectID))
+			fail(http.StatusNotFound, "Push hook not found")
+			return
+		}
+		if errors.Is(err, board.ErrInvalidSignature) {
+			log.Warn("invalid signature",
+				slog.Int("project_id", projectID),
+				slog.String("provider", in.Provider),
+			)
+			fail(http.StatusUnauthorized, "Invalid signature")
+			return
+		}
+		if errors.Is(err, board.ErrInvalidPush) {
+			log.Warn("invalid push payload", sl.Err(err))
+			fail(http.StatusBadRequest, "Invalid push payload")
+			return
+		}
+		if err != nil {
+			log.Error("failed to receive push", sl.Err(err))
+			fail(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		log.Info("push received",
+			slog.Int("project_id", projectID),
+			slog.String("provider", in.Provider),
+			slog.Int("refs", len(result.Refs)),
+		)
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   result,
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+const dateLayout = "2006-01-02"
+
+// SearchTasks godoc
+// @Summary Полнотекстовый поиск задач
+// @Description Ищет задачи по названию, описанию и комментариям во всех проектах пользователя: созданных им и тех, где он автор или исполнитель задач. Результаты отсортированы по релевантности
+// @Tags Search
+// @Produce json
+// @Param q query string true "Поисковый запрос, поддерживает кавычки, OR и -слово"
+// @Param status query string false "Статус задачи" Enums(todo, in_progress, done)
+// @Param id_executor query int false "ID исполнителя"
+// @Param id_label query int false "ID метки"
+// @Param from query string false "Создана не раньше (YYYY-MM-DD)"
+// @Param to query string false "Создана не позже (YYYY-MM-DD)"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(rank, -rank)
+// @Success 200 {object} response.SuccessResponse{data=[]response.SearchHit} "Найденные задачи"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры поиска"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при поиске"
+// @Security BearerAuth
+// @Router /api/search/tasks [get]
+func (s *Server) SearchTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.SearchTasks"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		query := r.URL.Query()
+
+		filter := model.SearchFilter{
+			Query:  query.Get("q"),
+			Status: query.Get("status"),
+		}
+
+		if filter.Query == "" {
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "q is required",
+			})
+			return
+		}
+
+		var err error
+
+		if v := query.Get("id_executor"); v != "" {
+			filter.ID_executor, err = strconv.ParseInt(v, 10, 64)
+		}
+		if v := query.Get("id_label"); v != "" && err == nil {
+			filter.ID_label, err = strconv.ParseInt(v, 10, 64)
+		}
+		if v := query.Get("from"); v != "" && err == nil {
+			filter.Created_from, err = parseDate(v)
+		}
+		if v := query.Get("to"); v != "" && err == nil {
+			filter.Created_to, err = parseDate(v)
+		}
+		if err != nil {
+			log.Error("failed to parse search params", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		hits, next, err := s.boardSvc.SearchTasks(userID, filter, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to search tasks", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to search tasks",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       hits,
+			NextCursor: next,
+		})
+	}
+}
+
+func parseDate(v string) (sql.NullTime, error) {
+
+	t, err := time.Parse(dateLayout, v)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/gorilla/websocket"
+	"github.com/wehw93/kanban-board/internal/events"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// writeWait limits how long a single write to a slow client may take.
+const writeWait = 10 * time.Second
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// ProjectSocket godoc
+// @Summary Изменения доски в реальном времени
+// @Description WebSocket с событиями проекта: task.created, task.updated, task.moved, task.assigned, task.deleted, task.restored, comment.created, column.created, column.updated, column.deleted, column.restored. Токен передается в заголовке Authorization или в параметре token. После переподключения передайте last_event_id, чтобы получить пропущенные события. Событие resync означает, что часть событий потеряна и доску нужно загрузить заново
+// @Tags Events
+// @Param id_project query int true "ID проекта"
+// @Param token query string false "JWT, если нельзя передать заголовок"
+// @Param last_event_id query int false "ID последнего полученного события"
+// @Success 101 {object} model.Event "Поток событий"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры"
+// @Failure 401 {object} response.ErrorResponse "Не авторизован"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /ws/projects [get]
+func (s *Server) ProjectSocket() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ProjectSocket"
+
+		log := s.logger.With(slog.String("op", op))
+
+		query := r.URL.Query()
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" {
+			token = query.Get("token")
+		}
+
+		userID, err := s.userFromToken(token)
+		if err != nil {
+			log.Error("failed to parse token", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusUnauthorized,
+				Message: "Invalid token",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(query.Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var after int64
+
+		if v := query.Get("last_event_id"); v != "" {
+			if after, err = strconv.ParseInt(v, 10, 64); err != nil {
+				log.Error("failed to conv last_event_id", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+		}
+
+		sub, missed, complete, err := s.boardSvc.SubscribeProject(userID, projectID, after)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("not a project member", slog.Int("user_id", userID), slog.Int("project_id", projectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You don't work in this project",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to subscribe", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+		defer sub.Close()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// the upgrader has already answered the client
+			log.Warn("failed to upgrade connection", sl.Err(err))
+			return
+		}
+		defer conn.Close()
+
+		log.Info("client connected",
+			slog.Int("user_id", userID),
+			slog.Int("project_id", projectID),
+			slog.Int64("last_event_id", after),
+		)
+
+		if err := s.streamEvents(conn, sub, after, missed, complete, int64(projectID)); err != nil {
+			log.Debug("client disconnected", sl.Err(err))
+		}
+	}
+}
+
+// streamEvents writes the missed events and then the live ones until the
+// client leaves or falls too far behind.
+func (s *Server) streamEvents(conn *websocket.Conn, sub *events.Subscription, after int64, missed []model.Event, complete bool, projectID int64) error {
+
+	// Clients only send control frames. Reading processes them and notices
+	// when the client is gone.
+	gone := make(chan struct{})
+
+	conn.SetReadDeadline(time.Now().Add(2 * s.pingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * s.pingInterval))
+	})
+
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(event model.Event) error {
+		conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return conn.WriteJSON(event)
+	}
+
+	if !complete {
+		if err := write(model.Event{ID_project: projectID, Type: model.EventResync, Created_at: time.Now()}); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range missed {
+		if err := write(event); err != nil {
+			return err
+		}
+	}
+
+	seen := replayedThrough(after, missed, complete)
+
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too far behind, reconnect with last_event_id")
+				return conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+			}
+			if event.ID <= seen {
+				continue
+			}
+			if err := write(event); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return err
+			}
+		case <-gone:
+			return nil
+		}
+	}
+}
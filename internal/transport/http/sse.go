@@ -0,0 +1,212 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+)
+
+// sseRetry is how long an EventSource waits before reconnecting, in ms.
+const sseRetry = 3000
+
+// ProjectEvents godoc
+// @Summary Поток изменений доски (SSE)
+// @Description Server-Sent Events с теми же событиями проекта, что и WebSocket /ws/projects. Имя SSE-события совпадает с типом события, id - с его ID. При переподключении браузер сам передает заголовок Last-Event-ID и получает пропущенные события, клиенты без EventSource могут передать last_event_id. Событие resync означает, что часть событий потеряна и доску нужно загрузить заново. Раз в ping_interval приходит комментарий-heartbeat
+// @Tags Events
+// @Produce text/event-stream
+// @Param id path int true "ID проекта"
+// @Param Last-Event-ID header int false "ID последнего полученного события"
+// @Param last_event_id query int false "ID последнего полученного события, если нельзя передать заголовок"
+// @Success 200 {object} model.Event "Поток событий"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры"
+// @Failure 401 {object} response.ErrorResponse "Не авторизован"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /api/projects/{id}/events [get]
+func (s *Server) ProjectEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ProjectEvents"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var after int64
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("last_event_id")
+		}
+
+		if lastEventID != "" {
+			if after, err = strconv.ParseInt(lastEventID, 10, 64); err != nil {
+				log.Error("failed to conv last event id", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "bad request",
+				})
+				return
+			}
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			log.Error("response writer can't flush")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		sub, missed, complete, err := s.boardSvc.SubscribeProject(userID, projectID, after)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("not a project member", slog.Int("user_id", userID), slog.Int("project_id", projectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You don't work in this project",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to subscribe", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+		defer sub.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// keeps nginx from buffering the stream
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		log.Info("client connected",
+			slog.Int("user_id", userID),
+			slog.Int("project_id", projectID),
+			slog.Int64("last_event_id", after),
+		)
+
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", sseRetry); err != nil {
+			return
+		}
+
+		if !complete {
+			resync := model.Event{ID_project: int64(projectID), Type: model.EventResync, Created_at: time.Now()}
+			if err := writeSSE(w, resync); err != nil {
+				log.Debug("client disconnected", sl.Err(err))
+				return
+			}
+		}
+
+		for _, event := range missed {
+			if err := writeSSE(w, event); err != nil {
+				log.Debug("client disconnected", sl.Err(err))
+				return
+			}
+		}
+		flusher.Flush()
+
+		seen := replayedThrough(after, missed, complete)
+
+		ticker := time.NewTicker(s.pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					// the client was too far behind, EventSource reconnects
+					// with Last-Event-ID on its own
+					return
+				}
+				if event.ID <= seen {
+					continue
+				}
+				if err := writeSSE(w, event); err != nil {
+					log.Debug("client disconnected", sl.Err(err))
+					return
+				}
+			case <-ticker.C:
+				if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+					log.Debug("client disconnected", sl.Err(err))
+					return
+				}
+			case <-r.Context().Done():
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeSSE writes the event as a single SSE message. A resync carries no id,
+// so the client keeps resuming from the last real event.
+func writeSSE(w io.Writer, event model.Event) error {
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+
+	return err
+}
+
+// replayedThrough returns the ID of the newest event the client has once
+// missed is sent. Live events up to it were already replayed.
+func replayedThrough(after int64, missed []model.Event, complete bool) int64 {
+
+	if !complete {
+		return 0
+	}
+
+	if len(missed) > 0 {
+		return missed[len(missed)-1].ID
+	}
+
+	return after
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+)
+
+type CreateSwimlaneRequest struct {
+	Name      string `json:"name" validate:"required"`
+	ProjectID int    `json:"id_project" validate:"required"`
+	Position  int    `json:"position"`
+}
+
+// CreateSwimlane godoc
+// @Summary Создание дорожки
+// @Description Создает новую дорожку (swimlane) в проекте
+// @Tags Swimlanes
+// @Accept json
+// @Produce json
+// @Param input body CreateSwimlaneRequest true "Данные дорожки"
+// @Success 201 {object} response.SuccessResponse{data=model.Swimlane} "Дорожка успешно создана"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 422 {object} response.ErrorResponse "Ошибка при создании дорожки"
+// @Security BearerAuth
+// @Router /api/swimlanes [post]
+func (s *Server) CreateSwimlane() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateSwimlane"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req CreateSwimlaneRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("create swimlane request",
+			slog.Int("project_id", req.ProjectID),
+			slog.String("swimlane_name", req.Name),
+		)
+
+		swimlane := &model.Swimlane{
+			Name:       req.Name,
+			ID_project: int64(req.ProjectID),
+			Position:   req.Position,
+		}
+
+		if err := s.boardSvc.CreateSwimlane(swimlane); err != nil {
+			log.Error("failed to create swimlane", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusUnprocessableEntity,
+				Message: "Failed to create swimlane",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   swimlane,
+		})
+	}
+}
+
+// ListSwimlanes godoc
+// @Summary Список дорожек проекта
+// @Description Возвращает дорожки проекта в порядке отображения
+// @Tags Swimlanes
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=[]model.Swimlane} "Список дорожек"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении дорожек"
+// @Security BearerAuth
+// @Router /api/swimlanes [get]
+func (s *Server) ListSwimlanes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListSwimlanes"
+
+		log := s.logger.With(slog.String("op", op))
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		swimlanes, err := s.boardSvc.ListSwimlanes(projectID)
+		if err != nil {
+			log.Error("failed to list swimlanes", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list swimlanes",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   swimlanes,
+		})
+	}
+}
+
+type UpdateSwimlaneRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Position int    `json:"position"`
+}
+
+// UpdateSwimlane godoc
+// @Summary Обновление дорожки
+// @Description Переименовывает дорожку и меняет её позицию
+// @Tags Swimlanes
+// @Accept json
+// @Produce json
+// @Param id query int true "ID дорожки"
+// @Param input body UpdateSwimlaneRequest true "Новые данные дорожки"
+// @Success 200 {object} response.SuccessResponse "Дорожка успешно обновлена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при обновлении дорожки"
+// @Security BearerAuth
+// @Router /api/swimlanes [put]
+func (s *Server) UpdateSwimlane() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.UpdateSwimlane"
+
+		log := s.logger.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var req UpdateSwimlaneRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		swimlane := model.Swimlane{
+			ID:       int64(id),
+			Name:     req.Name,
+			Position: req.Position,
+		}
+
+		if err := s.boardSvc.UpdateSwimlane(swimlane); err != nil {
+			log.Error("failed to update swimlane", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to update swimlane",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "swimlane updated successfully",
+		})
+	}
+}
+
+type DeleteSwimlaneRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteSwimlane godoc
+// @Summary Удаление дорожки
+// @Description Удаляет дорожку, задачи дорожки остаются на доске без дорожки
+// @Tags Swimlanes
+// @Accept json
+// @Produce json
+// @Param input body DeleteSwimlaneRequest true "ID дорожки"
+// @Success 200 {object} response.SuccessResponse "Дорожка успешно удалена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении дорожки"
+// @Security BearerAuth
+// @Router /api/swimlanes [delete]
+func (s *Server) DeleteSwimlane() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteSwimlane"
+
+		log := s.logger.With(slog.String("op", op))
+
+		var req DeleteSwimlaneRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("deleting swimlane", slog.Int("swimlane_id", req.ID))
+
+		if err := s.boardSvc.DeleteSwimlane(req.ID); err != nil {
+			log.Error("failed to delete swimlane", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete swimlane",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "swimlane deleted successfully",
+		})
+	}
+}
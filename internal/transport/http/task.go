@@ -12,12 +12,18 @@ import (
 	"github.com/wehw93/kanban-board/internal/lib/http/response"
 	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
 	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/storage"
 )
 
 type CreateTaskRequest struct {
 	IDColumn    int    `json:"id_column" validate:"required"`
 	Name        string `json:"name" validate:"required"`
 	Description string `json:"description" validate:"required"`
+	Priority    string `json:"priority" enums:"low,medium,high,urgent"`
+	IDSwimlane  *int   `json:"id_swimlane"`
+	DueDate     string `json:"due_date" format:"date" example:"2024-06-30"`
+	// Estimate is the size of the task in points.
+	Estimate *int `json:"estimate" minimum:"0"`
 }
 
 // CreateTask godoc
@@ -29,6 +35,7 @@ type CreateTaskRequest struct {
 // @Param input body CreateTaskRequest true "Данные задачи"
 // @Success 200 {object} response.SuccessResponse{data=model.Task} "Задача успешно создана"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 409 {object} response.ErrorResponse "Превышен WIP-лимит колонки, задача не изменена"
 // @Failure 422 {object} response.ErrorResponse "Ошибка при создании задачи"
 // @Security BearerAuth
 // @Router /api/tasks [post]
@@ -64,16 +71,69 @@ func (s *Server) CreateTask() http.HandlerFunc {
 			slog.Int("creator_id", creator_id),
 			slog.String("name of task", req.Name))
 
+		if req.Priority != "" && !model.ValidPriority(req.Priority) {
+			log.Error("invalid priority", slog.String("priority", req.Priority))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "priority must be one of low, medium, high, urgent",
+			})
+			return
+		}
+
 		task := &model.Task{
 			ID_column:   int64(req.IDColumn),
 			Name:        req.Name,
 			Description: req.Description,
 			ID_creator:  int64(creator_id),
+			Priority:    req.Priority,
+		}
+
+		if req.IDSwimlane != nil {
+			task.ID_swimlane = sql.NullInt64{Int64: int64(*req.IDSwimlane), Valid: true}
+		}
+
+		due, err := parseDueDate(req.DueDate)
+		if err != nil {
+			log.Error("invalid due date", slog.String("due_date", req.DueDate))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "due_date must be a YYYY-MM-DD date",
+			})
+			return
+		}
+		task.Due_date = due
+
+		if req.Estimate != nil {
+			if *req.Estimate < 0 {
+				log.Error("negative estimate", slog.Int("estimate", *req.Estimate))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "estimate must not be negative",
+				})
+				return
+			}
+			task.Estimate = sql.NullInt64{Int64: int64(*req.Estimate), Valid: true}
 		}
 
 		task.Date_of_create = time.Now().Format("2006-01-02")
 
-		err := s.boardSvc.CreateTask(task)
+		err = s.boardSvc.CreateTask(task)
+		if errors.Is(err, storage.ErrSwimlaneNotFound) {
+			log.Warn("swimlane not found in project", slog.Int("swimlane_id", *req.IDSwimlane))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Swimlane not found in the project of the column",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrWIPLimitExceeded) {
+			log.Warn("wip limit exceeded", slog.Int("column_id", req.IDColumn))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "WIP limit of the column is reached",
+			})
+			return
+		}
 
 		if err != nil {
 			log.Error("failed to create task", sl.Err(err))
@@ -92,6 +152,21 @@ func (s *Server) CreateTask() http.HandlerFunc {
 
 }
 
+// parseDueDate reads a YYYY-MM-DD date, an empty string is no due date.
+func parseDueDate(value string) (sql.NullTime, error) {
+
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+
+	due, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: due, Valid: true}, nil
+}
+
 type ReadTaskRequest struct {
 	ID int `json:"id" validate:"required"`
 }
@@ -155,13 +230,13 @@ type DeleteTaskRequest struct {
 }
 
 // DeleteTask godoc
-// @Summary Удаление задачи
-// @Description Удаляет задачу по ID
+// @Summary Архивирование задачи
+// @Description Переносит задачу по ID в архив
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Param input body DeleteTaskRequest true "ID задачи"
-// @Success 200 {object} response.SuccessResponse "Задача успешно удалена"
+// @Success 200 {object} response.SuccessResponse "Задача перенесена в архив"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
 // @Failure 500 {object} response.ErrorResponse "Ошибка при удалении задачи"
 // @Security BearerAuth
@@ -195,22 +270,22 @@ func (s *Server) DeleteTask() http.HandlerFunc {
 			return
 		}
 
-		log.Info("deleting task",
+		log.Info("archiving task",
 			slog.Int("id", req.ID),
 			slog.Int("user_id", userID),
 		)
 
-		if err := s.boardSvc.DeleteTask(userID, req.ID); err != nil {
-			log.Error("failed to delete task", sl.Err(err))
+		if err := s.boardSvc.ArchiveTask(userID, req.ID); err != nil {
+			log.Error("failed to archive task", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusInternalServerError,
-				Message: "Failed to delete task",
+				Message: "Failed to archive task",
 			})
 			return
 		}
 		render.JSON(w, r, response.SuccessResponse{
 			Status:  http.StatusOK,
-			Message: "task deleted successfully",
+			Message: "task archived successfully",
 		})
 	}
 }
@@ -219,11 +294,20 @@ type UpdateTaskRequest struct {
 	Name        *string `json:"name"`
 	Description *string `json:"description"`
 	Id_column   *int    `json:"id_column"`
+	Priority    *string `json:"priority" enums:"low,medium,high,urgent"`
+	// IDSwimlane moves the task to another manual swimlane, 0 takes it out of any lane.
+	IDSwimlane *int `json:"id_swimlane"`
+	// IDExecutor assigns the task to a user, 0 leaves it without an executor.
+	IDExecutor *int `json:"id_executor"`
+	// DueDate is a YYYY-MM-DD date, an empty string removes it.
+	DueDate *string `json:"due_date" format:"date" example:"2024-06-30"`
+	// Estimate is the size of the task in points, a negative value removes it.
+	Estimate *int `json:"estimate"`
 }
 
 // UpdateTask godoc
 // @Summary Обновление задачи
-// @Description Обновляет имя, описание или колонку задачи
+// @Description Обновляет имя, описание, колонку, приоритет, дорожку, срок, оценку или исполнителя задачи. Изменения одного запроса публикуются одним событием и одним уведомлением: о назначении, если задаче назначен исполнитель, иначе о переносе или изменении. Задачу можно перенести в колонку другой доски того же проекта
 // @Tags Tasks
 // @Accept json
 // @Produce json
@@ -231,6 +315,8 @@ type UpdateTaskRequest struct {
 // @Param input body UpdateTaskRequest true "Обновленные данные задачи"
 // @Success 200 {object} response.SuccessResponse "Задача успешно обновлена"
 // @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Исполнитель не найден"
+// @Failure 409 {object} response.ErrorResponse "Превышен WIP-лимит колонки, задача не изменена"
 // @Failure 500 {object} response.ErrorResponse "Ошибка при обновлении задачи"
 // @Security BearerAuth
 // @Router /api/tasks [put]
@@ -288,37 +374,73 @@ func (s *Server) UpdateTask() http.HandlerFunc {
 			slog.Any("new_data", req),
 		)
 
-		var updateErrors []error
+		var (
+			update       model.TaskUpdate
+			updateErrors []error
+		)
 
-		task := &model.Task{
-			ID:          int64(id),
-			ID_executor: sql.NullInt64{Int64: int64(userID), Valid: userID != 0},
+		update.Name = req.Name
+		update.Description = req.Description
+
+		if req.Id_column != nil {
+			column := int64(*req.Id_column)
+			update.ID_column = &column
 		}
 
-		if req.Name != nil {
-			task.ID_column = int64(*req.Id_column)
-			if err := s.boardSvc.UpdateTaskName(task); err != nil {
-				log.Error("failed to update name", sl.Err(err))
-				updateErrors = append(updateErrors, errors.New("failed to update name"))
+		if req.Priority != nil {
+			if !model.ValidPriority(*req.Priority) {
+				log.Error("invalid priority", slog.String("priority", *req.Priority))
+				updateErrors = append(updateErrors, errors.New("invalid priority"))
+			} else {
+				update.Priority = req.Priority
 			}
 		}
 
-		if req.Description != nil {
-			task.Description = *req.Description
-			if err := s.boardSvc.UpdateTaskDescription(task); err != nil {
-				log.Error("failed to update description", sl.Err(err))
-				updateErrors = append(updateErrors, errors.New("failed to update description"))
-			}
+		if req.IDSwimlane != nil {
+			update.ID_swimlane = &sql.NullInt64{Int64: int64(*req.IDSwimlane), Valid: *req.IDSwimlane != 0}
 		}
 
-		if req.Id_column != nil {
-			task.ID_column = int64(*req.Id_column)
-			if err := s.boardSvc.UpdateTaskColumn(task); err != nil {
-				log.Error("failed to update column id", sl.Err(err))
-				updateErrors = append(updateErrors, errors.New("failed to update column id"))
+		if req.DueDate != nil {
+			due, err := parseDueDate(*req.DueDate)
+			if err != nil {
+				log.Error("invalid due date", slog.String("due_date", *req.DueDate))
+				updateErrors = append(updateErrors, errors.New("invalid due date"))
+			} else {
+				update.Due_date = &due
 			}
 		}
 
+		if req.Estimate != nil {
+			update.Estimate = &sql.NullInt64{Int64: int64(*req.Estimate), Valid: *req.Estimate >= 0}
+		}
+
+		if req.IDExecutor != nil {
+			update.ID_executor = &sql.NullInt64{Int64: int64(*req.IDExecutor), Valid: *req.IDExecutor != 0}
+		}
+
+		// The edit is saved and announced in one go, so a PUT that changes
+		// several fields notifies once.
+		err = s.boardSvc.UpdateTask(userID, id, update)
+		switch {
+		case errors.Is(err, storage.ErrWIPLimitExceeded):
+			log.Warn("wip limit exceeded", slog.Any("column_id", req.Id_column))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "WIP limit of the column is reached",
+			})
+			return
+		case errors.Is(err, storage.ErrUserNotFound):
+			log.Warn("executor not found", slog.Any("executor_id", req.IDExecutor))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Executor not found",
+			})
+			return
+		case err != nil:
+			log.Error("failed to update task", sl.Err(err))
+			updateErrors = append(updateErrors, err)
+		}
+
 		if len(updateErrors) > 0 {
 			render.JSON(w, r, response.ErrorResponse{
 				Status:  http.StatusInternalServerError,
@@ -341,6 +463,9 @@ func (s *Server) UpdateTask() http.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param id query int true "ID задачи"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id)
 // @Success 200 {object} response.SuccessResponse{data=[]model.Task_log} "Логи задачи"
 // @Failure 400 {object} response.ErrorResponse "Неверный ID"
 // @Failure 500 {object} response.ErrorResponse "Ошибка при получении логов"
@@ -363,7 +488,25 @@ func (s *Server) GetLogsTask() http.HandlerFunc {
 			return
 		}
 
-		logs, err := s.boardSvc.GetLogsTask(id_task)
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		logs, next, err := s.boardSvc.GetLogsTask(id_task, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
 		if err != nil {
 			log.Error("failed to get logs task", sl.Err(err))
 			render.JSON(w, r, response.ErrorResponse{
@@ -374,9 +517,10 @@ func (s *Server) GetLogsTask() http.HandlerFunc {
 		}
 
 		render.JSON(w, r, response.SuccessResponse{
-			Status:  http.StatusOK,
-			Message: "logs of task id:" + strconv.Itoa(id_task),
-			Data:    logs,
+			Status:     http.StatusOK,
+			Message:    "logs of task id:" + strconv.Itoa(id_task),
+			Data:       logs,
+			NextCursor: next,
 		})
 	}
 }
@@ -0,0 +1,527 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/lib/rrule"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type CreateTaskTemplateRequest struct {
+	IDColumn    int    `json:"id_column" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+	Priority    string `json:"priority" enums:"low,medium,high,urgent"`
+	IDExecutor  *int   `json:"id_executor"`
+	IDSwimlane  *int   `json:"id_swimlane"`
+	// Estimate is the size of every task in points.
+	Estimate *int   `json:"estimate" minimum:"0"`
+	Rule     string `json:"rule" validate:"required" example:"FREQ=WEEKLY;BYDAY=MO"`
+	// StartsAt is the start of the series, now by default.
+	StartsAt string `json:"starts_at" format:"date-time" example:"2026-10-19T09:00:00Z"`
+}
+
+// CreateTaskTemplate godoc
+// @Summary Создание повторяющейся задачи
+// @Description Создает шаблон задачи с правилом повторения (подмножество RRULE: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY для DAILY и WEEKLY, UNTIL или COUNT). Когда подходит срок повторения, в колонке шаблона создается новая задача. Правило считается в UTC от starts_at, пропущенные повторения не создаются задним числом
+// @Tags TaskTemplates
+// @Accept json
+// @Produce json
+// @Param input body CreateTaskTemplateRequest true "Данные шаблона"
+// @Success 201 {object} response.SuccessResponse{data=model.TaskTemplate} "Шаблон создан, next_at - время первой задачи"
+// @Failure 400 {object} response.ErrorResponse "Неверное правило повторения или данные шаблона"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Колонка, дорожка или исполнитель не найдены"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при создании шаблона"
+// @Security BearerAuth
+// @Router /api/task-templates [post]
+func (s *Server) CreateTaskTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateTaskTemplate"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req CreateTaskTemplateRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		if req.Name == "" || req.Rule == "" {
+			log.Error("missing name or rule")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "name and rule are required",
+			})
+			return
+		}
+
+		if req.Priority != "" && !model.ValidPriority(req.Priority) {
+			log.Error("invalid priority", slog.String("priority", req.Priority))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "priority must be one of low, medium, high, urgent",
+			})
+			return
+		}
+
+		if req.Estimate != nil && *req.Estimate < 0 {
+			log.Error("negative estimate", slog.Int("estimate", *req.Estimate))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "estimate must not be negative",
+			})
+			return
+		}
+
+		template := &model.TaskTemplate{
+			ID_column:   sql.NullInt64{Int64: int64(req.IDColumn), Valid: true},
+			Name:        req.Name,
+			Description: req.Description,
+			Priority:    req.Priority,
+			Rule:        req.Rule,
+		}
+
+		if req.IDExecutor != nil {
+			template.ID_executor = sql.NullInt64{Int64: int64(*req.IDExecutor), Valid: true}
+		}
+		if req.IDSwimlane != nil {
+			template.ID_swimlane = sql.NullInt64{Int64: int64(*req.IDSwimlane), Valid: true}
+		}
+		if req.Estimate != nil {
+			template.Estimate = sql.NullInt64{Int64: int64(*req.Estimate), Valid: true}
+		}
+
+		if req.StartsAt != "" {
+			startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+			if err != nil {
+				log.Error("invalid starts_at", slog.String("starts_at", req.StartsAt))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "starts_at must be an RFC 3339 time",
+				})
+				return
+			}
+			template.Starts_at = startsAt
+		}
+
+		err := s.boardSvc.CreateTaskTemplate(userID, template)
+
+		var ruleErr *rrule.Error
+
+		if errors.As(err, &ruleErr) {
+			log.Warn("invalid rule", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: ruleErr.Error(),
+			})
+			return
+		}
+		if errors.Is(err, board.ErrTaskTemplateEnded) {
+			log.Warn("rule has ended", slog.String("rule", req.Rule))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: board.ErrTaskTemplateEnded.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrColumnNotFound) {
+			log.Warn("column not found", slog.Int("column_id", req.IDColumn))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Column not found",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrSwimlaneNotFound) {
+			log.Warn("swimlane not found in project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Swimlane not found in the project of the column",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("executor not found", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Executor not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create task template", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to create task template",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   template,
+		})
+	}
+}
+
+// ListTaskTemplates godoc
+// @Summary Список повторяющихся задач проекта
+// @Description Возвращает шаблоны повторяющихся задач проекта с временем следующего повторения
+// @Tags TaskTemplates
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=[]model.TaskTemplate} "Шаблоны проекта"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении шаблонов"
+// @Security BearerAuth
+// @Router /api/task-templates [get]
+func (s *Server) ListTaskTemplates() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListTaskTemplates"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		templates, err := s.boardSvc.ListTaskTemplates(userID, projectID)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to list task templates", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list task templates",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   templates,
+		})
+	}
+}
+
+type SetTaskTemplateColumnRequest struct {
+	ID       int `json:"id" validate:"required"`
+	IDColumn int `json:"id_column" validate:"required"`
+}
+
+// SetTaskTemplateColumn godoc
+// @Summary Смена колонки повторяющейся задачи
+// @Description Переносит шаблон в другую колонку его проекта. Шаблон, колонка которого удалена, ждет новую колонку и задачи не создает
+// @Tags TaskTemplates
+// @Accept json
+// @Produce json
+// @Param input body SetTaskTemplateColumnRequest true "ID шаблона и колонки"
+// @Success 200 {object} response.SuccessResponse "Колонка шаблона изменена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Шаблон или колонка не найдены"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при изменении шаблона"
+// @Security BearerAuth
+// @Router /api/task-templates [put]
+func (s *Server) SetTaskTemplateColumn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.SetTaskTemplateColumn"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req SetTaskTemplateColumnRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.SetTaskTemplateColumn(userID, req.ID, req.IDColumn)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrTaskTemplateNotFound) {
+			log.Warn("task template not found", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Task template not found",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrColumnNotFound) {
+			log.Warn("column not found in project", slog.Int("column_id", req.IDColumn))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Column not found in the project of the template",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to set task template column", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to update task template",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "task template updated successfully",
+		})
+	}
+}
+
+type DeleteTaskTemplateRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteTaskTemplate godoc
+// @Summary Удаление повторяющейся задачи
+// @Description Удаляет шаблон и останавливает повторения, уже созданные задачи остаются
+// @Tags TaskTemplates
+// @Accept json
+// @Produce json
+// @Param input body DeleteTaskTemplateRequest true "ID шаблона"
+// @Success 200 {object} response.SuccessResponse "Шаблон удален"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Шаблон не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении шаблона"
+// @Security BearerAuth
+// @Router /api/task-templates [delete]
+func (s *Server) DeleteTaskTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteTaskTemplate"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req DeleteTaskTemplateRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.DeleteTaskTemplate(userID, req.ID)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrTaskTemplateNotFound) {
+			log.Warn("task template not found", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Task template not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete task template", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete task template",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "task template deleted successfully",
+		})
+	}
+}
+
+// ListTaskInstances godoc
+// @Summary История повторяющейся задачи
+// @Description Возвращает задачи, созданные по шаблону, с номером повторения, запланированным временем и ссылкой на предыдущую задачу. У удаленных задач id_task, name и status пустые
+// @Tags TaskTemplates
+// @Produce json
+// @Param id query int true "ID шаблона"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(occurrence, -occurrence)
+// @Success 200 {object} response.SuccessResponse{data=[]model.TaskInstance} "Задачи шаблона, по умолчанию новые первыми"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID или параметры страницы"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Шаблон не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении истории"
+// @Security BearerAuth
+// @Router /api/task-templates/instances [get]
+func (s *Server) ListTaskInstances() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListTaskInstances"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		instances, next, err := s.boardSvc.ListTaskInstances(userID, id, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrTaskTemplateNotFound) {
+			log.Warn("task template not found", slog.Int("id", id))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Task template not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to list task instances", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list task instances",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       instances,
+			NextCursor: next,
+		})
+	}
+}
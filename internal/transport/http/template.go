@@ -0,0 +1,324 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type CreateTemplateRequest struct {
+	Name    string                 `json:"name" validate:"required"`
+	Columns []model.TemplateColumn `json:"columns"`
+	Labels  []string               `json:"labels"`
+	// ProjectID takes the columns of the first board of the project and its
+	// labels instead of columns and labels.
+	ProjectID int `json:"id_project"`
+}
+
+// CreateTemplate godoc
+// @Summary Сохранение шаблона проекта
+// @Description Сохраняет шаблон с колонками (по порядку, с WIP-лимитами) и метками. С id_project шаблон берется из проекта: колонки его первой доски и его метки. Среди колонок должны быть in_progress и done, по которым доска меняет статус задач. Название не может совпадать со встроенным шаблоном
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param input body CreateTemplateRequest true "Данные шаблона"
+// @Success 201 {object} response.SuccessResponse{data=model.Template} "Шаблон сохранен"
+// @Failure 400 {object} response.ErrorResponse "Неверный шаблон"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден"
+// @Failure 409 {object} response.ErrorResponse "Шаблон с таким названием уже есть"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при сохранении шаблона"
+// @Security BearerAuth
+// @Router /api/templates [post]
+func (s *Server) CreateTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateTemplate"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req CreateTemplateRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		template := &model.Template{
+			ID_user: int64(userID),
+			Name:    req.Name,
+			Columns: req.Columns,
+			Labels:  req.Labels,
+		}
+
+		err := s.boardSvc.SaveTemplate(template, req.ProjectID)
+		if errors.Is(err, board.ErrInvalidTemplate) {
+			log.Warn("invalid template", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: board.ErrInvalidTemplate.Error(),
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			log.Warn("project not found", slog.Int("project_id", req.ProjectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Project not found",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrTemplateExists) {
+			log.Warn("template already exists", slog.String("name", req.Name))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "Template already exists",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to save template", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to save template",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   template,
+		})
+	}
+}
+
+// ListTemplates godoc
+// @Summary Список шаблонов проекта
+// @Description Возвращает встроенные шаблоны (Basic Kanban, Scrum) и шаблоны, сохраненные пользователем
+// @Tags Templates
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=[]model.Template} "Список шаблонов"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении шаблонов"
+// @Security BearerAuth
+// @Router /api/templates [get]
+func (s *Server) ListTemplates() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListTemplates"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		templates, err := s.boardSvc.ListTemplates(userID)
+		if err != nil {
+			log.Error("failed to list templates", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list templates",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   templates,
+		})
+	}
+}
+
+type DeleteTemplateRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteTemplate godoc
+// @Summary Удаление шаблона проекта
+// @Description Удаляет сохраненный шаблон (только свой, встроенные удалить нельзя). Проекты, созданные по шаблону, не меняются
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param input body DeleteTemplateRequest true "ID шаблона"
+// @Success 200 {object} response.SuccessResponse "Шаблон удален"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Шаблон не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении шаблона"
+// @Security BearerAuth
+// @Router /api/templates [delete]
+func (s *Server) DeleteTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteTemplate"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req DeleteTemplateRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		err := s.boardSvc.DeleteTemplate(userID, req.ID)
+		if errors.Is(err, storage.ErrTemplateNotFound) {
+			log.Warn("template not found", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Template not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete template", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete template",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "template deleted successfully",
+		})
+	}
+}
+
+type CloneProjectRequest struct {
+	// Name of the copy, the name of the project with " (copy)" by default.
+	Name string `json:"name"`
+	// WithTasks copies the live tasks too.
+	WithTasks bool `json:"with_tasks"`
+}
+
+// CloneProject godoc
+// @Summary Копия проекта
+// @Description Создает новый проект текущего пользователя с доски, колонками, WIP-лимитами, дорожками и метками проекта, в котором он работает. Архивные колонки не копируются. С with_tasks копируются и неархивные задачи с исполнителями и метками, без их истории
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Param input body CloneProjectRequest false "Параметры копии"
+// @Success 201 {object} response.SuccessResponse{data=model.Project} "Копия создана"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 409 {object} response.ErrorResponse "Проект с таким названием уже есть"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при копировании проекта"
+// @Security BearerAuth
+// @Router /api/projects/{id}/clone [post]
+func (s *Server) CloneProject() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CloneProject"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		var req CloneProjectRequest
+
+		if r.ContentLength != 0 {
+			if err := render.DecodeJSON(r.Body, &req); err != nil {
+				log.Error("failed to decode request body", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "Invalid request body",
+				})
+				return
+			}
+		}
+
+		project, err := s.boardSvc.CloneProject(userID, projectID, req.Name, req.WithTasks)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrProjectExists) {
+			log.Warn("project already exists", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "Project with this name already exists",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to clone project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to clone project",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   project,
+		})
+	}
+}
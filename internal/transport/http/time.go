@@ -0,0 +1,467 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// renderTimeError answers the errors time tracking endpoints share.
+func renderTimeError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+
+	switch {
+	case errors.Is(err, board.ErrInvalidTimeEntry):
+		log.Warn("invalid time entry", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: board.ErrInvalidTimeEntry.Error(),
+		})
+	case errors.Is(err, storage.ErrInvalidPage):
+		log.Warn("invalid page", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid cursor or sort field",
+		})
+	case errors.Is(err, service.ErrNotProjectMember):
+		log.Warn("user is not a project member", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusForbidden,
+			Message: "You do not work in this project",
+		})
+	case errors.Is(err, storage.ErrTaskNotFound):
+		log.Warn("task not found", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusNotFound,
+			Message: "Task not found",
+		})
+	case errors.Is(err, storage.ErrTimeEntryNotFound):
+		log.Warn("time entry not found", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusNotFound,
+			Message: "Time entry not found",
+		})
+	case errors.Is(err, storage.ErrTimerNotRunning):
+		log.Warn("no timer is running", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusNotFound,
+			Message: "No timer is running",
+		})
+	case errors.Is(err, storage.ErrTimerRunning):
+		log.Warn("timer already running", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusConflict,
+			Message: "A timer is already running, stop it first",
+		})
+	default:
+		log.Error("failed to track time", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusInternalServerError,
+			Message: "failed to track time",
+		})
+	}
+}
+
+type CreateTimeEntryRequest struct {
+	IDTask    int    `json:"id_task" validate:"required"`
+	StartedAt string `json:"started_at" format:"date-time" example:"2026-10-19T09:00:00Z"`
+	EndedAt   string `json:"ended_at" format:"date-time" example:"2026-10-19T10:30:00Z"`
+	// Duration is in seconds.
+	Duration int    `json:"duration" minimum:"1" example:"5400"`
+	Note     string `json:"note"`
+}
+
+// parseTime reads an optional RFC 3339 time.
+func parseTime(value string) (time.Time, error) {
+
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// CreateTimeEntry godoc
+// @Summary Запись затраченного времени
+// @Description Добавляет к задаче время текущего пользователя без таймера. Достаточно двух из started_at, ended_at и duration (в секундах): одна duration заканчивается сейчас
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param input body CreateTimeEntryRequest true "Данные записи"
+// @Success 201 {object} response.SuccessResponse{data=model.TimeEntry} "Время записано"
+// @Failure 400 {object} response.ErrorResponse "Неверное время"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Задача не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при записи времени"
+// @Security BearerAuth
+// @Router /api/tasks/time [post]
+func (s *Server) CreateTimeEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateTimeEntry"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req CreateTimeEntryRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		startedAt, err := parseTime(req.StartedAt)
+		if err != nil {
+			log.Error("invalid started_at", slog.String("started_at", req.StartedAt))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "started_at must be an RFC 3339 time",
+			})
+			return
+		}
+
+		endedAt, err := parseTime(req.EndedAt)
+		if err != nil {
+			log.Error("invalid ended_at", slog.String("ended_at", req.EndedAt))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "ended_at must be an RFC 3339 time",
+			})
+			return
+		}
+
+		entry := &model.TimeEntry{
+			ID_task:    int64(req.IDTask),
+			ID_user:    int64(userID),
+			Started_at: startedAt,
+			Ended_at:   sql.NullTime{Time: endedAt, Valid: !endedAt.IsZero()},
+			Note:       req.Note,
+		}
+
+		err = s.boardSvc.CreateTimeEntry(entry, time.Duration(req.Duration)*time.Second)
+		if err != nil {
+			renderTimeError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   entry,
+		})
+	}
+}
+
+// ListTimeEntries godoc
+// @Summary Записи времени задачи
+// @Description Возвращает записи времени всех пользователей по задаче, по умолчанию новые первыми. Длительность в секундах, у идущего таймера - до текущего момента
+// @Tags Time
+// @Produce json
+// @Param id query int true "ID задачи"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(started_at, -started_at, id, -id)
+// @Success 200 {object} response.SuccessResponse{data=[]model.TimeEntry} "Записи времени"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID или параметры страницы"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Задача не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении записей"
+// @Security BearerAuth
+// @Router /api/tasks/time [get]
+func (s *Server) ListTimeEntries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListTimeEntries"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			log.Error("failed to conv id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		entries, next, err := s.boardSvc.ListTimeEntries(userID, taskID, page)
+		if err != nil {
+			renderTimeError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       entries,
+			NextCursor: next,
+		})
+	}
+}
+
+type DeleteTimeEntryRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteTimeEntry godoc
+// @Summary Удаление записи времени
+// @Description Удаляет свою запись времени, в том числе идущий таймер
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param input body DeleteTimeEntryRequest true "ID записи"
+// @Success 200 {object} response.SuccessResponse "Запись удалена"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Запись не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении записи"
+// @Security BearerAuth
+// @Router /api/tasks/time [delete]
+func (s *Server) DeleteTimeEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteTimeEntry"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req DeleteTimeEntryRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		if err := s.boardSvc.DeleteTimeEntry(userID, req.ID); err != nil {
+			renderTimeError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "time entry deleted successfully",
+		})
+	}
+}
+
+// GetTimer godoc
+// @Summary Текущий таймер
+// @Description Возвращает идущий таймер текущего пользователя, data пустая, если таймер не запущен
+// @Tags Time
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=model.TimeEntry} "Таймер"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении таймера"
+// @Security BearerAuth
+// @Router /api/timer [get]
+func (s *Server) GetTimer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.GetTimer"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		entry, err := s.boardSvc.GetTimer(userID)
+		if errors.Is(err, storage.ErrTimerNotRunning) {
+			render.JSON(w, r, response.SuccessResponse{
+				Status:  http.StatusOK,
+				Message: "no timer is running",
+			})
+			return
+		}
+		if err != nil {
+			renderTimeError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   entry,
+		})
+	}
+}
+
+type StartTimerRequest struct {
+	IDTask int    `json:"id_task" validate:"required"`
+	Note   string `json:"note"`
+}
+
+// StartTimer godoc
+// @Summary Запуск таймера
+// @Description Запускает таймер текущего пользователя по задаче. У пользователя может идти только один таймер
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param input body StartTimerRequest true "Задача и заметка"
+// @Success 201 {object} response.SuccessResponse{data=model.TimeEntry} "Таймер запущен"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} response.ErrorResponse "Таймер уже запущен"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при запуске таймера"
+// @Security BearerAuth
+// @Router /api/timer/start [post]
+func (s *Server) StartTimer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.StartTimer"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req StartTimerRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		entry, err := s.boardSvc.StartTimer(userID, req.IDTask, req.Note)
+		if err != nil {
+			renderTimeError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   entry,
+		})
+	}
+}
+
+type StopTimerRequest struct {
+	// Note replaces the note given at the start when not empty.
+	Note string `json:"note"`
+}
+
+// StopTimer godoc
+// @Summary Остановка таймера
+// @Description Останавливает идущий таймер текущего пользователя и возвращает получившуюся запись времени
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param input body StopTimerRequest false "Заметка"
+// @Success 200 {object} response.SuccessResponse{data=model.TimeEntry} "Таймер остановлен"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Таймер не запущен"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при остановке таймера"
+// @Security BearerAuth
+// @Router /api/timer/stop [post]
+func (s *Server) StopTimer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.StopTimer"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req StopTimerRequest
+
+		if r.ContentLength != 0 {
+			if err := render.DecodeJSON(r.Body, &req); err != nil {
+				log.Error("failed to decode request body", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "Invalid request body",
+				})
+				return
+			}
+		}
+
+		entry, err := s.boardSvc.StopTimer(userID, req.Note)
+		if err != nil {
+			renderTimeError(w, r, log, err)
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   entry,
+		})
+	}
+}
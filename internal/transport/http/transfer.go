@@ -0,0 +1,217 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/service/transfer"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+// maxImportBody limits the size of an uploaded import document.
+const maxImportBody = 32 << 20
+
+// ExportProject godoc
+// @Summary Экспорт проекта
+// @Description Выгружает проект файлом. format=json - версионированный документ с проектом, досками, колонками, дорожками, метками, задачами, их логами и комментариями, включая архивные; пользователи указаны по email. format=csv - плоская таблица задач. Файл отдается потоком по мере чтения задач, все данные читаются из одного снимка базы
+// @Tags Projects
+// @Produce json,text/csv
+// @Param id path int true "ID проекта"
+// @Param format query string false "Формат файла, по умолчанию json" Enums(json, csv)
+// @Success 200 {object} model.ProjectExport "Документ проекта, за полями заголовка следуют tasks (model.ExportedTask), logs (model.ExportedLog) и comments (model.ExportedComment)"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при экспорте"
+// @Security BearerAuth
+// @Router /api/projects/{id}/export [get]
+func (s *Server) ExportProject() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ExportProject"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			log.Error("failed to conv project id", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = transfer.FormatJSON
+		}
+		if format != transfer.FormatJSON && format != transfer.FormatCSV {
+			log.Warn("unknown format", slog.String("format", format))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "format must be json or csv",
+			})
+			return
+		}
+
+		var started bool
+
+		err = s.transferSvc.Export(userID, projectID, format, func(exp *model.ProjectExport) (io.Writer, error) {
+			started = true
+
+			filename := exp.Project.Slug + ".json"
+			w.Header().Set("Content-Type", "application/json")
+			if format == transfer.FormatCSV {
+				filename = exp.Project.Slug + "-tasks.csv"
+				w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			}
+			w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+			return w, nil
+		})
+		if err != nil {
+			switch {
+			case started:
+				// The response is already under way, a failure can only cut it short.
+				log.Error("failed to write export", sl.Err(err))
+			case errors.Is(err, service.ErrNotProjectMember):
+				log.Warn("user is not a project member", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusForbidden,
+					Message: "You do not work in this project",
+				})
+			case errors.Is(err, storage.ErrProjectNotFound):
+				log.Warn("project not found", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusNotFound,
+					Message: "Project not found",
+				})
+			default:
+				log.Error("failed to export project", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusInternalServerError,
+					Message: "failed to export project",
+				})
+			}
+		}
+	}
+}
+
+// ImportProject godoc
+// @Summary Импорт проекта
+// @Description Создает новый проект текущего пользователя из файла в теле запроса: format=json - документ экспорта этого сервиса, trello - JSON-экспорт доски Trello, jira - CSV-экспорт задач Jira. Списки Trello и статусы Jira становятся колонками, статус задач определяется по их названию. Первая колонка в работе и первая завершенная колонка получают имена in_progress и done, по которым доска меняет статус задач, прежние имена возвращаются в renamed_columns; если таких колонок нет, добавляются пустые. Пользователи сопоставляются по email, неизвестные возвращаются в unknown_users: их задачи остаются без исполнителя, созданные ими записываются на импортирующего, а их комментарии сохраняются без автора. Все создается в одной транзакции, с dry_run=true ничего не сохраняется и возвращается отчет о том, что было бы создано. Размер файла - до 32 МБ
+// @Tags Projects
+// @Accept json,text/csv
+// @Produce json
+// @Param format query string false "Формат файла, по умолчанию json" Enums(json, trello, jira)
+// @Param name query string false "Название проекта вместо указанного в файле"
+// @Param dry_run query bool false "Только проверить и посчитать"
+// @Param file body string true "Содержимое файла"
+// @Success 200 {object} response.SuccessResponse{data=model.ImportReport} "Отчет пробного импорта"
+// @Success 201 {object} response.SuccessResponse{data=model.ImportReport} "Проект создан"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат или содержимое файла"
+// @Failure 409 {object} response.ErrorResponse "Проект с таким названием уже есть"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при импорте"
+// @Security BearerAuth
+// @Router /api/projects/import [post]
+func (s *Server) ImportProject() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ImportProject"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = transfer.FormatJSON
+		}
+
+		var dryRun bool
+		if v := r.URL.Query().Get("dry_run"); v != "" {
+			var err error
+			if dryRun, err = strconv.ParseBool(v); err != nil {
+				log.Warn("invalid dry_run", slog.String("dry_run", v))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "dry_run must be true or false",
+				})
+				return
+			}
+		}
+
+		body := http.MaxBytesReader(w, r.Body, maxImportBody)
+
+		report, err := s.transferSvc.Import(userID, format, body, r.URL.Query().Get("name"), dryRun)
+		if err != nil {
+			var docErr *transfer.DocumentError
+
+			switch {
+			case errors.Is(err, transfer.ErrUnknownFormat):
+				log.Warn("unknown format", slog.String("format", format))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: "format must be json, trello or jira",
+				})
+			case errors.As(err, &docErr):
+				log.Warn("invalid import document", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusBadRequest,
+					Message: docErr.Error(),
+				})
+			case errors.Is(err, storage.ErrProjectExists):
+				log.Warn("project already exists", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusConflict,
+					Message: "Project with this name already exists",
+				})
+			default:
+				log.Error("failed to import project", sl.Err(err))
+				render.JSON(w, r, response.ErrorResponse{
+					Status:  http.StatusInternalServerError,
+					Message: "failed to import project",
+				})
+			}
+			return
+		}
+
+		status := http.StatusCreated
+		if dryRun {
+			status = http.StatusOK
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: status,
+			Data:   report,
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/service"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type TrashRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// TrashColumn godoc
+// @Summary Удаление колонки в корзину
+// @Description Удаляет колонку вместе со всеми её задачами, их историей и метками. Удаление можно отменить, пока не истекло окно отмены
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param input body TrashRequest true "ID колонки"
+// @Success 200 {object} response.SuccessResponse{data=response.TrashItem} "Колонка перемещена в корзину"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте колонки"
+// @Failure 404 {object} response.ErrorResponse "Колонка не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении колонки"
+// @Security BearerAuth
+// @Router /api/trash/columns [post]
+func (s *Server) TrashColumn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.TrashColumn"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req TrashRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("moving column to trash",
+			slog.Int("column_id", req.ID),
+			slog.Int("user_id", userID),
+		)
+
+		item, err := s.boardSvc.TrashColumn(userID, req.ID)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", slog.Int("column_id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrColumnNotFound) {
+			log.Warn("column not found", slog.Int("column_id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Column not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to move column to trash", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete column",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "column moved to trash",
+			Data:    item,
+		})
+	}
+}
+
+// TrashTask godoc
+// @Summary Удаление задачи в корзину
+// @Description Удаляет задачу вместе с её историей и метками. Удаление можно отменить, пока не истекло окно отмены
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param input body TrashRequest true "ID задачи"
+// @Success 200 {object} response.SuccessResponse{data=response.TrashItem} "Задача перемещена в корзину"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Задача не найдена"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении задачи"
+// @Security BearerAuth
+// @Router /api/trash/tasks [post]
+func (s *Server) TrashTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.TrashTask"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req TrashRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("moving task to trash",
+			slog.Int("task_id", req.ID),
+			slog.Int("user_id", userID),
+		)
+
+		item, err := s.boardSvc.TrashTask(userID, req.ID)
+		if errors.Is(err, storage.ErrTaskNotFound) {
+			log.Warn("task not found", slog.Int("task_id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Task not found",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to move task to trash", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to delete task",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "task moved to trash",
+			Data:    item,
+		})
+	}
+}
+
+// ListTrash godoc
+// @Summary Корзина проекта
+// @Description Возвращает удаленные колонки и задачи проекта, которые еще можно восстановить
+// @Tags Trash
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(deleted, -deleted, id, -id)
+// @Success 200 {object} response.SuccessResponse{data=[]response.TrashItem} "Содержимое корзины"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении корзины"
+// @Security BearerAuth
+// @Router /api/trash [get]
+func (s *Server) ListTrash() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListTrash"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		items, next, err := s.boardSvc.ListTrash(userID, projectID, page)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", slog.Int("project_id", projectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to list trash", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list trash",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       items,
+			NextCursor: next,
+		})
+	}
+}
+
+type RestoreFromTrashRequest struct {
+	ID int `json:"id" validate:"required"`
+	// ColumnID is used for a task when its original column is gone.
+	ColumnID int `json:"id_column"`
+}
+
+// RestoreFromTrash godoc
+// @Summary Отмена удаления
+// @Description Восстанавливает колонку или задачу из корзины с прежними ID. Задача возвращается в исходную колонку, а если её уже нет - в колонку id_column того же проекта
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param input body RestoreFromTrashRequest true "ID элемента корзины и запасная колонка"
+// @Success 200 {object} response.SuccessResponse "Восстановлено из корзины"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не работает в проекте"
+// @Failure 404 {object} response.ErrorResponse "Элемент корзины не найден или окно отмены истекло"
+// @Failure 409 {object} response.ErrorResponse "Некуда восстановить или достигнут WIP-лимит колонки"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при восстановлении"
+// @Security BearerAuth
+// @Router /api/trash/restore [post]
+func (s *Server) RestoreFromTrash() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.RestoreFromTrash"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req RestoreFromTrashRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		log.Info("restoring from trash",
+			slog.Int("id", req.ID),
+			slog.Int("fallback_column_id", req.ColumnID),
+			slog.Int("user_id", userID),
+		)
+
+		err := s.boardSvc.RestoreFromTrash(userID, req.ID, req.ColumnID)
+		if errors.Is(err, service.ErrNotProjectMember) {
+			log.Warn("user is not a project member", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusForbidden,
+				Message: "You do not work in this project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrTrashItemNotFound) {
+			log.Warn("trash item not found", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Trash item not found or undo window is over",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrColumnNotFound) || errors.Is(err, storage.ErrBoardNotFound) {
+			log.Warn("nowhere to restore", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "Original column or board is gone, pass id_column of the same project",
+			})
+			return
+		}
+		if errors.Is(err, storage.ErrWIPLimitExceeded) {
+			log.Warn("wip limit reached", slog.Int("id", req.ID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusConflict,
+				Message: "WIP limit of the column is reached",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore from trash", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Failed to restore from trash",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "restored from trash",
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/wehw93/kanban-board/internal/lib/http/response"
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+	"github.com/wehw93/kanban-board/internal/model"
+	"github.com/wehw93/kanban-board/internal/service/board"
+	"github.com/wehw93/kanban-board/internal/storage"
+)
+
+type CreateWebhookRequest struct {
+	ProjectID int    `json:"id_project" validate:"required"`
+	URL       string `json:"url" validate:"required"`
+	// Secret signs the deliveries, one is generated when it's empty.
+	Secret string `json:"secret"`
+	// Events are the event types to send, empty means all of them.
+	Events []string `json:"events"`
+	// Active defaults to true.
+	Active *bool `json:"active"`
+}
+
+// CreateWebhook godoc
+// @Summary Создание вебхука
+// @Description Подписывает URL на события проекта. Каждая доставка - POST с событием в теле и заголовками X-Board-Event, X-Board-Delivery, X-Board-Timestamp и X-Board-Signature = sha256=HMAC-SHA256(secret, timestamp + "." + тело) в hex. Неудачные доставки повторяются с экспоненциальной задержкой. Секрет возвращается только в ответе на создание. Доступно только создателю проекта
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param input body CreateWebhookRequest true "Данные вебхука"
+// @Success 201 {object} response.SuccessResponse{data=model.Webhook} "Вебхук создан"
+// @Failure 400 {object} response.ErrorResponse "Неверный URL или тип события"
+// @Failure 404 {object} response.ErrorResponse "Проект не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при создании вебхука"
+// @Security BearerAuth
+// @Router /api/webhooks [post]
+func (s *Server) CreateWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.CreateWebhook"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req CreateWebhookRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		webhook := &model.Webhook{
+			ID_project: int64(req.ProjectID),
+			URL:        req.URL,
+			Secret:     req.Secret,
+			Events:     req.Events,
+			Active:     req.Active == nil || *req.Active,
+		}
+
+		err := s.boardSvc.CreateWebhook(userID, webhook)
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			log.Warn("project not found", slog.Int("project_id", req.ProjectID))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusNotFound,
+				Message: "Project not found",
+			})
+			return
+		}
+		if err != nil {
+			s.renderWebhookError(w, r, log, err, "Failed to create webhook")
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusCreated,
+			Data:   webhook,
+		})
+	}
+}
+
+// ListWebhooks godoc
+// @Summary Вебхуки проекта
+// @Description Возвращает вебхуки проекта без секретов. Доступно только создателю проекта
+// @Tags Webhooks
+// @Produce json
+// @Param id_project query int true "ID проекта"
+// @Success 200 {object} response.SuccessResponse{data=[]model.Webhook} "Список вебхуков"
+// @Failure 400 {object} response.ErrorResponse "Неверный ID проекта"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении вебхуков"
+// @Security BearerAuth
+// @Router /api/webhooks [get]
+func (s *Server) ListWebhooks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListWebhooks"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(r.URL.Query().Get("id_project"))
+		if err != nil {
+			log.Error("failed to conv id_project", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		webhooks, err := s.boardSvc.ListWebhooks(userID, projectID)
+		if err != nil {
+			log.Error("failed to list webhooks", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to list webhooks",
+			})
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   webhooks,
+		})
+	}
+}
+
+type UpdateWebhookRequest struct {
+	ID     int       `json:"id" validate:"required"`
+	URL    *string   `json:"url"`
+	Secret *string   `json:"secret"`
+	Events *[]string `json:"events"`
+	Active *bool     `json:"active"`
+}
+
+// UpdateWebhook godoc
+// @Summary Изменение вебхука
+// @Description Меняет переданные поля вебхука, остальные остаются прежними. Пустой список events подписывает на все события
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param input body UpdateWebhookRequest true "Новые значения"
+// @Success 200 {object} response.SuccessResponse "Вебхук изменен"
+// @Failure 400 {object} response.ErrorResponse "Неверный URL или тип события"
+// @Failure 404 {object} response.ErrorResponse "Вебхук не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при изменении вебхука"
+// @Security BearerAuth
+// @Router /api/webhooks [put]
+func (s *Server) UpdateWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.UpdateWebhook"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req UpdateWebhookRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		webhook, err := s.boardSvc.GetWebhook(userID, req.ID)
+		if err == nil {
+			if req.URL != nil {
+				webhook.URL = *req.URL
+			}
+			if req.Secret != nil && *req.Secret != "" {
+				webhook.Secret = *req.Secret
+			}
+			if req.Events != nil {
+				webhook.Events = *req.Events
+			}
+			if req.Active != nil {
+				webhook.Active = *req.Active
+			}
+			err = s.boardSvc.UpdateWebhook(userID, *webhook)
+		}
+		if err != nil {
+			s.renderWebhookError(w, r, log, err, "Failed to update webhook")
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "webhook updated successfully",
+		})
+	}
+}
+
+type WebhookIDRequest struct {
+	ID int `json:"id" validate:"required"`
+}
+
+// DeleteWebhook godoc
+// @Summary Удаление вебхука
+// @Description Удаляет вебхук вместе с журналом и очередью его доставок
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param input body WebhookIDRequest true "ID вебхука"
+// @Success 200 {object} response.SuccessResponse "Вебхук удален"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Вебхук не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при удалении вебхука"
+// @Security BearerAuth
+// @Router /api/webhooks [delete]
+func (s *Server) DeleteWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.DeleteWebhook"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req WebhookIDRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		if err := s.boardSvc.DeleteWebhook(userID, req.ID); err != nil {
+			s.renderWebhookError(w, r, log, err, "Failed to delete webhook")
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "webhook deleted successfully",
+		})
+	}
+}
+
+// ListWebhookDeliveries godoc
+// @Summary Журнал доставок вебхука
+// @Description Возвращает доставки вебхука, новые первыми: статус, число попыток, время следующей попытки, код ответа и последнюю ошибку
+// @Tags Webhooks
+// @Produce json
+// @Param id_webhook query int true "ID вебхука"
+// @Param limit query int false "Размер страницы, по умолчанию 20, не больше 100"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Param sort query string false "Поле сортировки, -поле по убыванию" Enums(id, -id)
+// @Success 200 {object} response.SuccessResponse{data=[]model.WebhookDelivery} "Доставки"
+// @Failure 400 {object} response.ErrorResponse "Неверные параметры"
+// @Failure 404 {object} response.ErrorResponse "Вебхук не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при получении журнала"
+// @Security BearerAuth
+// @Router /api/webhooks/deliveries [get]
+func (s *Server) ListWebhookDeliveries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.ListWebhookDeliveries"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		webhookID, err := strconv.Atoi(r.URL.Query().Get("id_webhook"))
+		if err != nil {
+			log.Error("failed to conv id_webhook", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("failed to parse page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+			})
+			return
+		}
+
+		deliveries, next, err := s.boardSvc.ListWebhookDeliveries(userID, webhookID, page)
+		if errors.Is(err, storage.ErrInvalidPage) {
+			log.Warn("invalid page", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid cursor or sort field",
+			})
+			return
+		}
+		if err != nil {
+			s.renderWebhookError(w, r, log, err, "failed to list deliveries")
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status:     http.StatusOK,
+			Data:       deliveries,
+			NextCursor: next,
+		})
+	}
+}
+
+// TestWebhook godoc
+// @Summary Тестовое событие
+// @Description Сразу отправляет вебхуку событие ping и возвращает результат доставки. Неудачная доставка повторяется как обычная
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param input body WebhookIDRequest true "ID вебхука"
+// @Success 200 {object} response.SuccessResponse{data=model.WebhookDelivery} "Результат доставки"
+// @Failure 400 {object} response.ErrorResponse "Неверный формат запроса"
+// @Failure 404 {object} response.ErrorResponse "Вебхук не найден"
+// @Failure 500 {object} response.ErrorResponse "Ошибка при отправке"
+// @Security BearerAuth
+// @Router /api/webhooks/test [post]
+func (s *Server) TestWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		const op = "http.TestWebhook"
+
+		log := s.logger.With(slog.String("op", op))
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			log.Error("failed to get userID from context")
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Message: "Internal server error",
+			})
+			return
+		}
+
+		var req WebhookIDRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			render.JSON(w, r, response.ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid request body",
+			})
+			return
+		}
+
+		delivery, err := s.boardSvc.TestWebhook(r.Context(), userID, req.ID)
+		if err != nil {
+			s.renderWebhookError(w, r, log, err, "Failed to send test event")
+			return
+		}
+
+		render.JSON(w, r, response.SuccessResponse{
+			Status: http.StatusOK,
+			Data:   delivery,
+		})
+	}
+}
+
+// renderWebhookError maps the errors shared by the webhook handlers.
+func (s *Server) renderWebhookError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error, message string) {
+
+	switch {
+	case errors.Is(err, storage.ErrWebhookNotFound):
+		log.Warn("webhook not found", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusNotFound,
+			Message: "Webhook not found",
+		})
+	case errors.Is(err, board.ErrInvalidWebhookURL), errors.Is(err, board.ErrUnknownEventType):
+		log.Warn("invalid webhook", sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: errors.Unwrap(err).Error(),
+		})
+	default:
+		log.Error(message, sl.Err(err))
+		render.JSON(w, r, response.ErrorResponse{
+			Status:  http.StatusInternalServerError,
+			Message: message,
+		})
+	}
+}
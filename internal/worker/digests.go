@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type DigestMailer interface {
+	SendDigests(ctx context.Context) (int, error)
+}
+
+// DigestSender mails the daily and weekly digests that are due.
+type DigestSender struct {
+	mailer DigestMailer
+	log    *slog.Logger
+}
+
+func NewDigestSender(mailer DigestMailer, log *slog.Logger) *DigestSender {
+	return &DigestSender{
+		mailer: mailer,
+		log:    log,
+	}
+}
+
+func (d *DigestSender) Name() string {
+	return "digest_sender"
+}
+
+func (d *DigestSender) Run(ctx context.Context) error {
+
+	const op = "worker.digests.Run"
+
+	sent, err := d.mailer.SendDigests(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if sent > 0 {
+		d.log.Info("sent digests", slog.Int("count", sent))
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type EventPurger interface {
+	PurgeEvents(before time.Time) (int64, error)
+}
+
+// EventCleanup deletes board events too old for a client to resume from.
+type EventCleanup struct {
+	purger    EventPurger
+	retention time.Duration
+	log       *slog.Logger
+}
+
+func NewEventCleanup(purger EventPurger, retention time.Duration, log *slog.Logger) *EventCleanup {
+	return &EventCleanup{
+		purger:    purger,
+		retention: retention,
+		log:       log,
+	}
+}
+
+func (e *EventCleanup) Name() string {
+	return "event_cleanup"
+}
+
+func (e *EventCleanup) Run(ctx context.Context) error {
+
+	const op = "worker.events.Run"
+
+	purged, err := e.purger.PurgeEvents(time.Now().Add(-e.retention))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if purged > 0 {
+		e.log.Info("purged old events", slog.Int64("count", purged))
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type TaskSpawner interface {
+	SpawnRecurringTasks(ctx context.Context) (int, error)
+}
+
+// RecurringTasks creates the tasks of task templates whose rule came due.
+type RecurringTasks struct {
+	spawner TaskSpawner
+	log     *slog.Logger
+}
+
+func NewRecurringTasks(spawner TaskSpawner, log *slog.Logger) *RecurringTasks {
+	return &RecurringTasks{
+		spawner: spawner,
+		log:     log,
+	}
+}
+
+func (r *RecurringTasks) Name() string {
+	return "recurring_tasks"
+}
+
+func (r *RecurringTasks) Run(ctx context.Context) error {
+
+	const op = "worker.recurring.Run"
+
+	created, err := r.spawner.SpawnRecurringTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if created > 0 {
+		r.log.Info("created recurring tasks", slog.Int("count", created))
+	}
+
+	return nil
+}
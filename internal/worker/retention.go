@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type ArchivePurger interface {
+	PurgeArchived(before time.Time) (int64, error)
+}
+
+// Retention deletes projects, columns and tasks that have been archived
+// for longer than the retention period.
+type Retention struct {
+	purger    ArchivePurger
+	retention time.Duration
+	log       *slog.Logger
+}
+
+func NewRetention(purger ArchivePurger, retention time.Duration, log *slog.Logger) *Retention {
+	return &Retention{
+		purger:    purger,
+		retention: retention,
+		log:       log,
+	}
+}
+
+func (r *Retention) Name() string {
+	return "retention"
+}
+
+func (r *Retention) Run(ctx context.Context) error {
+
+	const op = "worker.retention.Run"
+
+	purged, err := r.purger.PurgeArchived(time.Now().Add(-r.retention))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if purged > 0 {
+		r.log.Info("purged archived rows", slog.Int64("count", purged))
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type ColumnSnapshotter interface {
+	SnapshotColumns() (int64, error)
+}
+
+// ColumnSnapshots records the daily task counts of columns for the
+// cumulative flow diagram.
+type ColumnSnapshots struct {
+	snapshotter ColumnSnapshotter
+	log         *slog.Logger
+}
+
+func NewColumnSnapshots(snapshotter ColumnSnapshotter, log *slog.Logger) *ColumnSnapshots {
+	return &ColumnSnapshots{
+		snapshotter: snapshotter,
+		log:         log,
+	}
+}
+
+func (c *ColumnSnapshots) Name() string {
+	return "column_snapshots"
+}
+
+func (c *ColumnSnapshots) Run(ctx context.Context) error {
+
+	const op = "worker.snapshots.Run"
+
+	recorded, err := c.snapshotter.SnapshotColumns()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.log.Debug("recorded column snapshots", slog.Int64("count", recorded))
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type TrashPurger interface {
+	PurgeExpiredTrash() (int64, error)
+}
+
+// TrashCleanup deletes trash items that can't be restored anymore.
+type TrashCleanup struct {
+	purger TrashPurger
+	log    *slog.Logger
+}
+
+func NewTrashCleanup(purger TrashPurger, log *slog.Logger) *TrashCleanup {
+	return &TrashCleanup{
+		purger: purger,
+		log:    log,
+	}
+}
+
+func (t *TrashCleanup) Name() string {
+	return "trash_cleanup"
+}
+
+func (t *TrashCleanup) Run(ctx context.Context) error {
+
+	const op = "worker.trash.Run"
+
+	purged, err := t.purger.PurgeExpiredTrash()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if purged > 0 {
+		t.log.Info("purged expired trash", slog.Int64("count", purged))
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type WebhookDeliverer interface {
+	DeliverWebhooks(ctx context.Context) (int, error)
+}
+
+// WebhookDispatcher sends the due deliveries from the webhook outbox.
+type WebhookDispatcher struct {
+	deliverer WebhookDeliverer
+	log       *slog.Logger
+}
+
+func NewWebhookDispatcher(deliverer WebhookDeliverer, log *slog.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		deliverer: deliverer,
+		log:       log,
+	}
+}
+
+func (d *WebhookDispatcher) Name() string {
+	return "webhook_dispatcher"
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) error {
+
+	const op = "worker.webhooks.Run"
+
+	delivered, err := d.deliverer.DeliverWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if delivered > 0 {
+		d.log.Debug("delivered webhooks", slog.Int("count", delivered))
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/wehw93/kanban-board/internal/lib/logger/sl"
+)
+
+// Job is a piece of background work that is repeated on a schedule.
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// Run executes the job right away and then every interval until ctx is done.
+// A failed run is logged and doesn't stop the schedule.
+func Run(ctx context.Context, log *slog.Logger, job Job, interval time.Duration) {
+
+	log = log.With(slog.String("job", job.Name()))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(ctx); err != nil {
+			log.Error("job failed", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE projects DROP CONSTRAINT projects_slug_key;
+
+ALTER TABLE projects DROP COLUMN slug;
+
+ALTER TABLE projects DROP CONSTRAINT projects_creator_name_key;
@@ -0,0 +1,15 @@
+UPDATE projects p SET name = left(p.name, 230) || ' (' || p.id || ')'
+WHERE EXISTS (SELECT 1 FROM projects q WHERE q.id_creator = p.id_creator and q.name = p.name and q.id < p.id);
+
+ALTER TABLE projects ADD CONSTRAINT projects_creator_name_key UNIQUE (id_creator, name);
+
+ALTER TABLE projects ADD COLUMN slug VARCHAR(80);
+
+UPDATE projects SET slug = coalesce(nullif(trim(both '-' from left(lower(regexp_replace(name, '[^a-zA-Z0-9]+', '-', 'g')), 64)), ''), 'project');
+
+UPDATE projects p SET slug = p.slug || '-' || p.id
+WHERE EXISTS (SELECT 1 FROM projects q WHERE q.slug = p.slug and q.id < p.id);
+
+ALTER TABLE projects ALTER COLUMN slug SET NOT NULL;
+
+ALTER TABLE projects ADD CONSTRAINT projects_slug_key UNIQUE (slug);
@@ -0,0 +1,13 @@
+CREATE TABLE events(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    type TEXT NOT NULL,
+    id_user BIGINT,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX events_id_project_id_idx ON events (id_project, id);
+CREATE INDEX events_created_at_idx ON events (created_at);
@@ -0,0 +1,35 @@
+CREATE TABLE webhooks(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    -- an empty list subscribes to every event type
+    events TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhooks_id_project_idx ON webhooks (id_project);
+
+-- webhook_deliveries is both the outbox the dispatcher works from and the
+-- delivery log of every webhook.
+CREATE TABLE webhook_deliveries(
+    id BIGSERIAL PRIMARY KEY,
+    id_webhook BIGINT NOT NULL,
+    id_event BIGINT,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    response_code INT,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ,
+    FOREIGN KEY (id_webhook) REFERENCES webhooks(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_event) REFERENCES events(id) ON DELETE SET NULL
+);
+
+CREATE INDEX webhook_deliveries_id_webhook_id_idx ON webhook_deliveries (id_webhook, id);
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
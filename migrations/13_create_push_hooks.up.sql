@@ -0,0 +1,7 @@
+-- push_hooks holds the secret a git host signs pushes to a project with.
+CREATE TABLE push_hooks(
+    id_project BIGINT PRIMARY KEY,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);
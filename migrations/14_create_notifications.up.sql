@@ -0,0 +1,28 @@
+CREATE TABLE notifications(
+    id BIGSERIAL PRIMARY KEY,
+    id_user BIGINT NOT NULL,
+    type TEXT NOT NULL,
+    id_project BIGINT NOT NULL,
+    id_task BIGINT NOT NULL,
+    id_actor BIGINT,
+    message TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    read_at TIMESTAMPTZ,
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_task) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_actor) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX notifications_id_user_id_idx ON notifications (id_user, id);
+CREATE INDEX notifications_unread_idx ON notifications (id_user) WHERE read_at IS NULL;
+
+-- notification_preferences only holds the choices a user made, a type
+-- without a row notifies.
+CREATE TABLE notification_preferences(
+    id_user BIGINT NOT NULL,
+    type TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (id_user, type),
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS digest_settings;
+DROP INDEX IF EXISTS tasks_id_executor_idx;
@@ -0,0 +1,10 @@
+CREATE INDEX tasks_id_executor_idx ON tasks (id_executor) WHERE archived_at IS NULL;
+
+-- digest_settings only holds users who changed the default weekly digest
+-- or were sent one.
+CREATE TABLE digest_settings(
+    id_user BIGINT PRIMARY KEY,
+    frequency TEXT NOT NULL DEFAULT 'weekly' CHECK (frequency IN ('off', 'daily', 'weekly')),
+    last_sent_at TIMESTAMPTZ,
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE CASCADE
+);
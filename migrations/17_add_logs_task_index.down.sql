@@ -0,0 +1 @@
+DROP INDEX IF EXISTS logs_id_task_idx;
@@ -0,0 +1,2 @@
+-- Metrics read the history of every done task of a project.
+CREATE INDEX logs_id_task_idx ON logs (id_task, date_of_operation);
@@ -0,0 +1,15 @@
+-- column_snapshots keeps the number of tasks in every column at the end of
+-- each day. id_column has no foreign key, the history of a deleted column
+-- stays in the project.
+CREATE TABLE column_snapshots(
+    day DATE NOT NULL,
+    id_column BIGINT NOT NULL,
+    id_board BIGINT NOT NULL,
+    id_project BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    tasks INT NOT NULL,
+    PRIMARY KEY (day, id_column),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX column_snapshots_id_project_day_idx ON column_snapshots (id_project, day);
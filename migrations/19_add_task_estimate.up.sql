@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN estimate INT CHECK (estimate >= 0);
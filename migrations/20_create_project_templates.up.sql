@@ -0,0 +1,12 @@
+-- project_templates holds the templates users saved, the built-in ones
+-- live in the code.
+CREATE TABLE project_templates(
+    id BIGSERIAL PRIMARY KEY,
+    id_user BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    columns JSONB NOT NULL,
+    labels TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (id_user, name),
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE CASCADE
+);
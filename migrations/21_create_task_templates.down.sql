@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_instances;
+DROP TABLE IF EXISTS task_templates;
@@ -0,0 +1,43 @@
+-- task_templates create a new task in their column every time their
+-- recurrence rule comes due. Without a column, e.g. after it went to the
+-- trash, the template waits until it is given another one.
+CREATE TABLE task_templates(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    id_column BIGINT,
+    id_creator BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority TEXT NOT NULL DEFAULT 'medium',
+    id_executor BIGINT,
+    id_swimlane BIGINT,
+    estimate INT CHECK (estimate >= 0),
+    rule TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    -- next_at is NULL once the rule has ended
+    next_at TIMESTAMPTZ,
+    created_count INT NOT NULL DEFAULT 0,
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_column) REFERENCES columns(id) ON DELETE SET NULL,
+    FOREIGN KEY (id_creator) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_executor) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (id_swimlane) REFERENCES swimlanes(id) ON DELETE SET NULL
+);
+
+CREATE INDEX task_templates_next_at_idx ON task_templates (next_at) WHERE next_at IS NOT NULL;
+
+-- task_instances links the tasks created from a template in the order they
+-- were created, the row stays when the task is deleted.
+CREATE TABLE task_instances(
+    id_template BIGINT NOT NULL,
+    occurrence INT NOT NULL,
+    id_task BIGINT,
+    id_previous BIGINT,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (id_template, occurrence),
+    FOREIGN KEY (id_template) REFERENCES task_templates(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_task) REFERENCES tasks(id) ON DELETE SET NULL,
+    FOREIGN KEY (id_previous) REFERENCES tasks(id) ON DELETE SET NULL
+);
+
+CREATE INDEX task_instances_id_task_idx ON task_instances (id_task);
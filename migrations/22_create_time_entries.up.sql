@@ -0,0 +1,17 @@
+CREATE TABLE time_entries(
+    id BIGSERIAL PRIMARY KEY,
+    id_task BIGINT NOT NULL,
+    id_user BIGINT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    -- ended_at is NULL while the timer runs
+    ended_at TIMESTAMPTZ,
+    note TEXT NOT NULL DEFAULT '',
+    CHECK (ended_at >= started_at),
+    FOREIGN KEY (id_task) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- a user runs one timer at a time
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (id_user) WHERE ended_at IS NULL;
+
+CREATE INDEX time_entries_id_task_idx ON time_entries (id_task);
@@ -0,0 +1,13 @@
+-- comments are notes left on a task by its people or, for pushed commits,
+-- on their behalf. A comment stays when its author's account is deleted.
+CREATE TABLE comments(
+    id BIGSERIAL PRIMARY KEY,
+    id_task BIGINT NOT NULL,
+    id_author BIGINT,
+    body TEXT NOT NULL CHECK (body <> ''),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (id_task) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_author) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX comments_id_task_idx ON comments (id_task);
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS comments_search_update ON comments;
+DROP FUNCTION IF EXISTS comments_search_update();
+DROP TRIGGER IF EXISTS tasks_search_update ON tasks;
+DROP FUNCTION IF EXISTS tasks_search_update();
+
+DROP INDEX IF EXISTS tasks_search_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search;
+ALTER TABLE tasks ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A') ||
+    setweight(to_tsvector('simple', description), 'B')
+) STORED;
+
+CREATE INDEX tasks_search_idx ON tasks USING GIN (search);
@@ -0,0 +1,42 @@
+-- search now covers the comments of the task too. A generated column can't
+-- read other tables, so triggers keep it up to date instead.
+DROP INDEX IF EXISTS tasks_search_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search;
+ALTER TABLE tasks ADD COLUMN search tsvector NOT NULL DEFAULT '';
+
+CREATE FUNCTION tasks_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search :=
+        setweight(to_tsvector('simple', NEW.name), 'A') ||
+        setweight(to_tsvector('simple', NEW.description), 'B') ||
+        setweight(to_tsvector('simple', coalesce(
+            (SELECT string_agg(body, ' ' ORDER BY id) FROM comments WHERE id_task = NEW.id), ''
+        )), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_search_update
+BEFORE INSERT OR UPDATE OF name, description ON tasks
+FOR EACH ROW EXECUTE FUNCTION tasks_search_update();
+
+-- a changed comment rebuilds the search of its task through the trigger above
+CREATE FUNCTION comments_search_update() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE tasks SET name = name WHERE id = OLD.id_task;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        UPDATE tasks SET name = name WHERE id = NEW.id_task;
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER comments_search_update
+AFTER INSERT OR UPDATE OF body, id_task OR DELETE ON comments
+FOR EACH ROW EXECUTE FUNCTION comments_search_update();
+
+UPDATE tasks SET name = name;
+
+CREATE INDEX tasks_search_idx ON tasks USING GIN (search);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS logs_status_idx;
+ALTER TABLE logs DROP COLUMN IF EXISTS status;
@@ -0,0 +1,9 @@
+-- status is the status a log entry moved the task to, NULL for entries of
+-- other kinds. Reports read it instead of the text of the entry.
+ALTER TABLE logs ADD COLUMN status TEXT;
+
+UPDATE logs
+SET status = substring(info from '^switch status from .*to (todo|in_progress|done)$')
+WHERE info LIKE 'switch status from %';
+
+CREATE INDEX logs_status_idx ON logs (id_task, status) WHERE status IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE task_templates
+    DROP COLUMN IF EXISTS retry_at,
+    DROP COLUMN IF EXISTS failures;
@@ -0,0 +1,5 @@
+-- A repeat that fails to spawn, e.g. on a column at its WIP limit, is
+-- retried with a growing delay so it can't hold up the templates behind it.
+ALTER TABLE task_templates
+    ADD COLUMN failures INT NOT NULL DEFAULT 0,
+    ADD COLUMN retry_at TIMESTAMPTZ;
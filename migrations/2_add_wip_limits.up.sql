@@ -0,0 +1 @@
+ALTER TABLE columns ADD COLUMN wip_limit INT CHECK (wip_limit > 0);
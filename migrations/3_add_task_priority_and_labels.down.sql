@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_labels;
+
+DROP TABLE IF EXISTS labels;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,18 @@
+ALTER TABLE tasks ADD COLUMN priority TEXT NOT NULL DEFAULT 'medium'
+    CHECK (priority IN ('low', 'medium', 'high', 'urgent'));
+
+CREATE TABLE labels(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    UNIQUE (id_project, name),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE TABLE task_labels(
+    id_task BIGINT NOT NULL,
+    id_label BIGINT NOT NULL,
+    PRIMARY KEY (id_task, id_label),
+    FOREIGN KEY (id_task) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_label) REFERENCES labels(id) ON DELETE CASCADE
+);
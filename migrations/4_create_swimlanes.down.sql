@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS id_swimlane;
+
+DROP TABLE IF EXISTS swimlanes;
@@ -0,0 +1,10 @@
+CREATE TABLE swimlanes(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+ALTER TABLE tasks ADD COLUMN id_swimlane BIGINT
+    REFERENCES swimlanes(id) ON DELETE SET NULL;
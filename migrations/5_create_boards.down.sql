@@ -0,0 +1,3 @@
+ALTER TABLE columns DROP COLUMN IF EXISTS id_board;
+
+DROP TABLE IF EXISTS boards;
@@ -0,0 +1,23 @@
+CREATE TABLE boards(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    UNIQUE (id, id_project),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+INSERT INTO boards (id_project, name)
+SELECT id, 'Default' FROM projects;
+
+ALTER TABLE columns ADD COLUMN id_board BIGINT;
+
+UPDATE columns c
+SET id_board = b.id
+FROM boards b
+WHERE b.id_project = c.id_project;
+
+ALTER TABLE columns ALTER COLUMN id_board SET NOT NULL;
+
+-- the pair keeps columns.id_project equal to the project of the board
+ALTER TABLE columns ADD FOREIGN KEY (id_board, id_project)
+    REFERENCES boards(id, id_project) ON DELETE CASCADE;
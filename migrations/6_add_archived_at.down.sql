@@ -0,0 +1,5 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
+
+ALTER TABLE columns DROP COLUMN IF EXISTS archived_at;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,11 @@
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMPTZ;
+
+ALTER TABLE columns ADD COLUMN archived_at TIMESTAMPTZ;
+
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX projects_archived_at_idx ON projects (archived_at) WHERE archived_at IS NOT NULL;
+
+CREATE INDEX columns_archived_at_idx ON columns (archived_at) WHERE archived_at IS NOT NULL;
+
+CREATE INDEX tasks_archived_at_idx ON tasks (archived_at) WHERE archived_at IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE trash(
+    id BIGSERIAL PRIMARY KEY,
+    id_project BIGINT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('column', 'task')),
+    name TEXT NOT NULL,
+    id_user BIGINT,
+    snapshot JSONB NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX trash_id_project_deleted_at_idx ON trash (id_project, deleted_at);
@@ -0,0 +1,13 @@
+CREATE TABLE filters(
+    id BIGSERIAL PRIMARY KEY,
+    id_user BIGINT NOT NULL,
+    id_project BIGINT,
+    name VARCHAR(255) NOT NULL,
+    query TEXT NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT false,
+    UNIQUE (id_user, name),
+    -- only filters scoped to a project can be shared with people working in it
+    CHECK (NOT shared OR id_project IS NOT NULL),
+    FOREIGN KEY (id_user) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (id_project) REFERENCES projects(id) ON DELETE CASCADE
+);